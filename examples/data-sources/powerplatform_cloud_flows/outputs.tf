@@ -0,0 +1,4 @@
+output "cloud_flows_turned_off" {
+  description = "Names of the cloud flows that are turned off"
+  value       = [for flow in data.powerplatform_cloud_flows.all.cloud_flows : flow.name if flow.state == "Off"]
+}
@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+data "powerplatform_connector_actions" "sql" {
+  connector_ids = ["/providers/Microsoft.PowerApps/apis/shared_sql"]
+}
+
+locals {
+  sql_connector  = data.powerplatform_connector_actions.sql.connectors[0]
+  sql_action_ids = [for action in local.sql_connector.actions : action.id]
+
+  # Block every action except reading rows.
+  sql_action_rules = [for action in local.sql_connector.actions : {
+    action_id = action.id
+    behavior  = contains(["GetItems_V2", "GetItem_V2"], action.id) ? "Allow" : "Block"
+  } if !action.trigger]
+
+  blocked_sql_action_ids = ["ExecutePassThroughNativeQuery_V2", "DeleteItem_V2"]
+}
+
+check "sql_action_ids" {
+  assert {
+    condition     = alltrue([for action_id in local.blocked_sql_action_ids : contains(local.sql_action_ids, action_id)])
+    error_message = "Unknown SQL Server action ids: ${join(", ", setsubtract(local.blocked_sql_action_ids, local.sql_action_ids))}"
+  }
+}
@@ -0,0 +1,9 @@
+output "sql_action_rules" {
+  description = "Action rules for the SQL Server connector"
+  value       = local.sql_action_rules
+}
+
+output "sql_supports_endpoint_filtering" {
+  description = "Whether endpoint rules can be set for the SQL Server connector"
+  value       = local.sql_connector.supports_endpoint_filtering
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+data "powerplatform_data_loss_prevention_policies" "all" {}
+
+locals {
+  policy = one([for policy in data.powerplatform_data_loss_prevention_policies.all.policies : policy if policy.display_name == "Company Policy"])
+}
+
+data "powerplatform_data_loss_prevention_policy_connector_classification" "company_policy" {
+  default_connectors_classification = local.policy.default_connectors_classification
+  business_connectors               = local.policy.business_connectors
+  non_business_connectors           = local.policy.non_business_connectors
+  blocked_connectors                = local.policy.blocked_connectors
+  released_after                    = "2025-01-01T00:00:00Z"
+
+  classification_rules = [
+    {
+      tier       = "Premium"
+      publisher  = "Microsoft"
+      data_group = "Business"
+    },
+    {
+      tier       = "Premium"
+      data_group = "Blocked"
+    },
+  ]
+}
+
+check "newly_released_connectors" {
+  assert {
+    condition     = length([for connector in data.powerplatform_data_loss_prevention_policy_connector_classification.company_policy.unclassified_connectors : connector if connector.newly_released]) == 0
+    error_message = "Connectors released since the last review are not classified in the Company Policy."
+  }
+}
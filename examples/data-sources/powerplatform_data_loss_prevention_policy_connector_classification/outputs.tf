@@ -0,0 +1,9 @@
+output "unclassified_connectors" {
+  description = "Connectors that the policy doesn't classify"
+  value       = data.powerplatform_data_loss_prevention_policy_connector_classification.company_policy.unclassified_connectors
+}
+
+output "effective_business_connectors" {
+  description = "Business connectors of the policy including the ones put there by classification rules"
+  value       = data.powerplatform_data_loss_prevention_policy_connector_classification.company_policy.effective_business_connectors
+}
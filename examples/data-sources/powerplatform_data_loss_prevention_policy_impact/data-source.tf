@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+variable "environment_ids" {
+  description = "Environments the proposed policy applies to"
+  type        = set(string)
+}
+
+data "powerplatform_data_loss_prevention_policy_impact" "proposed" {
+  environments                      = var.environment_ids
+  default_connectors_classification = "Blocked"
+
+  business_connectors = [
+    {
+      id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+    },
+    {
+      id = "/providers/Microsoft.PowerApps/apis/shared_office365users"
+    },
+  ]
+
+  non_business_connectors = [
+    {
+      id = "/providers/Microsoft.PowerApps/apis/shared_msnweather"
+    },
+  ]
+
+  blocked_connectors = [
+    {
+      id = "/providers/Microsoft.PowerApps/apis/shared_dropbox"
+    },
+  ]
+}
+
+check "proposed_policy_impact" {
+  assert {
+    condition     = length(data.powerplatform_data_loss_prevention_policy_impact.proposed.apps) == 0
+    error_message = "The proposed policy breaks ${length(data.powerplatform_data_loss_prevention_policy_impact.proposed.apps)} app(s)."
+  }
+}
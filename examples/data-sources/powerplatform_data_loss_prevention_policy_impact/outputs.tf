@@ -0,0 +1,9 @@
+output "broken_apps" {
+  description = "Apps the proposed policy would break"
+  value       = data.powerplatform_data_loss_prevention_policy_impact.proposed.apps
+}
+
+output "broken_connections" {
+  description = "Connections the proposed policy would block"
+  value       = data.powerplatform_data_loss_prevention_policy_impact.proposed.connections
+}
@@ -40,3 +40,19 @@ data "powerplatform_data_records" "data_query" {
     }
   ]
 }
+
+# FetchXML queries can use outer joins and aggregates. Columns of the linked contact are returned as `primary_contact`.
+data "powerplatform_data_records" "fetch_xml_query" {
+  environment_id    = powerplatform_environment.env.id
+  entity_collection = "accounts"
+  fetch_xml         = <<-EOT
+    <fetch>
+      <entity name="account">
+        <attribute name="name" />
+        <link-entity name="contact" from="contactid" to="primarycontactid" alias="primary_contact" link-type="outer">
+          <attribute name="fullname" />
+        </link-entity>
+      </entity>
+    </fetch>
+  EOT
+}
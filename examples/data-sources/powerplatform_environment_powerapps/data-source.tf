@@ -11,3 +11,8 @@ provider "powerplatform" {
 }
 
 data "powerplatform_environment_powerapps" "all" {}
+
+# Apps that use SQL Server connections, for example to review them before changing the DLP policy.
+data "powerplatform_environment_powerapps" "sql" {
+  connector_name = "shared_sql"
+}
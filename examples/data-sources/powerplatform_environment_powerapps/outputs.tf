@@ -2,3 +2,8 @@ output "all_environments_apps" {
   description = "Returns all Power Apps in the tenant"
   value       = data.powerplatform_environment_powerapps.all.powerapps
 }
+
+output "quarantined_sql_apps" {
+  description = "Names of the quarantined apps that use SQL Server connections"
+  value       = [for app in data.powerplatform_environment_powerapps.sql.powerapps : app.display_name if app.is_quarantined]
+}
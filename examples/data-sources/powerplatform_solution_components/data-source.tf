@@ -0,0 +1,16 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+data "powerplatform_solution_components" "core" {
+  environment_id = var.environment_id
+  solution_name  = var.solution_name
+}
@@ -0,0 +1,7 @@
+output "unmanaged_components" {
+  description = "Returns the components of the solution that are not tables or table subcomponents"
+  value = [
+    for component in data.powerplatform_solution_components.core.components : component
+    if component.component_type_name != "Entity" && component.component_type_name != "Attribute"
+  ]
+}
@@ -0,0 +1,9 @@
+variable "environment_id" {
+  type        = string
+  description = "The id of the Power Platform environment"
+}
+
+variable "solution_name" {
+  type        = string
+  description = "The unique name of the solution"
+}
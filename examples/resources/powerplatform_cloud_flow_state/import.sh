@@ -0,0 +1,3 @@
+# Cloud flow state resource can be imported using:
+# {environment_id}/{flow_id}
+terraform import powerplatform_cloud_flow_state.notify 00000000-0000-0000-0000-000000000000/00000000-0000-0000-0000-000000000001
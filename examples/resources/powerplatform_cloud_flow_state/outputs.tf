@@ -0,0 +1,3 @@
+output "solution_flow_states" {
+  value = { for id, flow in powerplatform_cloud_flow_state.solution_flows : flow.name => flow.state }
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+data "powerplatform_cloud_flows" "all" {
+  environment_id = var.environment_id
+}
+
+# Turns on every flow of the solution once it is imported.
+resource "powerplatform_cloud_flow_state" "solution_flows" {
+  for_each = {
+    for flow in data.powerplatform_cloud_flows.all.cloud_flows : flow.id => flow
+    if contains(flow.solutions, var.solution_name)
+  }
+
+  environment_id = var.environment_id
+  flow_id        = each.key
+  state          = "On"
+  owner_id       = var.owner_id
+}
@@ -0,0 +1,17 @@
+variable "environment_id" {
+  default     = "00000000-0000-0000-0000-000000000001"
+  description = "Unique identifier of the environment"
+  type        = string
+}
+
+variable "solution_name" {
+  default     = "ContosoOrders"
+  description = "Unique name of the solution whose flows are turned on"
+  type        = string
+}
+
+variable "owner_id" {
+  default     = null
+  description = "Dataverse system user id of the owner of the flows, the current owners are kept when null"
+  type        = string
+}
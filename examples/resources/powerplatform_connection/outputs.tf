@@ -4,3 +4,8 @@ output "azure_openai_connection" {
   value       = powerplatform_connection.azure_openai_connection
   sensitive   = true
 }
+
+output "dataverse_service_principal_connection_status" {
+  description = "Status of the Dataverse service principal connection"
+  value       = powerplatform_connection.dataverse_service_principal.status
+}
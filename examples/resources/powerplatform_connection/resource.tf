@@ -28,3 +28,23 @@ resource "powerplatform_connection" "azure_openai_connection" {
     ]
   }
 }
+
+resource "powerplatform_connection" "dataverse_service_principal" {
+  environment_id = var.environment_id
+  name           = "shared_commondataserviceforapps"
+  display_name   = "Dataverse Service Principal"
+  connection_parameters_set = jsonencode({
+    "name" : "oauthClientCredentials",
+    "values" : {
+      "token:clientId" : { "value" : "${var.client_id}" },
+      "token:clientSecret" : { "value" : "${var.client_secret}" },
+      "token:TenantId" : { "value" : "${var.tenant_id}" }
+    }
+  })
+
+  lifecycle {
+    ignore_changes = [
+      connection_parameters_set
+    ]
+  }
+}
@@ -29,3 +29,22 @@ variable "azure_search_api_key" {
   sensitive   = true
   type        = string
 }
+
+variable "client_id" {
+  default     = "00000000-0000-0000-0000-000000000002"
+  description = "Client id of the service principal used by the connection"
+  type        = string
+}
+
+variable "client_secret" {
+  default     = ""
+  description = "Client secret of the service principal used by the connection"
+  sensitive   = true
+  type        = string
+}
+
+variable "tenant_id" {
+  default     = "00000000-0000-0000-0000-000000000003"
+  description = "Tenant of the service principal used by the connection"
+  type        = string
+}
@@ -0,0 +1,3 @@
+# Connection reference resource can be imported using:
+# {environment_id}/{logical_name}
+terraform import powerplatform_connection_reference.sql 00000000-0000-0000-0000-000000000000/contoso_sharedsql_1a2b3
@@ -0,0 +1,3 @@
+output "connection_reference_id" {
+  value = powerplatform_connection_reference.sql.connection_reference_id
+}
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_connection" "sql" {
+  environment_id = var.environment_id
+  name           = "shared_sql"
+  display_name   = "SQL Server"
+}
+
+# Binds the connection reference deployed by a solution to the connection.
+# Rebinding it in the maker portal shows up as drift on the next plan.
+resource "powerplatform_connection_reference" "sql" {
+  environment_id = var.environment_id
+  logical_name   = "contoso_sharedsql_1a2b3"
+  connector_id   = "/providers/Microsoft.PowerApps/apis/shared_sql"
+  connection_id  = powerplatform_connection.sql.id
+}
@@ -0,0 +1,5 @@
+variable "environment_id" {
+  default     = "00000000-0000-0000-0000-000000000001"
+  description = "Unique identifier of the environment"
+  type        = string
+}
@@ -0,0 +1,3 @@
+# Custom connector resource can be imported using the environment id and the name of the connector
+# in the format: <environment_id>/<connector_name>
+terraform import powerplatform_custom_connector.example "00000000-0000-0000-0000-000000000000/shared_contoso-20orders-5f1e2d3c4b5a6978"
@@ -0,0 +1,3 @@
+output "custom_connector_name" {
+  value = powerplatform_custom_connector.contoso.id
+}
@@ -0,0 +1,53 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+variable "client_secret" {
+  description = "Client secret of the OAuth2 application of the Contoso API"
+  type        = string
+  sensitive   = true
+}
+
+resource "powerplatform_environment" "custom_connector_example_env" {
+  display_name     = "powerplatform_custom_connector_example"
+  location         = "europe"
+  environment_type = "Sandbox"
+}
+
+resource "powerplatform_custom_connector" "contoso" {
+  environment_id   = powerplatform_environment.custom_connector_example_env.id
+  display_name     = "Contoso Orders"
+  description      = "Lists and creates orders of Contoso"
+  openapi_file     = "${path.module}/openapi.json"
+  icon_brand_color = "#da3b01"
+
+  security = {
+    type              = "OAuth2"
+    client_id         = "00000000-0000-0000-0000-000000000001"
+    client_secret     = var.client_secret
+    authorization_url = "https://login.contoso.com/oauth2/authorize"
+    token_url         = "https://login.contoso.com/oauth2/token"
+    scopes            = ["orders.read", "orders.write"]
+  }
+
+  policy_templates = [
+    {
+      template_id = "setheader"
+      title       = "Set the client header"
+      parameters = {
+        "x-ms-apimTemplateParameter.name"         = "X-Client"
+        "x-ms-apimTemplateParameter.value"        = "power-platform"
+        "x-ms-apimTemplateParameter.existsAction" = "override"
+        "x-ms-apimTemplate-policySection"         = "Request"
+      }
+    },
+  ]
+}
@@ -77,3 +77,47 @@ module "application_user" {
   business_unit_id = one(data.powerplatform_data_records.root_business_unit.rows).businessunitid
   role_ids         = [module.custom_role.role_id]
 }
+
+# Seed a record by alternate key, so the same configuration matches the existing record in every environment.
+# The account table needs an alternate key on `accountnumber` and the contact table one on `emailaddress1`.
+resource "powerplatform_data_record" "account_by_alternate_key" {
+  environment_id     = powerplatform_environment.data_record_example_env.id
+  table_logical_name = "account"
+
+  alternate_key = {
+    accountnumber = "ACC-001"
+  }
+
+  columns = {
+    name = "Contoso"
+
+    primarycontactid = {
+      table_logical_name = "contact"
+      alternate_key = {
+        emailaddress1 = "john.doe@contoso.com"
+      }
+    }
+  }
+}
+
+variable "mailbox_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "powerplatform_data_record" "mailbox" {
+  environment_id     = powerplatform_environment.data_record_example_env.id
+  table_logical_name = "mailbox"
+
+  columns = {
+    name         = "Support mailbox"
+    emailaddress = "support@contoso.com"
+  }
+
+  # Sent to Dataverse but never stored in state. Bump the version to send a changed password.
+  sensitive_columns = {
+    password = var.mailbox_password
+  }
+  sensitive_columns_version = "1"
+}
@@ -0,0 +1,3 @@
+output "contact_ids" {
+  value = powerplatform_data_record_import.contacts.row_ids
+}
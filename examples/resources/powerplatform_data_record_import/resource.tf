@@ -0,0 +1,38 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "data_record_import_example_env" {
+  display_name     = "powerplatform_data_record_import_example"
+  location         = "europe"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_data_record_import" "contacts" {
+  environment_id     = powerplatform_environment.data_record_import_example_env.id
+  table_logical_name = "contact"
+  file               = "${path.module}/contacts.csv"
+
+  # Rows are upserted by their id, other key columns need an alternate key on the table.
+  key_column = "contactid"
+
+  column_mapping = {
+    "Id"         = "contactid"
+    "First name" = "firstname"
+    "Last name"  = "lastname"
+    "Email"      = "emailaddress1"
+  }
+}
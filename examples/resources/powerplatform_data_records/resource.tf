@@ -0,0 +1,61 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "data_records_example_env" {
+  display_name     = "powerplatform_data_records_example"
+  location         = "europe"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_data_records" "contacts" {
+  environment_id     = powerplatform_environment.data_records_example_env.id
+  table_logical_name = "contact"
+
+  records = {
+    john_doe = {
+      firstname     = "John"
+      lastname      = "Doe"
+      emailaddress1 = "johndoe@contoso.com"
+    }
+    jane_doe = {
+      firstname     = "Jane"
+      lastname      = "Doe"
+      emailaddress1 = "janedoe@contoso.com"
+    }
+  }
+}
+
+resource "powerplatform_data_records" "accounts" {
+  environment_id     = powerplatform_environment.data_records_example_env.id
+  table_logical_name = "account"
+
+  records = {
+    contoso = {
+      name = "Contoso"
+      primarycontactid = {
+        table_logical_name = "contact"
+        data_record_id     = powerplatform_data_records.contacts.record_ids["john_doe"]
+      }
+      contact_customer_accounts = toset([
+        {
+          table_logical_name = "contact"
+          data_record_id     = powerplatform_data_records.contacts.record_ids["jane_doe"]
+        }
+      ])
+    }
+  }
+}
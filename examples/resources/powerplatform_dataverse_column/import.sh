@@ -0,0 +1,2 @@
+# Dataverse column resource can be imported using the composite id <environment_id>/<table_logical_name>/<column_logical_name>
+terraform import powerplatform_dataverse_column.stage 00000000-0000-0000-0000-000000000001/cts_project/cts_stage
@@ -0,0 +1,3 @@
+output "stage_column" {
+  value = powerplatform_dataverse_column.stage
+}
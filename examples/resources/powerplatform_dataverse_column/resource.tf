@@ -0,0 +1,85 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "example_column" {
+  display_name     = "example_dataverse_column"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_publisher" "example" {
+  environment_id       = powerplatform_environment.example_column.id
+  uniquename           = "contoso"
+  friendly_name        = "Contoso Publisher"
+  customization_prefix = "cts"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.example.environment_id
+  schema_name             = "cts_Project"
+  display_name            = "Project"
+  display_collection_name = "Projects"
+
+  primary_name_column = {
+    schema_name  = "cts_Name"
+    display_name = "Project Name"
+  }
+}
+
+resource "powerplatform_dataverse_column" "stage" {
+  environment_id     = powerplatform_dataverse_table.project.environment_id
+  table_logical_name = powerplatform_dataverse_table.project.logical_name
+  schema_name        = "cts_Stage"
+  type               = "Choice"
+  display_name       = "Stage"
+  required_level     = "ApplicationRequired"
+
+  options = [
+    { value = 100000000, label = "Planned" },
+    { value = 100000001, label = "In Progress" },
+    { value = 100000002, label = "Completed" },
+  ]
+}
+
+resource "powerplatform_dataverse_column" "budget" {
+  environment_id     = powerplatform_dataverse_table.project.environment_id
+  table_logical_name = powerplatform_dataverse_table.project.logical_name
+  schema_name        = "cts_Budget"
+  type               = "Money"
+  display_name       = "Budget"
+  min_value          = 0
+  precision          = 2
+}
+
+resource "powerplatform_dataverse_column" "start_date" {
+  environment_id     = powerplatform_dataverse_table.project.environment_id
+  table_logical_name = powerplatform_dataverse_table.project.logical_name
+  schema_name        = "cts_StartDate"
+  type               = "DateTime"
+  display_name       = "Start Date"
+  format             = "DateOnly"
+  date_time_behavior = "DateOnly"
+}
+
+resource "powerplatform_dataverse_column" "account" {
+  environment_id      = powerplatform_dataverse_table.project.environment_id
+  table_logical_name  = powerplatform_dataverse_table.project.logical_name
+  schema_name         = "cts_AccountId"
+  type                = "Lookup"
+  display_name        = "Customer"
+  lookup_target_table = "account"
+}
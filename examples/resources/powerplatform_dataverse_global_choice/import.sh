@@ -0,0 +1,2 @@
+# Dataverse global choice resource can be imported using the composite id <environment_id>/<global_choice_name> or <environment_id>/<global_choice_id>
+terraform import powerplatform_dataverse_global_choice.priority 00000000-0000-0000-0000-000000000001/cts_priority
@@ -0,0 +1,3 @@
+output "global_choice" {
+  value = powerplatform_dataverse_global_choice.priority
+}
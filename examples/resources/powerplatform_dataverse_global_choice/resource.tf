@@ -0,0 +1,58 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "example_global_choice" {
+  display_name     = "example_dataverse_global_choice"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_publisher" "example" {
+  environment_id       = powerplatform_environment.example_global_choice.id
+  uniquename           = "contoso"
+  friendly_name        = "Contoso Publisher"
+  customization_prefix = "cts"
+}
+
+resource "powerplatform_dataverse_global_choice" "priority" {
+  environment_id          = powerplatform_publisher.example.environment_id
+  name                    = "cts_priority"
+  display_name            = "Priority"
+  localized_display_names = { "1031" = "Priorität" }
+  description             = "Priority of work items"
+
+  options = [
+    {
+      value            = 100000000
+      label            = "Low"
+      localized_labels = { "1031" = "Niedrig" }
+      color            = "#00a000"
+    },
+    {
+      value            = 100000001
+      label            = "Medium"
+      localized_labels = { "1031" = "Mittel" }
+    },
+    {
+      value            = 100000002
+      label            = "High"
+      localized_labels = { "1031" = "Hoch" }
+      description      = "Needs attention within a day"
+      color            = "#d00000"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Dataverse relationship resource can be imported using the composite id <environment_id>/<table_logical_name>/<relationship_schema_name> or <environment_id>/<table_logical_name>/<relationship_id>
+terraform import powerplatform_dataverse_relationship.project_account 00000000-0000-0000-0000-000000000001/cts_project/cts_account_cts_project
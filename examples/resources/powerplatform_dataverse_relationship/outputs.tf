@@ -0,0 +1,7 @@
+output "project_account_relationship" {
+  value = powerplatform_dataverse_relationship.project_account
+}
+
+output "project_contact_relationship" {
+  value = powerplatform_dataverse_relationship.project_contact
+}
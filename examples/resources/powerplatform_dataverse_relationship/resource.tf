@@ -0,0 +1,68 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "example_relationship" {
+  display_name     = "example_dataverse_relationship"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_publisher" "example" {
+  environment_id       = powerplatform_environment.example_relationship.id
+  uniquename           = "contoso"
+  friendly_name        = "Contoso Publisher"
+  customization_prefix = "cts"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.example.environment_id
+  schema_name             = "cts_Project"
+  display_name            = "Project"
+  display_collection_name = "Projects"
+
+  primary_name_column = {
+    schema_name  = "cts_Name"
+    display_name = "Name"
+  }
+}
+
+resource "powerplatform_dataverse_relationship" "project_account" {
+  environment_id             = powerplatform_dataverse_table.project.environment_id
+  schema_name                = "cts_account_cts_project"
+  type                       = "ManyToOne"
+  table_logical_name         = powerplatform_dataverse_table.project.logical_name
+  related_table_logical_name = "account"
+
+  lookup_column = {
+    schema_name    = "cts_AccountId"
+    display_name   = "Account"
+    required_level = "ApplicationRequired"
+  }
+
+  cascade_configuration = {
+    delete = "Restrict"
+  }
+}
+
+resource "powerplatform_dataverse_relationship" "project_contact" {
+  environment_id             = powerplatform_dataverse_table.project.environment_id
+  schema_name                = "cts_project_contact"
+  type                       = "ManyToMany"
+  table_logical_name         = powerplatform_dataverse_table.project.logical_name
+  related_table_logical_name = "contact"
+  intersect_entity_name      = "cts_project_contact"
+}
@@ -0,0 +1,2 @@
+# Dataverse table resource can be imported using the composite id <environment_id>/<table_logical_name> or <environment_id>/<table_id>
+terraform import powerplatform_dataverse_table.project 00000000-0000-0000-0000-000000000001/cts_project
@@ -0,0 +1,3 @@
+output "table" {
+  value = powerplatform_dataverse_table.project
+}
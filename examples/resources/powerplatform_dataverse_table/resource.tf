@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "example_table" {
+  display_name     = "example_dataverse_table"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_publisher" "example" {
+  environment_id       = powerplatform_environment.example_table.id
+  uniquename           = "contoso"
+  friendly_name        = "Contoso Publisher"
+  customization_prefix = "cts"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.example.environment_id
+  schema_name             = "${powerplatform_publisher.example.customization_prefix}_Project"
+  display_name            = "Project"
+  display_collection_name = "Projects"
+  description             = "Projects run for customers"
+  ownership_type          = "UserOwned"
+  has_notes               = true
+  is_audit_enabled        = true
+  change_tracking_enabled = true
+
+  primary_name_column = {
+    schema_name    = "${powerplatform_publisher.example.customization_prefix}_Name"
+    display_name   = "Project Name"
+    max_length     = 200
+    required_level = "ApplicationRequired"
+  }
+}
@@ -39,3 +39,11 @@ resource "powerplatform_environment_application_package_install" "install_sample
   environment_id = powerplatform_environment.env.id
   unique_name    = data.powerplatform_environment_application_packages.application_to_install.applications[0].unique_name
 }
+
+# Pinning the version makes the installation fail when the catalog offers a different version.
+# Raising the version later upgrades the installed package in place.
+resource "powerplatform_environment_application_package_install" "install_pinned_application" {
+  environment_id = powerplatform_environment.env.id
+  unique_name    = "ProcessMiningAnchor"
+  version        = "1.3.0.8"
+}
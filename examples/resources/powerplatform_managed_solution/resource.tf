@@ -45,7 +45,17 @@ resource "powerplatform_managed_solution" "solution" {
   # location such as a blob SAS url and is treated as sensitive.
   source = {
     path = "${path.module}/TerraformSolutionExample_1_0_0_1_managed.zip"
-    # url = "https://example.blob.core.windows.net/artifacts/TerraformSolutionExample_1_0_0_1_managed.zip?<sas>"
+    # url = "https://example.blob.core.windows.net/artifacts/TerraformSolutionExample_1_0_0_1_managed.zip"
+    # sas_token = var.artifacts_sas_token
+    #
+    # Private GitHub release assets are downloaded through the API with a token:
+    # url          = "https://api.github.com/repos/contoso/solutions/releases/assets/123456"
+    # bearer_token = var.github_token
+    # headers      = { Accept = "application/octet-stream" }
+    #
+    # The package is rejected when its checksum differs. Downloads with a
+    # checksum are cached, so an unchanged artifact is only downloaded once.
+    # expected_sha256 = "<sha256 of the package>"
   }
 
   # Every connection reference declared by the package must be bound to a
@@ -0,0 +1,3 @@
+# Power App bypass consent resource can be imported using:
+# {environment_id}/{app_name}
+terraform import powerplatform_powerapp_bypass_consent.expenses 00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002
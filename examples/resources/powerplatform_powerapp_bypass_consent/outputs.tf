@@ -0,0 +1,4 @@
+output "app_bypass_consent" {
+  description = "Whether users launch the app without being prompted for consent"
+  value       = powerplatform_powerapp_bypass_consent.expenses.bypass_consent
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+# Lets users launch the app without being prompted to consent to its connections.
+resource "powerplatform_powerapp_bypass_consent" "expenses" {
+  environment_id = var.environment_id
+  app_name       = var.app_name
+  bypass_consent = true
+}
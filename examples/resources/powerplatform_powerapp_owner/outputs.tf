@@ -0,0 +1,4 @@
+output "app_owner" {
+  description = "Display name of the owner of the app"
+  value       = powerplatform_powerapp_owner.expenses.owner_display_name
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+# Hands the app over to a new owner, the original maker keeps the right to edit it.
+resource "powerplatform_powerapp_owner" "expenses" {
+  environment_id          = var.environment_id
+  app_name                = var.app_name
+  owner_id                = var.owner_object_id
+  role_for_previous_owner = "CanEdit"
+}
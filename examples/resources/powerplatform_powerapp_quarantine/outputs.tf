@@ -0,0 +1,4 @@
+output "app_quarantined" {
+  description = "Whether the app is quarantined"
+  value       = powerplatform_powerapp_quarantine.expenses.quarantined
+}
@@ -0,0 +1,18 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+# Blocks users from launching the app while an incident is investigated.
+resource "powerplatform_powerapp_quarantine" "expenses" {
+  environment_id = var.environment_id
+  app_name       = var.app_name
+  quarantined    = true
+}
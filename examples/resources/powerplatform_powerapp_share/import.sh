@@ -0,0 +1,3 @@
+# Power App share resource can be imported using:
+# {environment_id}/{app_name}/{entra_object_id}
+terraform import powerplatform_powerapp_share.sales_team 00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002/00000000-0000-0000-0000-000000000003
@@ -0,0 +1,4 @@
+output "app_share" {
+  description = "Share of the app with the sales team"
+  value       = powerplatform_powerapp_share.sales_team
+}
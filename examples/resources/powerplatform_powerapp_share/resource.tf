@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_powerapp_share" "sales_team" {
+  environment_id = var.environment_id
+  app_name       = var.app_name
+  role_name      = "CanView"
+  principal = {
+    entra_object_id = var.group_object_id
+    type            = "Group"
+  }
+}
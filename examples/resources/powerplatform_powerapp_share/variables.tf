@@ -0,0 +1,17 @@
+variable "environment_id" {
+  default     = "00000000-0000-0000-0000-000000000001"
+  description = "Unique identifier of the environment"
+  type        = string
+}
+
+variable "app_name" {
+  default     = "00000000-0000-0000-0000-000000000002"
+  description = "Name of the canvas app to share"
+  type        = string
+}
+
+variable "group_object_id" {
+  default     = "00000000-0000-0000-0000-000000000003"
+  description = "Entra Object Id of the group that will be granted access to the app"
+  type        = string
+}
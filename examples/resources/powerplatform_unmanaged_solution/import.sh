@@ -0,0 +1,2 @@
+# Unmanaged solution resource can be imported using the composite id <environment_id>_<solution_id>
+terraform import powerplatform_unmanaged_solution.example 00000000-0000-0000-0000-000000000001_11111111-1111-1111-1111-111111111111
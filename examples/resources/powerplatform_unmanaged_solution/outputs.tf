@@ -0,0 +1,3 @@
+output "unmanaged_solution" {
+  value = powerplatform_unmanaged_solution.example
+}
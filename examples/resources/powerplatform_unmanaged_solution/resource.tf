@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    powerplatform = {
+      source = "microsoft/power-platform"
+    }
+  }
+}
+
+provider "powerplatform" {
+  use_cli = true
+}
+
+resource "powerplatform_environment" "example_solution" {
+  display_name     = "example_unmanaged_solution"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "powerplatform_publisher" "example" {
+  environment_id       = powerplatform_environment.example_solution.id
+  uniquename           = "contoso"
+  friendly_name        = "Contoso Publisher"
+  customization_prefix = "cts"
+}
+
+resource "powerplatform_unmanaged_solution" "example" {
+  environment_id = powerplatform_environment.example_solution.id
+  uniquename     = "ContosoCore"
+  display_name   = "Contoso Core"
+  version        = "1.0.0.0"
+  publisher_id   = powerplatform_publisher.example.id
+  description    = "Core customizations of Contoso"
+
+  components = [
+    {
+      # Table, including its columns, forms and views
+      component_type = 1
+      object_id      = "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"
+    },
+    {
+      # Web resource
+      component_type        = 61
+      object_id             = "c81f6d5e-5b3e-ef11-8409-000d3a4a5a01"
+      include_subcomponents = false
+    }
+  ]
+}
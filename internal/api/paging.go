@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PagingOptions controls how list requests are paged.
+type PagingOptions struct {
+	// MaxPageSize is sent as `Prefer: odata.maxpagesize=<n>` when greater than zero.
+	MaxPageSize int
+	// MaxRecords caps the number of records returned across all pages. Zero means no limit.
+	MaxRecords int
+}
+
+// pageDto is the envelope of a single page of a list response.
+// Dataverse returns the link to the next page as `@odata.nextLink`, while BAPI and Power Platform API use `nextLink`.
+type pageDto struct {
+	Value         []json.RawMessage `json:"value"`
+	ODataNextLink string            `json:"@odata.nextLink"`
+	NextLink      string            `json:"nextLink"`
+}
+
+// PageIterator reads the pages of a list response one by one, following the next link of every page.
+type PageIterator struct {
+	client                *Client
+	scopes                []string
+	headers               http.Header
+	acceptableStatusCodes []int
+	maxRecords            int
+	nextUrl               string
+	records               int
+	started               bool
+}
+
+// NewPageIterator creates an iterator for the list endpoint at url.
+// The acceptable status codes only apply to the first page; every following page must return 200.
+func (client *Client) NewPageIterator(scopes []string, url string, headers http.Header, acceptableStatusCodes []int, options *PagingOptions) *PageIterator {
+	pageHeaders := http.Header{}
+	for k, v := range headers {
+		pageHeaders[k] = append([]string(nil), v...)
+	}
+
+	iterator := &PageIterator{
+		client:                client,
+		scopes:                scopes,
+		headers:               pageHeaders,
+		acceptableStatusCodes: acceptableStatusCodes,
+		nextUrl:               url,
+	}
+
+	if options != nil {
+		iterator.maxRecords = options.MaxRecords
+		if options.MaxPageSize > 0 {
+			prefer := fmt.Sprintf("odata.maxpagesize=%d", options.MaxPageSize)
+			if existing := pageHeaders.Get("Prefer"); existing != "" {
+				prefer = existing + "," + prefer
+			}
+			pageHeaders.Set("Prefer", prefer)
+		}
+	}
+
+	return iterator
+}
+
+// HasNext reports whether there is another page to read.
+func (iterator *PageIterator) HasNext() bool {
+	return iterator.nextUrl != ""
+}
+
+// Next reads the next page and returns its response together with the raw records of the page.
+// A first page with a status code other than 200 ends the iteration; its response is returned so the caller can handle it.
+// A page that is not a collection (it has no `value` array) is returned without records.
+func (iterator *PageIterator) Next(ctx context.Context) (*Response, []json.RawMessage, error) {
+	if !iterator.HasNext() {
+		return nil, nil, nil
+	}
+
+	acceptableStatusCodes := []int{http.StatusOK}
+	if !iterator.started {
+		acceptableStatusCodes = iterator.acceptableStatusCodes
+	}
+	iterator.started = true
+
+	url := iterator.nextUrl
+	iterator.nextUrl = ""
+
+	// doRequest adds authorization and telemetry headers to the header map, so every page gets its own copy.
+	headers := iterator.headers.Clone()
+
+	resp, err := iterator.client.Execute(ctx, iterator.scopes, "GET", url, headers, nil, acceptableStatusCodes, nil)
+	if err != nil {
+		return resp, nil, err
+	}
+	if resp.HttpResponse.StatusCode != http.StatusOK {
+		return resp, nil, nil
+	}
+
+	page := pageDto{}
+	if len(resp.BodyAsBytes) > 0 {
+		if err := json.Unmarshal(resp.BodyAsBytes, &page); err != nil {
+			return resp, nil, fmt.Errorf("Error marshalling response to json. %w", err)
+		}
+	}
+
+	records := page.Value
+	if iterator.maxRecords > 0 && iterator.records+len(records) >= iterator.maxRecords {
+		records = records[:iterator.maxRecords-iterator.records]
+		iterator.records += len(records)
+		tflog.Debug(ctx, fmt.Sprintf("Stopped paging after reaching the limit of %d records", iterator.maxRecords))
+		return resp, records, nil
+	}
+	iterator.records += len(records)
+
+	nextUrl := page.ODataNextLink
+	if nextUrl == "" {
+		nextUrl = page.NextLink
+	}
+	if nextUrl != "" && strings.EqualFold(nextUrl, url) {
+		return resp, records, fmt.Errorf("next page link of '%s' points to itself", url)
+	}
+	iterator.nextUrl = nextUrl
+
+	return resp, records, nil
+}
+
+// ExecuteForAllPages reads all pages of the list endpoint at url and unmarshals their records into T.
+// The response of the first page is returned so callers can handle its status code as they would for Execute.
+func ExecuteForAllPages[T any](ctx context.Context, client *Client, scopes []string, url string, headers http.Header, acceptableStatusCodes []int, options *PagingOptions) ([]T, *Response, error) {
+	iterator := client.NewPageIterator(scopes, url, headers, acceptableStatusCodes, options)
+
+	var firstResponse *Response
+	values := []T{}
+	for iterator.HasNext() {
+		resp, records, err := iterator.Next(ctx)
+		if firstResponse == nil {
+			firstResponse = resp
+		}
+		if err != nil {
+			return nil, firstResponse, err
+		}
+
+		for _, record := range records {
+			var value T
+			if err := json.Unmarshal(record, &value); err != nil {
+				return nil, firstResponse, fmt.Errorf("Error marshalling response to json. %w", err)
+			}
+			values = append(values, value)
+		}
+	}
+
+	return values, firstResponse, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type pagingTestRecordDto struct {
+	Id int `json:"id"`
+}
+
+func newPagingTestServer(t *testing.T, nextLinkProperty string, pages int, recordsPerPage int) (*httptest.Server, *[]*http.Request) {
+	t.Helper()
+
+	requests := []*http.Request{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Clone(context.Background()))
+
+		page := 0
+		if _, err := fmt.Sscanf(r.URL.Query().Get("page"), "%d", &page); err != nil {
+			page = 0
+		}
+
+		body := `{"value":[`
+		for i := 0; i < recordsPerPage; i++ {
+			if i > 0 {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"id":%d}`, page*recordsPerPage+i)
+		}
+		body += "]"
+		if page+1 < pages {
+			body += fmt.Sprintf(`,"%s":"%s/items?page=%d"`, nextLinkProperty, server.URL, page+1)
+		}
+		body += "}"
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+
+	return server, &requests
+}
+
+func newPagingTestClient() *api.Client {
+	cfg := config.ProviderConfig{TestMode: true}
+	return api.NewApiClientBase(&cfg, api.NewAuthBase(&cfg))
+}
+
+func TestUnitExecuteForAllPages_FollowsODataNextLink(t *testing.T) {
+	server, requests := newPagingTestServer(t, "@odata.nextLink", 3, 2)
+	defer server.Close()
+
+	records, resp, err := api.ExecuteForAllPages[pagingTestRecordDto](context.Background(), newPagingTestClient(), []string{"test"}, server.URL+"/items", nil, []int{http.StatusOK}, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.HttpResponse.StatusCode)
+	require.Len(t, records, 6)
+	for i, record := range records {
+		assert.Equal(t, i, record.Id)
+	}
+	assert.Len(t, *requests, 3)
+}
+
+func TestUnitExecuteForAllPages_FollowsNextLink(t *testing.T) {
+	server, requests := newPagingTestServer(t, "nextLink", 2, 3)
+	defer server.Close()
+
+	records, _, err := api.ExecuteForAllPages[pagingTestRecordDto](context.Background(), newPagingTestClient(), []string{"test"}, server.URL+"/items", nil, []int{http.StatusOK}, nil)
+
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+	assert.Len(t, *requests, 2)
+}
+
+func TestUnitExecuteForAllPages_MaxPageSizeAndMaxRecords(t *testing.T) {
+	server, requests := newPagingTestServer(t, "@odata.nextLink", 5, 2)
+	defer server.Close()
+
+	headers := http.Header{}
+	headers.Set("Prefer", `odata.include-annotations="*"`)
+
+	records, _, err := api.ExecuteForAllPages[pagingTestRecordDto](context.Background(), newPagingTestClient(), []string{"test"}, server.URL+"/items", headers, []int{http.StatusOK}, &api.PagingOptions{
+		MaxPageSize: 2,
+		MaxRecords:  3,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, 2, records[2].Id)
+	require.Len(t, *requests, 2, "paging must stop once the record cap is reached")
+	for _, request := range *requests {
+		assert.Equal(t, `odata.include-annotations="*",odata.maxpagesize=2`, request.Header.Get("Prefer"))
+	}
+	assert.Equal(t, `odata.include-annotations="*"`, headers.Get("Prefer"), "the caller's headers must not be modified")
+	assert.Empty(t, headers.Get("Authorization"), "the caller's headers must not be modified")
+}
+
+func TestUnitExecuteForAllPages_ReturnsUnsuccessfulFirstPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"0x80040220","message":"no access"}}`))
+	}))
+	defer server.Close()
+
+	records, resp, err := api.ExecuteForAllPages[pagingTestRecordDto](context.Background(), newPagingTestClient(), []string{"test"}, server.URL+"/items", nil, []int{http.StatusOK, http.StatusForbidden}, nil)
+
+	require.NoError(t, err)
+	require.Empty(t, records)
+	require.Equal(t, http.StatusForbidden, resp.HttpResponse.StatusCode)
+}
+
+func TestUnitExecuteForAllPages_RejectsSelfReferencingNextLink(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"value":[{"id":1}],"@odata.nextLink":"%s/items"}`, server.URL)))
+	}))
+	defer server.Close()
+
+	_, _, err := api.ExecuteForAllPages[pagingTestRecordDto](context.Background(), newPagingTestClient(), []string{"test"}, server.URL+"/items", nil, []int{http.StatusOK}, nil)
+
+	require.ErrorContains(t, err, "points to itself")
+}
@@ -174,6 +174,16 @@ const (
 	DATAVERSE_CALLER_PROVISIONING_POLL_TIMEOUT  = 10 * time.Minute
 )
 
+// Dataverse refuses to uninstall a solution while components of other solutions depend on it. Dependent
+// solutions that are uninstalled in the same apply are waited for, and the grace period gives their
+// uninstall time to start when both deletes are scheduled at the same moment. The max retry count bounds
+// the wait for an uninstall that never completes.
+const (
+	SOLUTION_UNINSTALL_DEPENDENCY_POLL_INTERVAL     = 15 * time.Second
+	SOLUTION_UNINSTALL_DEPENDENCY_GRACE_RETRY_COUNT = 4
+	SOLUTION_UNINSTALL_DEPENDENCY_MAX_RETRY_COUNT   = 40
+)
+
 const (
 	ENV_VAR_POWER_PLATFORM_CLOUD                        = "POWER_PLATFORM_CLOUD"
 	ENV_VAR_POWER_PLATFORM_TENANT_ID                    = "POWER_PLATFORM_TENANT_ID"
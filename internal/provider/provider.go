@@ -29,11 +29,14 @@ import (
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/application"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/authorization"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/capacity"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/cloud_flow"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection_reference"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/connectors"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/copilot_studio_application_insights"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/currencies"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/data_record"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/disaster_recovery"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/dlp_policy"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/enterprise_policy"
@@ -405,20 +408,33 @@ func (p *PowerPlatformProvider) Resources(ctx context.Context) []func() resource
 		func() resource.Resource { return application.NewEnvironmentApplicationPackageInstallResource() },
 		func() resource.Resource { return dlp_policy.NewDataLossPreventionPolicyResource() },
 		func() resource.Resource { return solution.NewSolutionResource() },
+		func() resource.Resource { return solution.NewUnmanagedSolutionResource() },
 		func() resource.Resource { return tenant_settings.NewTenantSettingsResource() },
 		func() resource.Resource { return managed_environment.NewManagedEnvironmentResource() },
 		func() resource.Resource { return managedsolution.NewManagedSolutionResource() },
 		func() resource.Resource { return environmentvariable.NewEnvironmentVariableResource() },
+		func() resource.Resource { return connection_reference.NewConnectionReferenceResource() },
+		func() resource.Resource { return cloud_flow.NewCloudFlowStateResource() },
 		func() resource.Resource { return licensing.NewBillingPolicyEnvironmentResource() },
 		func() resource.Resource { return licensing.NewBillingPolicyResource() },
 		func() resource.Resource { return authorization.NewUserResource() },
 		func() resource.Resource { return data_record.NewDataRecordResource() },
+		func() resource.Resource { return data_record.NewDataRecordsResource() },
+		func() resource.Resource { return data_record.NewDataRecordImportResource() },
 		func() resource.Resource { return publisher.NewPublisherResource() },
+		func() resource.Resource { return dataverse_metadata.NewTableResource() },
+		func() resource.Resource { return dataverse_metadata.NewColumnResource() },
+		func() resource.Resource { return dataverse_metadata.NewGlobalChoiceResource() },
+		func() resource.Resource { return dataverse_metadata.NewRelationshipResource() },
 		func() resource.Resource { return environment_settings.NewEnvironmentSettingsResource() },
 		func() resource.Resource { return connection.NewConnectionResource() },
 		func() resource.Resource { return rest.NewDataverseWebApiResource() },
 		func() resource.Resource { return environment_wave.NewEnvironmentWaveResource() },
 		func() resource.Resource { return connection.NewConnectionShareResource() },
+		func() resource.Resource { return powerapps.NewPowerAppShareResource() },
+		func() resource.Resource { return powerapps.NewPowerAppOwnerResource() },
+		func() resource.Resource { return powerapps.NewPowerAppQuarantineResource() },
+		func() resource.Resource { return powerapps.NewPowerAppBypassConsentResource() },
 		func() resource.Resource { return environment_groups.NewEnvironmentGroupResource() },
 		func() resource.Resource { return admin_management_application.NewAdminManagementApplicationResource() },
 		func() resource.Resource { return environment_group_rule_set.NewEnvironmentGroupRuleSetResource() },
@@ -438,6 +454,7 @@ func (p *PowerPlatformProvider) Resources(ctx context.Context) []func() resource
 		func() resource.Resource {
 			return role_based_access.NewEnvironmentRoleBasedAccessAssignmentResource()
 		},
+		func() resource.Resource { return connectors.NewCustomConnectorResource() },
 	}
 }
 
@@ -445,12 +462,17 @@ func (p *PowerPlatformProvider) DataSources(ctx context.Context) []func() dataso
 	return []func() datasource.DataSource{
 		func() datasource.DataSource { return analytics_data_export.NewAnalyticsExportDataSource() },
 		func() datasource.DataSource { return connectors.NewConnectorsDataSource() },
+		func() datasource.DataSource { return connectors.NewConnectorActionsDataSource() },
 		func() datasource.DataSource { return application.NewEnvironmentApplicationPackagesDataSource() },
 		func() datasource.DataSource { return powerapps.NewEnvironmentPowerAppsDataSource() },
+		func() datasource.DataSource { return cloud_flow.NewCloudFlowsDataSource() },
 		func() datasource.DataSource { return environment.NewEnvironmentsDataSource() },
 		func() datasource.DataSource { return environment_templates.NewEnvironmentTemplatesDataSource() },
 		func() datasource.DataSource { return solution.NewSolutionsDataSource() },
+		func() datasource.DataSource { return solution.NewSolutionComponentsDataSource() },
 		func() datasource.DataSource { return dlp_policy.NewDataLossPreventionPolicyDataSource() },
+		func() datasource.DataSource { return dlp_policy.NewDataLossPreventionPolicyImpactDataSource() },
+		func() datasource.DataSource { return dlp_policy.NewDataLossPreventionPolicyConnectorClassificationDataSource() },
 		func() datasource.DataSource { return tenant_settings.NewTenantSettingsDataSource() },
 		func() datasource.DataSource { return licensing.NewBillingPoliciesDataSource() },
 		func() datasource.DataSource { return licensing.NewBillingPoliciesEnvironmetsDataSource() },
@@ -20,11 +20,14 @@ import (
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/application"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/authorization"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/capacity"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/cloud_flow"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection_reference"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/connectors"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/copilot_studio_application_insights"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/currencies"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/data_record"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/disaster_recovery"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/dlp_policy"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/enterprise_policy"
@@ -56,12 +59,17 @@ func TestUnitPowerPlatformProviderHasChildDataSources_Basic(t *testing.T) {
 	expectedDataSources := []datasource.DataSource{
 		analytics_data_export.NewAnalyticsExportDataSource(),
 		powerapps.NewEnvironmentPowerAppsDataSource(),
+		cloud_flow.NewCloudFlowsDataSource(),
 		environment.NewEnvironmentsDataSource(),
 		environment_templates.NewEnvironmentTemplatesDataSource(),
 		application.NewEnvironmentApplicationPackagesDataSource(),
 		connectors.NewConnectorsDataSource(),
+		connectors.NewConnectorActionsDataSource(),
 		solution.NewSolutionsDataSource(),
+		solution.NewSolutionComponentsDataSource(),
 		dlp_policy.NewDataLossPreventionPolicyDataSource(),
+		dlp_policy.NewDataLossPreventionPolicyImpactDataSource(),
+		dlp_policy.NewDataLossPreventionPolicyConnectorClassificationDataSource(),
 		tenant_settings.NewTenantSettingsDataSource(),
 		licensing.NewBillingPoliciesDataSource(),
 		licensing.NewBillingPoliciesEnvironmetsDataSource(),
@@ -99,19 +107,32 @@ func TestUnitPowerPlatformProviderHasChildResources_Basic(t *testing.T) {
 		application.NewEnvironmentApplicationPackageInstallResource(),
 		dlp_policy.NewDataLossPreventionPolicyResource(),
 		solution.NewSolutionResource(),
+		solution.NewUnmanagedSolutionResource(),
 		tenant_settings.NewTenantSettingsResource(),
 		managed_environment.NewManagedEnvironmentResource(),
 		managedsolution.NewManagedSolutionResource(),
 		environmentvariable.NewEnvironmentVariableResource(),
+		connection_reference.NewConnectionReferenceResource(),
+		cloud_flow.NewCloudFlowStateResource(),
 		licensing.NewBillingPolicyResource(),
 		licensing.NewBillingPolicyEnvironmentResource(),
 		authorization.NewUserResource(),
 		environment_settings.NewEnvironmentSettingsResource(),
 		data_record.NewDataRecordResource(),
+		data_record.NewDataRecordsResource(),
+		data_record.NewDataRecordImportResource(),
 		publisher.NewPublisherResource(),
+		dataverse_metadata.NewTableResource(),
+		dataverse_metadata.NewColumnResource(),
+		dataverse_metadata.NewGlobalChoiceResource(),
+		dataverse_metadata.NewRelationshipResource(),
 		rest.NewDataverseWebApiResource(),
 		connection.NewConnectionResource(),
 		connection.NewConnectionShareResource(),
+		powerapps.NewPowerAppShareResource(),
+		powerapps.NewPowerAppOwnerResource(),
+		powerapps.NewPowerAppQuarantineResource(),
+		powerapps.NewPowerAppBypassConsentResource(),
 		admin_management_application.NewAdminManagementApplicationResource(),
 		environment_group_rule_set.NewEnvironmentGroupRuleSetResource(),
 		enterprise_policy.NewEnterpisePolicyResource(),
@@ -125,6 +146,7 @@ func TestUnitPowerPlatformProviderHasChildResources_Basic(t *testing.T) {
 		role_based_access.NewRoleBasedAccessAssignmentResource(),
 		role_based_access.NewEnvironmentGroupRoleBasedAccessAssignmentResource(),
 		role_based_access.NewEnvironmentRoleBasedAccessAssignmentResource(),
+		connectors.NewCustomConnectorResource(),
 	}
 	resources := provider.NewPowerPlatformProvider(context.Background())().(*provider.PowerPlatformProvider).Resources(context.Background())
 
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -183,19 +184,18 @@ func (client *client) getApplicationUsersByApplicationId(ctx context.Context, en
 	values.Add("$filter", fmt.Sprintf("applicationid eq %s", applicationId))
 	apiUrl.RawQuery = values.Encode()
 
-	var response applicationUsersResponseDto
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+	users, resp, err := api.ExecuteForAllPages[applicationUserDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
 	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
 		return nil, err
 	}
-	if resp.HttpResponse.StatusCode == http.StatusNotFound || len(response.Value) == 0 {
+	if resp.HttpResponse.StatusCode == http.StatusNotFound || len(users) == 0 {
 		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("application user '%s' not found in environment '%s'", applicationId, environmentId))
 	}
 
-	return response.Value, nil
+	return users, nil
 }
 
 func (client *client) GetApplicationUserSystemId(ctx context.Context, environmentId string, applicationId string) (string, error) {
@@ -372,8 +372,7 @@ func (client *client) GetDataverseSecurityRoles(ctx context.Context, environment
 	}
 	apiUrl.RawQuery = values.Encode()
 
-	var response applicationSecurityRoleArrayDto
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+	securityRoles, resp, err := api.ExecuteForAllPages[applicationSecurityRoleDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -384,7 +383,7 @@ func (client *client) GetDataverseSecurityRoles(ctx context.Context, environment
 		return nil, err
 	}
 
-	return response.Value, nil
+	return securityRoles, nil
 }
 
 func (client *client) ResolveSecurityRoleNames(ctx context.Context, environmentId, businessUnitId string, roleNames []string) ([]applicationSecurityRoleDto, error) {
@@ -560,14 +559,12 @@ func (client *client) GetTenantApplications(ctx context.Context) ([]tenantApplic
 	values.Add(constants.API_VERSION_PARAM, constants.APPLICATION_API_VERSION)
 	apiUrl.RawQuery = values.Encode()
 
-	application := tenantApplicationArrayDto{}
-
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &application)
+	applications, _, err := api.ExecuteForAllPages[tenantApplicationDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return application.Value, nil
+	return applications, nil
 }
 
 func (client *client) GetApplicationsByEnvironmentId(ctx context.Context, environmentId string) ([]environmentApplicationDto, error) {
@@ -580,14 +577,50 @@ func (client *client) GetApplicationsByEnvironmentId(ctx context.Context, enviro
 	values.Add(constants.API_VERSION_PARAM, constants.APPLICATION_API_VERSION)
 	apiUrl.RawQuery = values.Encode()
 
-	application := environmentApplicationArrayDto{}
+	applications, _, err := api.ExecuteForAllPages[environmentApplicationDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
 
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &application)
+// GetInstalledApplicationPackage returns the package with the given unique name that is installed in the environment.
+func (client *client) GetInstalledApplicationPackage(ctx context.Context, environmentId string, uniqueName string) (*environmentApplicationDto, error) {
+	applications, err := client.GetApplicationsByEnvironmentId(ctx, environmentId)
 	if err != nil {
 		return nil, err
 	}
 
-	return application.Value, nil
+	for _, application := range applications {
+		if strings.EqualFold(application.UniqueName, uniqueName) && application.State == APPLICATION_PACKAGE_STATE_INSTALLED {
+			return &application, nil
+		}
+	}
+	return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("application package '%s' is not installed in environment '%s'", uniqueName, environmentId))
+}
+
+// GetAvailableApplicationPackageVersion returns the version of the package with the given unique name that the catalog
+// offers for the environment. When the newest version is already installed, the catalog only lists the installed package.
+func (client *client) GetAvailableApplicationPackageVersion(ctx context.Context, environmentId string, uniqueName string) (string, error) {
+	applications, err := client.GetApplicationsByEnvironmentId(ctx, environmentId)
+	if err != nil {
+		return "", err
+	}
+
+	availableVersion := ""
+	for _, application := range applications {
+		if !strings.EqualFold(application.UniqueName, uniqueName) {
+			continue
+		}
+		if application.State != APPLICATION_PACKAGE_STATE_INSTALLED || availableVersion == "" {
+			availableVersion = application.Version
+		}
+	}
+	if availableVersion == "" {
+		return "", customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("application package '%s' is not available in environment '%s'", uniqueName, environmentId))
+	}
+	return availableVersion, nil
 }
 
 func (client *client) InstallApplicationInEnvironment(ctx context.Context, environmentId string, uniqueName string) (string, error) {
@@ -662,3 +695,45 @@ func (client *client) InstallApplicationInEnvironment(ctx context.Context, envir
 func (client *client) getApplicationUserBySystemId(ctx context.Context, environmentId string, systemUserId string) (*applicationUserDto, error) {
 	return client.GetPrincipalBySystemUserId(ctx, environmentId, systemUserId)
 }
+
+// compareApplicationVersions compares two dot separated version strings and returns -1, 0 or 1.
+func compareApplicationVersions(left, right string) (int, error) {
+	leftParts, err := parseApplicationVersion(left)
+	if err != nil {
+		return 0, err
+	}
+	rightParts, err := parseApplicationVersion(right)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < max(len(leftParts), len(rightParts)); i++ {
+		var l, r int
+		if i < len(leftParts) {
+			l = leftParts[i]
+		}
+		if i < len(rightParts) {
+			r = rightParts[i]
+		}
+		if l != r {
+			if l < r {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseApplicationVersion(version string) ([]int, error) {
+	segments := strings.Split(strings.TrimSpace(version), ".")
+	parts := make([]int, 0, len(segments))
+	for _, segment := range segments {
+		part, err := strconv.Atoi(segment)
+		if err != nil || part < 0 {
+			return nil, fmt.Errorf("invalid application package version '%s'", version)
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
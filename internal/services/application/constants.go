@@ -0,0 +1,8 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package application
+
+const (
+	APPLICATION_PACKAGE_STATE_INSTALLED = "Installed"
+)
@@ -26,14 +26,6 @@ type tenantApplicationErrorDetailsDto struct {
 	Type       string `json:"type"`
 }
 
-type tenantApplicationArrayDto struct {
-	Value []tenantApplicationDto `json:"value"`
-}
-
-type environmentApplicationArrayDto struct {
-	Value []environmentApplicationDto `json:"value"`
-}
-
 type environmentApplicationDto struct {
 	ApplicationId         string `json:"applicationId"`
 	Name                  string `json:"applicationName"`
@@ -88,10 +80,6 @@ type linkedEnvironmentIdMetadataDto struct {
 	InstanceURL string
 }
 
-type applicationUsersResponseDto struct {
-	Value []applicationUserDto `json:"value"`
-}
-
 type applicationUserDto struct {
 	FullName       string                       `json:"fullname"`
 	ApplicationId  string                       `json:"applicationid"`
@@ -108,10 +96,6 @@ type applicationSecurityRoleDto struct {
 	BusinessUnitId string `json:"_businessunitid_value"`
 }
 
-type applicationSecurityRoleArrayDto struct {
-	Value []applicationSecurityRoleDto `json:"value"`
-}
-
 type applicationBusinessUnitArrayDto struct {
 	Value []applicationBusinessUnitDto `json:"value"`
 }
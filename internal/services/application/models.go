@@ -54,6 +54,7 @@ type EnvironmentApplicationPackageInstallResourceModel struct {
 	Id            types.String   `tfsdk:"id"`
 	UniqueName    types.String   `tfsdk:"unique_name"`
 	EnvironmentId types.String   `tfsdk:"environment_id"`
+	Version       types.String   `tfsdk:"version"`
 }
 
 type UserResource struct {
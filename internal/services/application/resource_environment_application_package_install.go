@@ -5,18 +5,23 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
 	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
 )
 
@@ -44,10 +49,11 @@ func (r *EnvironmentApplicationPackageInstallResource) Schema(ctx context.Contex
 	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
 	defer exitContext()
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "This resource allows you to install a Dynamics 365 application in an environment. This is functionally equivalent to the 'Install' button in the Power Platform admin center or [`pac application install` in the Power Platform CLI](https://docs.microsoft.com/powerapps/developer/data-platform/powerapps-cli#pac-application-install).  This resource uses the [Install Application Package](https://learn.microsoft.com/rest/api/power-platform/appmanagement/applications/install-application-package) endpoint in the Power Platform API.",
+		MarkdownDescription: "This resource allows you to install a Dynamics 365 application in an environment. This is functionally equivalent to the 'Install' button in the Power Platform admin center or [`pac application install` in the Power Platform CLI](https://docs.microsoft.com/powerapps/developer/data-platform/powerapps-cli#pac-application-install).  This resource uses the [Install Application Package](https://learn.microsoft.com/rest/api/power-platform/appmanagement/applications/install-application-package) endpoint in the Power Platform API.\n\nWhen `version` is set, the resource installs exactly that version of the package. Changing `version` upgrades the package in place instead of replacing the resource. Downgrades are not supported by the Power Platform API. The installed version is read back on every refresh, so a package that is behind the configured version shows up as a change in the plan.",
 		Attributes: map[string]schema.Attribute{
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
+				Update: true,
 			}),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "Unique id (guid)",
@@ -70,6 +76,17 @@ func (r *EnvironmentApplicationPackageInstallResource) Schema(ctx context.Contex
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the application package, for example `1.0.0.0`. When set, installation fails unless the catalog offers exactly this version and a change of the value upgrades the installed package. When not set, the version that is currently offered by the catalog is installed and reported.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(helpers.VersionRegex), "version must be in the format `major.minor.build.revision`"),
+				},
+			},
 		},
 	}
 }
@@ -119,6 +136,13 @@ func (r *EnvironmentApplicationPackageInstallResource) Create(ctx context.Contex
 		return
 	}
 
+	if !state.Version.IsNull() && !state.Version.IsUnknown() {
+		if err := r.validateAvailableVersion(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString(), state.Version.ValueString()); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+			return
+		}
+	}
+
 	applicationId, err := r.ApplicationClient.InstallApplicationInEnvironment(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
@@ -127,6 +151,13 @@ func (r *EnvironmentApplicationPackageInstallResource) Create(ctx context.Contex
 
 	state.Id = types.StringValue(applicationId)
 
+	installed, err := r.ApplicationClient.GetInstalledApplicationPackage(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading installed version of %s", r.FullTypeName()), err.Error())
+		return
+	}
+	state.Version = types.StringValue(installed.Version)
+
 	tflog.Trace(ctx, fmt.Sprintf("created a resource with ID %s", state.UniqueName.ValueString()))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -146,6 +177,24 @@ func (r *EnvironmentApplicationPackageInstallResource) Read(ctx context.Context,
 
 	tflog.Debug(ctx, fmt.Sprintf("READ: %s with application_name %s", r.FullTypeName(), state.UniqueName.ValueString()))
 
+	// An imported resource only knows its id until the configuration has been applied.
+	if state.EnvironmentId.ValueString() == "" || state.UniqueName.ValueString() == "" {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	installed, err := r.ApplicationClient.GetInstalledApplicationPackage(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	state.Version = types.StringValue(installed.Version)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -164,9 +213,47 @@ func (r *EnvironmentApplicationPackageInstallResource) Update(ctx context.Contex
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if plan.Version.IsUnknown() || plan.Version.IsNull() || plan.Version.Equal(state.Version) {
+		plan.Version = state.Version
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		tflog.Debug(ctx, "No application have been updated, as this is the expected behavior")
+		return
+	}
 
-	tflog.Debug(ctx, "No application have been updated, as this is the expected behavior")
+	cmp, err := compareApplicationVersions(plan.Version.ValueString(), state.Version.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+	if cmp < 0 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Cannot downgrade application package '%s'", plan.UniqueName.ValueString()),
+			fmt.Sprintf("Version '%s' is installed in environment '%s'. Application packages can't be downgraded to version '%s'.", state.Version.ValueString(), plan.EnvironmentId.ValueString(), plan.Version.ValueString()),
+		)
+		return
+	}
+
+	if err := r.validateAvailableVersion(ctx, plan.EnvironmentId.ValueString(), plan.UniqueName.ValueString(), plan.Version.ValueString()); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Upgrading application package '%s' from version '%s' to '%s'", plan.UniqueName.ValueString(), state.Version.ValueString(), plan.Version.ValueString()))
+
+	// Installing a package that is already installed upgrades it to the version offered by the catalog.
+	if _, err := r.ApplicationClient.InstallApplicationInEnvironment(ctx, plan.EnvironmentId.ValueString(), plan.UniqueName.ValueString()); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	installed, err := r.ApplicationClient.GetInstalledApplicationPackage(ctx, plan.EnvironmentId.ValueString(), plan.UniqueName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading installed version of %s", r.FullTypeName()), err.Error())
+		return
+	}
+	plan.Version = types.StringValue(installed.Version)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *EnvironmentApplicationPackageInstallResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -182,6 +269,24 @@ func (r *EnvironmentApplicationPackageInstallResource) Delete(ctx context.Contex
 	tflog.Debug(ctx, "No application have been uninstalled, as this is the expected behavior")
 }
 
+// validateAvailableVersion makes sure that the catalog offers the requested version of the package,
+// as the install endpoint always installs the newest version that is available for the environment.
+func (r *EnvironmentApplicationPackageInstallResource) validateAvailableVersion(ctx context.Context, environmentId, uniqueName, version string) error {
+	availableVersion, err := r.ApplicationClient.GetAvailableApplicationPackageVersion(ctx, environmentId, uniqueName)
+	if err != nil {
+		return err
+	}
+
+	cmp, err := compareApplicationVersions(version, availableVersion)
+	if err != nil {
+		return err
+	}
+	if cmp != 0 {
+		return fmt.Errorf("version '%s' of application package '%s' is not available in environment '%s', the available version is '%s'", version, uniqueName, environmentId, availableVersion)
+	}
+	return nil
+}
+
 func (r *EnvironmentApplicationPackageInstallResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
 	defer exitContext()
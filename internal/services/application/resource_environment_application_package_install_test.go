@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/jarcoal/httpmock"
 	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
 	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
@@ -89,6 +91,12 @@ func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Install(t *te
 		},
 	)
 
+	httpmock.RegisterResponder("GET", "https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/applicationPackages?api-version=2022-03-01-preview",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Install/get_application_packages.json").String()), nil
+		},
+	)
+
 	httpmock.RegisterResponder("GET", "https://europe.api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/lifecycleOperations/00000000-0000-0000-0000-000000000001?api-version=2023-06-01",
 		func(req *http.Request) (*http.Response, error) {
 			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Install/get_lifecycle_delete.json").String()), nil
@@ -145,6 +153,7 @@ func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Install(t *te
 					resource.TestMatchResourceAttr("powerplatform_environment_application_package_install.development", "id", regexp.MustCompile(helpers.StringRegex)),
 					resource.TestMatchResourceAttr("powerplatform_environment_application_package_install.development", "environment_id", regexp.MustCompile(helpers.GuidRegex)),
 					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "unique_name", "ProcessMiningAnchor"),
+					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "version", "1.3.0.8"),
 				),
 			},
 			{
@@ -168,6 +177,7 @@ func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Install(t *te
 					resource.TestMatchResourceAttr("powerplatform_environment_application_package_install.development", "id", regexp.MustCompile(helpers.StringRegex)),
 					resource.TestMatchResourceAttr("powerplatform_environment_application_package_install.development", "environment_id", regexp.MustCompile(helpers.GuidRegex)),
 					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "unique_name", "MicrosoftFormsPro"),
+					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "version", "2.0.0.45"),
 				),
 			},
 		},
@@ -255,3 +265,157 @@ func TestUnitEnvironmentApplicationPackageInstallResource_Validate_No_Dataverse(
 		},
 	})
 }
+
+func registerApplicationPackageVersionMocks(installedVersion *string, catalogVersion *string) {
+	mocks.ActivateEnvironmentHttpMocks()
+
+	httpmock.RegisterResponder("GET", `=~^https://api\.bap\.microsoft\.com/providers/Microsoft\.BusinessAppPlatform/scopes/admin/environments/([\d-]+)\z`,
+		func(req *http.Request) (*http.Response, error) {
+			id := httpmock.MustGetSubmatch(req, 1)
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File(fmt.Sprintf("tests/resource/Validate_Install/get_environment_%s.json", id)).String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", "https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/applicationPackages?api-version=2022-03-01-preview",
+		func(req *http.Request) (*http.Response, error) {
+			packages := []string{}
+			if *installedVersion != "" {
+				packages = append(packages, fmt.Sprintf(`{"uniqueName":"ProcessMiningAnchor","applicationId":"9b6bc8a3-7a83-4a7c-9a1d-6b4b5a2f2b11","version":"%s","state":"Installed"}`, *installedVersion))
+			}
+			if *catalogVersion != *installedVersion {
+				packages = append(packages, fmt.Sprintf(`{"uniqueName":"ProcessMiningAnchor","applicationId":"9b6bc8a3-7a83-4a7c-9a1d-6b4b5a2f2b11","version":"%s","state":"None"}`, *catalogVersion))
+			}
+			return httpmock.NewStringResponse(http.StatusOK, fmt.Sprintf(`{"value":[%s]}`, strings.Join(packages, ","))), nil
+		})
+
+	httpmock.RegisterResponder("POST", "https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/applicationPackages/ProcessMiningAnchor/install?api-version=2022-03-01-preview",
+		func(req *http.Request) (*http.Response, error) {
+			*installedVersion = *catalogVersion
+			resp := httpmock.NewStringResponse(http.StatusAccepted, "")
+			resp.Header.Add("Operation-Location", "https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/operations/475af49d-9bca-437f-8be1-9e467f44be8a?api-version=1")
+			return resp, nil
+		})
+
+	httpmock.RegisterResponder("GET", "https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/operations/475af49d-9bca-437f-8be1-9e467f44be8a?api-version=1",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Install/get_operation.json").String()), nil
+		})
+}
+
+func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Version_Upgrade(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	installedVersion := ""
+	catalogVersion := "1.3.0.8"
+	registerApplicationPackageVersionMocks(&installedVersion, &catalogVersion)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment_application_package_install" "development" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					unique_name    = "ProcessMiningAnchor"
+					version        = "1.3.0.8"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "version", "1.3.0.8"),
+				),
+			},
+			{
+				PreConfig: func() {
+					catalogVersion = "1.4.0.2"
+				},
+				Config: `
+				resource "powerplatform_environment_application_package_install" "development" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					unique_name    = "ProcessMiningAnchor"
+					version        = "1.4.0.2"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "version", "1.4.0.2"),
+					func(_ *terraform.State) error {
+						calls := httpmock.GetCallCountInfo()["POST https://api.powerplatform.com/appmanagement/environments/00000000-0000-0000-0000-000000000001/applicationPackages/ProcessMiningAnchor/install?api-version=2022-03-01-preview"]
+						if calls != 2 {
+							return fmt.Errorf("expected 2 install calls, got %d", calls)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_environment_application_package_install" "development" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					unique_name    = "ProcessMiningAnchor"
+					version        = "1.3.0.8"
+				}`,
+				ExpectError: regexp.MustCompile("Cannot downgrade application package 'ProcessMiningAnchor'"),
+			},
+		},
+	})
+}
+
+func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Version_Drift(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	installedVersion := ""
+	catalogVersion := "1.4.0.2"
+	registerApplicationPackageVersionMocks(&installedVersion, &catalogVersion)
+
+	config := `
+	resource "powerplatform_environment_application_package_install" "development" {
+		environment_id = "00000000-0000-0000-0000-000000000001"
+		unique_name    = "ProcessMiningAnchor"
+		version        = "1.4.0.2"
+	}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_environment_application_package_install.development", "version", "1.4.0.2"),
+				),
+			},
+			{
+				PreConfig: func() {
+					installedVersion = "1.3.0.8"
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitEnvironmentApplicationPackageInstallResource_Validate_Version_Not_Available(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	installedVersion := ""
+	catalogVersion := "1.4.0.2"
+	registerApplicationPackageVersionMocks(&installedVersion, &catalogVersion)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment_application_package_install" "development" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					unique_name    = "ProcessMiningAnchor"
+					version        = "1.3.0.8"
+				}`,
+				ExpectError: regexp.MustCompile("version '1.3.0.8' of application package 'ProcessMiningAnchor' is not available"),
+			},
+		},
+	})
+}
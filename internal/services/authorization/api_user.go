@@ -54,8 +54,7 @@ func (client *client) GetDataverseUsers(ctx context.Context, environmentId strin
 	}
 
 	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/systemusers", nil)
-	userArray := userArrayDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &userArray)
+	users, resp, err := api.ExecuteForAllPages[userDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +64,7 @@ func (client *client) GetDataverseUsers(ctx context.Context, environmentId strin
 	if err := client.Api.HandleNotFoundResponse(resp); err != nil {
 		return nil, err
 	}
-	return userArray.Value, nil
+	return users, nil
 }
 
 func (client *client) GetDataverseUserBySystemUserId(ctx context.Context, environmentId, systemUserId string) (*userDto, error) {
@@ -146,8 +145,7 @@ func (client *client) GetDataverseUserByAadObjectId(ctx context.Context, environ
 
 	const maxRetries = 30
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		user := userArrayDto{}
-		resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &user)
+		users, resp, err := api.ExecuteForAllPages[userDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -158,8 +156,8 @@ func (client *client) GetDataverseUserByAadObjectId(ctx context.Context, environ
 			return nil, err
 		}
 
-		if len(user.Value) > 0 {
-			return &user.Value[0], nil
+		if len(users) > 0 {
+			return &users[0], nil
 		}
 
 		if attempt < maxRetries-1 {
@@ -533,8 +531,7 @@ func (client *client) GetDataverseSecurityRoles(ctx context.Context, environment
 		values.Add("$filter", fmt.Sprintf("_businessunitid_value eq %s", businessUnitId))
 		apiUrl.RawQuery = values.Encode()
 	}
-	securityRoleArray := securityRoleArrayDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &securityRoleArray)
+	securityRoles, resp, err := api.ExecuteForAllPages[securityRoleDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -544,5 +541,5 @@ func (client *client) GetDataverseSecurityRoles(ctx context.Context, environment
 	if err := client.Api.HandleNotFoundResponse(resp); err != nil {
 		return nil, err
 	}
-	return securityRoleArray.Value, nil
+	return securityRoles, nil
 }
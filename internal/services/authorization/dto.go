@@ -24,10 +24,6 @@ type securityRoleDto struct {
 	BusinessUnitId string `json:"_businessunitid_value"`
 }
 
-type securityRoleArrayDto struct {
-	Value []securityRoleDto `json:"value"`
-}
-
 func (u *userDto) securityRolesArray() []string {
 	if len(u.SecurityRoles) == 0 {
 		return []string{}
@@ -39,10 +35,6 @@ func (u *userDto) securityRolesArray() []string {
 	return roles
 }
 
-type userArrayDto struct {
-	Value []userDto `json:"value"`
-}
-
 type environmentIdDto struct {
 	Id         string                     `json:"id"`
 	Name       string                     `json:"name"`
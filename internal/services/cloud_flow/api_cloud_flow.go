@@ -0,0 +1,242 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/solution"
+)
+
+const (
+	cloudFlowSelect = "workflowid,name,description,statecode,statuscode,_ownerid_value,solutionid,ismanaged,modifiedon,createdon"
+	// cloudFlowDefinitionColumn holds the whole definition of the flow as json, which is only read when its trigger is needed.
+	cloudFlowDefinitionColumn = "clientdata"
+
+	// cloudFlowCategory is the workflow category of Power Automate cloud flows.
+	cloudFlowCategory = 5
+	// cloudFlowComponentType is the solution component type of workflows.
+	cloudFlowComponentType = 29
+
+	cloudFlowStateCodeOff       = 0
+	cloudFlowStateCodeOn        = 1
+	cloudFlowStateCodeSuspended = 2
+	cloudFlowStatusCodeOff      = 1
+	cloudFlowStatusCodeOn       = 2
+
+	cloudFlowStateOn        = "On"
+	cloudFlowStateOff       = "Off"
+	cloudFlowStateSuspended = "Suspended"
+)
+
+// systemSolutions hold every component of an environment, so membership in them doesn't tell anything about the flow.
+var systemSolutions = []string{"Active", "Basic", "Default"}
+
+func newCloudFlowClient(apiClient *api.Client) client {
+	return client{
+		Api:            apiClient,
+		SolutionClient: solution.NewSolutionClient(apiClient),
+	}
+}
+
+type client struct {
+	Api            *api.Client
+	SolutionClient solution.Client
+}
+
+func (client *client) DataverseExists(ctx context.Context, environmentId string) (bool, error) {
+	return client.SolutionClient.DataverseExists(ctx, environmentId)
+}
+
+// GetCloudFlows returns the cloud flows of the environment. Their definitions are only included when includeDefinition is set.
+func (client *client) GetCloudFlows(ctx context.Context, environmentId string, includeDefinition bool) ([]cloudFlowDto, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	selectColumns := cloudFlowSelect
+	if includeDefinition {
+		selectColumns += "," + cloudFlowDefinitionColumn
+	}
+
+	values := url.Values{}
+	values.Add("$select", selectColumns)
+	values.Add("$filter", fmt.Sprintf("category eq %d", cloudFlowCategory))
+	values.Add("$orderby", "name asc")
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/workflows", constants.DATAVERSE_API_VERSION), values)
+
+	flows, resp, err := api.ExecuteForAllPages[cloudFlowDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+	return flows, nil
+}
+
+// GetCloudFlowSolutions returns the unique names of the solutions, other than the system solutions, that contain each flow,
+// keyed by the id of the flow.
+func (client *client) GetCloudFlowSolutions(ctx context.Context, environmentId string) (map[string][]string, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	values.Add("$select", "objectid")
+	values.Add("$filter", fmt.Sprintf("componenttype eq %d", cloudFlowComponentType))
+	values.Add("$expand", "solutionid($select=uniquename)")
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/solutioncomponents", constants.DATAVERSE_API_VERSION), values)
+
+	components, resp, err := api.ExecuteForAllPages[cloudFlowSolutionComponentDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	solutions := map[string][]string{}
+	for _, component := range components {
+		if isSystemSolution(component.Solution.UniqueName) {
+			continue
+		}
+		objectId := strings.ToLower(component.ObjectId)
+		solutions[objectId] = append(solutions[objectId], component.Solution.UniqueName)
+	}
+	for objectId := range solutions {
+		sort.Strings(solutions[objectId])
+	}
+	return solutions, nil
+}
+
+func (client *client) GetCloudFlow(ctx context.Context, environmentId, flowId string) (*cloudFlowDto, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	values.Add("$select", cloudFlowSelect)
+	values.Add("$filter", fmt.Sprintf("workflowid eq %s and category eq %d", flowId, cloudFlowCategory))
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/workflows", constants.DATAVERSE_API_VERSION), values)
+
+	response := cloudFlowArrayDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	if len(response.Value) == 0 {
+		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("cloud flow '%s' not found", flowId))
+	}
+	return &response.Value[0], nil
+}
+
+// SetCloudFlowState turns the flow on or off. Turning a flow on fails when its connection references aren't bound to connections.
+func (client *client) SetCloudFlowState(ctx context.Context, environmentId, flowId string, on bool) error {
+	state := cloudFlowStateDto{
+		StateCode:  cloudFlowStateCodeOff,
+		StatusCode: cloudFlowStatusCodeOff,
+	}
+	if on {
+		state = cloudFlowStateDto{
+			StateCode:  cloudFlowStateCodeOn,
+			StatusCode: cloudFlowStatusCodeOn,
+		}
+	}
+	return client.patchCloudFlow(ctx, environmentId, flowId, state)
+}
+
+// SetCloudFlowOwner assigns the flow to the user with the given Dataverse system user id.
+func (client *client) SetCloudFlowOwner(ctx context.Context, environmentId, flowId, ownerId string) error {
+	return client.patchCloudFlow(ctx, environmentId, flowId, cloudFlowOwnerDto{
+		OwnerId: fmt.Sprintf("/systemusers(%s)", ownerId),
+	})
+}
+
+func (client *client) patchCloudFlow(ctx context.Context, environmentId, flowId string, body any) error {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/workflows(%s)", constants.DATAVERSE_API_VERSION, flowId), nil)
+	resp, err := client.Api.Execute(ctx, nil, "PATCH", apiUrl, nil, body, []int{http.StatusNoContent, http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+func isSystemSolution(uniqueName string) bool {
+	for _, systemSolution := range systemSolutions {
+		if strings.EqualFold(uniqueName, systemSolution) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloudFlowState returns the state of the flow as shown in Power Automate.
+func cloudFlowState(flow cloudFlowDto) string {
+	switch flow.StateCode {
+	case cloudFlowStateCodeOn:
+		return cloudFlowStateOn
+	case cloudFlowStateCodeSuspended:
+		return cloudFlowStateSuspended
+	default:
+		return cloudFlowStateOff
+	}
+}
+
+// cloudFlowTriggerType classifies the trigger of the flow the way Power Automate does: flows started on a schedule are
+// `Scheduled`, flows started manually, from an app or by an http request are `Instant`, and flows started by an event are `Automated`.
+// An empty string is returned when the definition of the flow has no trigger.
+func cloudFlowTriggerType(flow cloudFlowDto) string {
+	clientData := cloudFlowClientDataDto{}
+	if err := json.Unmarshal([]byte(flow.ClientData), &clientData); err != nil {
+		return ""
+	}
+
+	for _, trigger := range clientData.Properties.Definition.Triggers {
+		switch strings.ToLower(trigger.Type) {
+		case "recurrence":
+			return "Scheduled"
+		case "request":
+			return "Instant"
+		default:
+			return "Automated"
+		}
+	}
+	return ""
+}
+
+func cloudFlowStateResourceId(environmentId, flowId string) string {
+	return fmt.Sprintf("%s/%s", environmentId, flowId)
+}
+
+func parseCloudFlowStateResourceId(id string) (environmentId string, flowId string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid import id %q, expected {environment_id}/{flow_id}", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &DataSource{}
+	_ datasource.DataSourceWithConfigure = &DataSource{}
+)
+
+func NewCloudFlowsDataSource() datasource.DataSource {
+	return &DataSource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "cloud_flows",
+		},
+	}
+}
+
+func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	d.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = d.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the Power Automate cloud flows stored in the Dataverse database of an environment.\n\nAdditional Resources:\n\n* [Manage cloud flows in solutions](https://learn.microsoft.com/power-automate/overview-solution-flows)\n",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Read: true,
+			}),
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse environment to list the cloud flows of.",
+				Required:            true,
+			},
+			"include_trigger_type": schema.BoolAttribute{
+				MarkdownDescription: "Whether to read the definition of every flow to determine its `trigger_type`. The definitions can be large, so reading them slows down environments with many flows. Defaults to `false`.",
+				Optional:            true,
+			},
+			"cloud_flows": schema.ListNestedAttribute{
+				MarkdownDescription: "List of cloud flows",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Unique id of the flow (guid)",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the flow",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the flow",
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							MarkdownDescription: "State of the flow, one of `On`, `Off` or `Suspended`",
+							Computed:            true,
+						},
+						"owner_id": schema.StringAttribute{
+							MarkdownDescription: "Dataverse system user id of the owner of the flow",
+							Computed:            true,
+						},
+						"trigger_type": schema.StringAttribute{
+							MarkdownDescription: "How the flow is started, one of `Automated`, `Instant` or `Scheduled`. Only set when `include_trigger_type` is `true`, empty when the flow has no trigger.",
+							Computed:            true,
+						},
+						"is_managed": schema.BoolAttribute{
+							MarkdownDescription: "Whether the flow was installed by a managed solution",
+							Computed:            true,
+						},
+						"solutions": schema.ListAttribute{
+							MarkdownDescription: "Unique names of the solutions that contain the flow. Empty for flows that aren't part of a solution.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"created_time": schema.StringAttribute{
+							MarkdownDescription: "Created time",
+							Computed:            true,
+						},
+						"last_modified_time": schema.StringAttribute{
+							MarkdownDescription: "Last modified time",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.CloudFlowClient = newCloudFlowClient(client.Api)
+}
+
+func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	var state ListDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentId := state.EnvironmentId.ValueString()
+	flows, err := d.CloudFlowClient.GetCloudFlows(ctx, environmentId, state.IncludeTriggerType.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", d.FullTypeName()), err.Error())
+		return
+	}
+
+	solutions, err := d.CloudFlowClient.GetCloudFlowSolutions(ctx, environmentId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", d.FullTypeName()), err.Error())
+		return
+	}
+
+	state.CloudFlows = []DataSourceModel{}
+	for _, flow := range flows {
+		state.CloudFlows = append(state.CloudFlows, convertFromCloudFlowDto(flow, solutions))
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
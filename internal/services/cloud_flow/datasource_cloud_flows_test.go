@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow_test
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestAccCloudFlowsDataSource_Validate_Read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+						language_code     = "1033"
+						currency_code     = "USD"
+						security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				data "powerplatform_cloud_flows" "all" {
+					environment_id = powerplatform_environment.env.id
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.#", regexp.MustCompile(`^\d+$`)),
+					resource.TestMatchResourceAttr("data.powerplatform_cloud_flows.all", "environment_id", regexp.MustCompile(helpers.GuidRegex)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudFlowsDataSource_Validate_Read(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_environment.json").String()))
+
+	// The definitions of the flows are only read when their trigger type is requested.
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/workflows\?%24filter=category\+eq\+5&`),
+		func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.URL.Query().Get("$select"), "clientdata") {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "flow definitions must be selected"), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_workflows.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/solutioncomponents\?.*%24filter=componenttype\+eq\+29`),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_solution_components.json").String()))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_cloud_flows" "all" {
+					environment_id       = "00000000-0000-0000-0000-000000000001"
+					include_trigger_type = true
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.#", "2"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.id", "22222222-2222-2222-2222-222222222221"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.name", "Notify on new order"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.state", "On"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.owner_id", "33333333-3333-3333-3333-333333333331"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.trigger_type", "Automated"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.is_managed", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.solutions.#", "1"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.solutions.0", "ContosoOrders"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.last_modified_time", "2024-05-02T09:30:00Z"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.id", "22222222-2222-2222-2222-222222222222"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.state", "Off"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.trigger_type", "Scheduled"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.is_managed", "false"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.solutions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCloudFlowsDataSource_Validate_Read_Without_Trigger_Type(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_environment.json").String()))
+
+	// The definitions of the flows are not paged through the list unless their trigger type is requested.
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/workflows\?%24filter=category\+eq\+5&`),
+		func(req *http.Request) (*http.Response, error) {
+			if strings.Contains(req.URL.Query().Get("$select"), "clientdata") {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "flow definitions must not be selected"), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_workflows_without_definition.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/solutioncomponents\?.*%24filter=componenttype\+eq\+29`),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/datasource/Validate_Read/get_solution_components.json").String()))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_cloud_flows" "all" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.#", "2"),
+					resource.TestCheckResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.id", "22222222-2222-2222-2222-222222222221"),
+					resource.TestCheckNoResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.0.trigger_type"),
+					resource.TestCheckNoResourceAttr("data.powerplatform_cloud_flows.all", "cloud_flows.1.trigger_type"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow
+
+type cloudFlowArrayDto struct {
+	Value []cloudFlowDto `json:"value"`
+}
+
+type cloudFlowDto struct {
+	WorkflowId  string `json:"workflowid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	StateCode   int    `json:"statecode"`
+	StatusCode  int    `json:"statuscode"`
+	OwnerId     string `json:"_ownerid_value"`
+	SolutionId  string `json:"solutionid"`
+	IsManaged   bool   `json:"ismanaged"`
+	ModifiedOn  string `json:"modifiedon"`
+	CreatedOn   string `json:"createdon"`
+	ClientData  string `json:"clientdata"`
+}
+
+// cloudFlowClientDataDto is the part of the flow definition, stored as json in the `clientdata` column, that holds its triggers.
+type cloudFlowClientDataDto struct {
+	Properties struct {
+		Definition struct {
+			Triggers map[string]struct {
+				Type string `json:"type"`
+				Kind string `json:"kind"`
+			} `json:"triggers"`
+		} `json:"definition"`
+	} `json:"properties"`
+}
+
+type cloudFlowSolutionComponentDto struct {
+	ObjectId string `json:"objectid"`
+	Solution struct {
+		UniqueName string `json:"uniquename"`
+	} `json:"solutionid"`
+}
+
+type cloudFlowStateDto struct {
+	StateCode  int `json:"statecode"`
+	StatusCode int `json:"statuscode"`
+}
+
+type cloudFlowOwnerDto struct {
+	OwnerId string `json:"ownerid@odata.bind"`
+}
@@ -0,0 +1,86 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+type DataSource struct {
+	helpers.TypeInfo
+	CloudFlowClient client
+}
+
+type ListDataSourceModel struct {
+	Timeouts           timeouts.Value    `tfsdk:"timeouts"`
+	EnvironmentId      types.String      `tfsdk:"environment_id"`
+	IncludeTriggerType types.Bool        `tfsdk:"include_trigger_type"`
+	CloudFlows         []DataSourceModel `tfsdk:"cloud_flows"`
+}
+
+type DataSourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	State            types.String `tfsdk:"state"`
+	OwnerId          types.String `tfsdk:"owner_id"`
+	TriggerType      types.String `tfsdk:"trigger_type"`
+	IsManaged        types.Bool   `tfsdk:"is_managed"`
+	Solutions        []string     `tfsdk:"solutions"`
+	CreatedTime      types.String `tfsdk:"created_time"`
+	LastModifiedTime types.String `tfsdk:"last_modified_time"`
+}
+
+func convertFromCloudFlowDto(flow cloudFlowDto, solutions map[string][]string) DataSourceModel {
+	model := DataSourceModel{
+		Id:               types.StringValue(flow.WorkflowId),
+		Name:             types.StringValue(flow.Name),
+		Description:      types.StringValue(flow.Description),
+		State:            types.StringValue(cloudFlowState(flow)),
+		OwnerId:          types.StringValue(flow.OwnerId),
+		TriggerType:      types.StringNull(),
+		IsManaged:        types.BoolValue(flow.IsManaged),
+		Solutions:        solutions[strings.ToLower(flow.WorkflowId)],
+		CreatedTime:      types.StringValue(flow.CreatedOn),
+		LastModifiedTime: types.StringValue(flow.ModifiedOn),
+	}
+	if triggerType := cloudFlowTriggerType(flow); triggerType != "" {
+		model.TriggerType = types.StringValue(triggerType)
+	}
+	if model.Solutions == nil {
+		model.Solutions = []string{}
+	}
+	return model
+}
+
+type StateResource struct {
+	helpers.TypeInfo
+	CloudFlowClient client
+}
+
+type StateResourceModel struct {
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	Id            types.String   `tfsdk:"id"`
+	EnvironmentId types.String   `tfsdk:"environment_id"`
+	FlowId        types.String   `tfsdk:"flow_id"`
+	State         types.String   `tfsdk:"state"`
+	OwnerId       types.String   `tfsdk:"owner_id"`
+	Name          types.String   `tfsdk:"name"`
+}
+
+func convertFromCloudFlowStateDto(environmentId string, flow *cloudFlowDto, timeoutValue timeouts.Value) StateResourceModel {
+	return StateResourceModel{
+		Timeouts:      timeoutValue,
+		Id:            types.StringValue(cloudFlowStateResourceId(environmentId, flow.WorkflowId)),
+		EnvironmentId: types.StringValue(environmentId),
+		FlowId:        types.StringValue(flow.WorkflowId),
+		State:         types.StringValue(cloudFlowState(*flow)),
+		OwnerId:       types.StringValue(flow.OwnerId),
+		Name:          types.StringValue(flow.Name),
+	}
+}
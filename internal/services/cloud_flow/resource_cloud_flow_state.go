@@ -0,0 +1,255 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &StateResource{}
+var _ resource.ResourceWithImportState = &StateResource{}
+
+func NewCloudFlowStateResource() resource.Resource {
+	return &StateResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "cloud_flow_state",
+		},
+	}
+}
+
+func (r *StateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *StateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Turns a solution-aware Power Automate cloud flow on or off, and optionally assigns it to another owner. This is typically used to switch on the flows of a managed solution after it was imported, once its connection references are bound to connections. Changes to the state or owner made outside of Terraform are detected as drift. Deleting this resource leaves the flow in its current state.\n\nAdditional Resources:\n\n* [Manage cloud flows in solutions](https://learn.microsoft.com/power-automate/overview-solution-flows)\n",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Read:   true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Composite resource id in the format `{environment_id}/{flow_id}`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse environment of the flow.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"flow_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the flow, e.g. the `id` of a flow of the `powerplatform_cloud_flows` data source.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "State of the flow, either `On` or `Off`. Turning a flow on fails when its connection references aren't bound to connections.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(cloudFlowStateOn, cloudFlowStateOff),
+				},
+			},
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "Dataverse system user id of the owner of the flow. The current owner is kept when not set.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the flow.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *StateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.CloudFlowClient = newCloudFlowClient(client.Api)
+}
+
+func (r *StateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan StateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentId := plan.EnvironmentId.ValueString()
+	dataverseExists, err := r.CloudFlowClient.DataverseExists(ctx, environmentId)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+	if !dataverseExists {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), fmt.Sprintf("environment %s does not have Dataverse linked", environmentId))
+		return
+	}
+
+	flow, err := r.applyCloudFlowState(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	state := convertFromCloudFlowStateDto(environmentId, flow, plan.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *StateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state StateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow, err := r.CloudFlowClient.GetCloudFlow(ctx, state.EnvironmentId.ValueString(), state.FlowId.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	// Use the state and owner the API returned, so that changing them outside of Terraform is detected as drift.
+	newState := convertFromCloudFlowStateDto(state.EnvironmentId.ValueString(), flow, state.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *StateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan StateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	flow, err := r.applyCloudFlowState(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	newState := convertFromCloudFlowStateDto(plan.EnvironmentId.ValueString(), flow, plan.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *StateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state StateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Leaving cloud flow '%s' in state '%s'", state.FlowId.ValueString(), state.State.ValueString()))
+}
+
+func (r *StateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, flowId, err := parseCloudFlowStateResourceId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), cloudFlowStateResourceId(environmentId, flowId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("flow_id"), flowId)...)
+}
+
+// applyCloudFlowState assigns the flow to the planned owner and then turns it on or off, skipping the changes the flow
+// already has. The owner is changed first, so that a flow that is turned on runs with the connections of its new owner.
+func (r *StateResource) applyCloudFlowState(ctx context.Context, plan *StateResourceModel) (*cloudFlowDto, error) {
+	environmentId := plan.EnvironmentId.ValueString()
+	flowId := plan.FlowId.ValueString()
+
+	flow, err := r.CloudFlowClient.GetCloudFlow(ctx, environmentId, flowId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plan.OwnerId.IsUnknown() && !plan.OwnerId.IsNull() && !strings.EqualFold(flow.OwnerId, plan.OwnerId.ValueString()) {
+		if err := r.CloudFlowClient.SetCloudFlowOwner(ctx, environmentId, flowId, plan.OwnerId.ValueString()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cloudFlowState(*flow) != plan.State.ValueString() {
+		if err := r.CloudFlowClient.SetCloudFlowState(ctx, environmentId, flowId, plan.State.ValueString() == cloudFlowStateOn); err != nil {
+			return nil, err
+		}
+	}
+
+	return r.CloudFlowClient.GetCloudFlow(ctx, environmentId, flowId)
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package cloud_flow_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitCloudFlowStateResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Validate_CRUD/get_environment.json").String()))
+
+	// The mocked API keeps the state and owner it was sent, so that the flow read back follows the applied changes.
+	response := map[string]any{}
+	if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_CRUD/get_workflow.json").String()), &response); err != nil {
+		t.Fatal(err)
+	}
+	flow := response["value"].([]any)[0].(map[string]any)
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/workflows\?%24filter=workflowid\+eq\+22222222-2222-2222-2222-222222222221\+and\+category\+eq\+5&`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, response)
+		})
+
+	httpmock.RegisterResponder("PATCH", "https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/workflows%2822222222-2222-2222-2222-222222222221%29",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			raw, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return nil, err
+			}
+			if owner, ok := body["ownerid@odata.bind"].(string); ok {
+				flow["_ownerid_value"] = strings.TrimSuffix(strings.TrimPrefix(owner, "/systemusers("), ")")
+			}
+			if stateCode, ok := body["statecode"]; ok {
+				flow["statecode"] = stateCode
+				flow["statuscode"] = body["statuscode"]
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_cloud_flow_state" "notify" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					flow_id        = "22222222-2222-2222-2222-222222222221"
+					state          = "On"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "id", "00000000-0000-0000-0000-000000000001/22222222-2222-2222-2222-222222222221"),
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "state", "On"),
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "owner_id", "33333333-3333-3333-3333-333333333331"),
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "name", "Notify on new order"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_cloud_flow_state" "notify" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					flow_id        = "22222222-2222-2222-2222-222222222221"
+					state          = "On"
+					owner_id       = "33333333-3333-3333-3333-333333333332"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "state", "On"),
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "owner_id", "33333333-3333-3333-3333-333333333332"),
+				),
+			},
+			{
+				// Turning the flow off in Power Automate is detected as drift.
+				PreConfig: func() {
+					flow["statecode"] = 0
+					flow["statuscode"] = 1
+				},
+				Config: `
+				resource "powerplatform_cloud_flow_state" "notify" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					flow_id        = "22222222-2222-2222-2222-222222222221"
+					state          = "On"
+					owner_id       = "33333333-3333-3333-3333-333333333332"
+				}`,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: `
+				resource "powerplatform_cloud_flow_state" "notify" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					flow_id        = "22222222-2222-2222-2222-222222222221"
+					state          = "Off"
+					owner_id       = "33333333-3333-3333-3333-333333333332"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_cloud_flow_state.notify", "state", "Off"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_cloud_flow_state.notify",
+				ImportState:       true,
+				ImportStateId:     "00000000-0000-0000-0000-000000000001/22222222-2222-2222-2222-222222222221",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitCloudFlowStateResource_Validate_Flow_Not_Found(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Validate_CRUD/get_environment.json").String()))
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/workflows\?`),
+		httpmock.NewStringResponder(http.StatusOK, `{"value":[]}`))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_cloud_flow_state" "notify" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					flow_id        = "22222222-2222-2222-2222-222222222229"
+					state          = "On"
+				}`,
+				ExpectError: regexp.MustCompile("cloud flow '22222222-2222-2222-2222-222222222229' not found"),
+			},
+		},
+	})
+}
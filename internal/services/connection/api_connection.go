@@ -21,17 +21,22 @@ import (
 	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
 )
 
-func newConnectionsClient(apiClient *api.Client) client {
-	return client{
+const (
+	// consentRedirectUrl is the redirect url of the consent links of connections.
+	consentRedirectUrl = "https://global.consent.azure-apim.net/redirect"
+)
+
+func NewConnectionsClient(apiClient *api.Client) Client {
+	return Client{
 		Api: apiClient,
 	}
 }
 
-type client struct {
+type Client struct {
 	Api *api.Client
 }
 
-func (client *client) CreateConnection(ctx context.Context, environmentId, connectorName string, connectionToCreate createDto) (*connectionDto, error) {
+func (client *Client) CreateConnection(ctx context.Context, environmentId, connectorName string, connectionToCreate createDto) (*ConnectionDto, error) {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -42,7 +47,7 @@ func (client *client) CreateConnection(ctx context.Context, environmentId, conne
 	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
 	apiUrl.RawQuery = values.Encode()
 
-	connection := connectionDto{}
+	connection := ConnectionDto{}
 	deadline := time.Now().Add(constants.CONNECTIVITY_ENVIRONMENT_POLL_TIMEOUT)
 	for {
 		_, err := client.Api.Execute(ctx, nil, "PUT", apiUrl.String(), nil, connectionToCreate, []int{http.StatusCreated}, &connection)
@@ -70,7 +75,7 @@ func isEnvironmentNotYetVisible(err error) bool {
 	return httpErr.StatusCode == http.StatusNotFound && strings.Contains(string(httpErr.Body), "ServiceToServiceEnvironmentNotFound")
 }
 
-func (client *client) UpdateConnection(ctx context.Context, environmentId, connectorName, connectionId, displayName string, connParams, connParamsSet map[string]any) (*connectionDto, error) {
+func (client *Client) UpdateConnection(ctx context.Context, environmentId, connectorName, connectionId, displayName string, connParams, connParamsSet map[string]any) (*ConnectionDto, error) {
 	conn, err := client.GetConnection(ctx, environmentId, connectorName, connectionId)
 	if err != nil {
 		return nil, err
@@ -90,7 +95,7 @@ func (client *client) UpdateConnection(ctx context.Context, environmentId, conne
 	conn.Properties.ConnectionParametersSet = connParamsSet
 	conn.Properties.ConnectionParameters = connParams
 
-	updatedConnection := connectionDto{}
+	updatedConnection := ConnectionDto{}
 	_, err = client.Api.Execute(ctx, nil, "PUT", apiUrl.String(), nil, conn, []int{http.StatusOK}, &updatedConnection)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update connection: %w", err)
@@ -99,7 +104,7 @@ func (client *client) UpdateConnection(ctx context.Context, environmentId, conne
 	return &updatedConnection, nil
 }
 
-func (client *client) GetConnection(ctx context.Context, environmentId, connectorName, connectionId string) (*connectionDto, error) {
+func (client *Client) GetConnection(ctx context.Context, environmentId, connectorName, connectionId string) (*ConnectionDto, error) {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -110,7 +115,7 @@ func (client *client) GetConnection(ctx context.Context, environmentId, connecto
 	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
 	apiUrl.RawQuery = values.Encode()
 
-	connection := connectionDto{}
+	connection := ConnectionDto{}
 	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &connection)
 	if err != nil {
 		if strings.Contains(err.Error(), "ConnectionNotFound") {
@@ -121,7 +126,7 @@ func (client *client) GetConnection(ctx context.Context, environmentId, connecto
 	return &connection, nil
 }
 
-func (client *client) GetConnections(ctx context.Context, environmentId string) ([]connectionDto, error) {
+func (client *Client) GetConnections(ctx context.Context, environmentId string) ([]ConnectionDto, error) {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -132,16 +137,15 @@ func (client *client) GetConnections(ctx context.Context, environmentId string)
 	values.Add("api-version", "1")
 	apiUrl.RawQuery = values.Encode()
 
-	connetionsArray := connectionArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &connetionsArray)
+	connections, _, err := api.ExecuteForAllPages[ConnectionDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connections: %w", err)
 	}
 
-	return connetionsArray.Value, nil
+	return connections, nil
 }
 
-func (client *client) DeleteConnection(ctx context.Context, environmentId, connectorName, connectionId string) error {
+func (client *Client) DeleteConnection(ctx context.Context, environmentId, connectorName, connectionId string) error {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -159,7 +163,39 @@ func (client *client) DeleteConnection(ctx context.Context, environmentId, conne
 	return nil
 }
 
-func (client *client) ShareConnection(ctx context.Context, environmentId, connectorName, connectionId, roleName, entraUserObjectId string) error {
+// GetConsentLink returns the link a user signs in with to consent to an unauthenticated connection parameter, usually `token`.
+func (client *Client) GetConsentLink(ctx context.Context, environmentId, connectorName, connectionId, parameterName string) (string, error) {
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
+		Path:   fmt.Sprintf("/connectivity/connectors/%s/connections/%s/listConsentLinks", connectorName, connectionId),
+	}
+	values := url.Values{}
+	values.Add("api-version", "1")
+	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
+	apiUrl.RawQuery = values.Encode()
+
+	request := consentLinksRequestDto{
+		Parameters: []consentLinkParameterDto{
+			{
+				ParameterName: parameterName,
+				RedirectUrl:   consentRedirectUrl,
+			},
+		},
+	}
+
+	links := consentLinksResponseDto{}
+	_, err := client.Api.Execute(ctx, nil, "POST", apiUrl.String(), nil, request, []int{http.StatusOK}, &links)
+	if err != nil {
+		return "", fmt.Errorf("failed to get consent link: %w", err)
+	}
+	if len(links.Value) == 0 || links.Value[0].Link == "" {
+		return "", fmt.Errorf("no consent link returned for parameter '%s' of connection '%s'", parameterName, connectionId)
+	}
+	return links.Value[0].Link, nil
+}
+
+func (client *Client) ShareConnection(ctx context.Context, environmentId, connectorName, connectionId, roleName, entraUserObjectId string) error {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -207,7 +243,7 @@ func getPrincipalString(principal map[string]any, key string) (string, error) {
 	return value, nil
 }
 
-func (client *client) GetConnectionShares(ctx context.Context, environmentId, connectorName, connectionId string) (*shareConnectionResponseArrayDto, error) {
+func (client *Client) GetConnectionShares(ctx context.Context, environmentId, connectorName, connectionId string) (*shareConnectionResponseArrayDto, error) {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -218,12 +254,11 @@ func (client *client) GetConnectionShares(ctx context.Context, environmentId, co
 	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
 	apiUrl.RawQuery = values.Encode()
 
-	share := shareConnectionResponseArrayDto{}
-
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &share)
+	shares, _, err := api.ExecuteForAllPages[shareConnectionResponseDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connection shares: %w", err)
 	}
+	share := shareConnectionResponseArrayDto{Value: shares}
 
 	sort.SliceStable(share.Value, func(i, j int) bool {
 		idI, errI := getPrincipalString(share.Value[i].Properties.Principal, "id")
@@ -237,7 +272,7 @@ func (client *client) GetConnectionShares(ctx context.Context, environmentId, co
 	return &share, nil
 }
 
-func (client *client) GetConnectionShare(ctx context.Context, environmentId, connectorName, connectionId, principalId string) (*shareConnectionResponseDto, error) {
+func (client *Client) GetConnectionShare(ctx context.Context, environmentId, connectorName, connectionId, principalId string) (*shareConnectionResponseDto, error) {
 	shares, err := client.GetConnectionShares(ctx, environmentId, connectorName, connectionId)
 	if err != nil {
 		return nil, err
@@ -262,7 +297,7 @@ func (client *client) GetConnectionShare(ctx context.Context, environmentId, con
 		fmt.Sprintf("Share for principal '%s' not found", principalId))
 }
 
-func (client *client) UpdateConnectionShare(ctx context.Context, environmentId, connectorName, connectionId string, share shareConnectionRequestDto) error {
+func (client *Client) UpdateConnectionShare(ctx context.Context, environmentId, connectorName, connectionId string, share shareConnectionRequestDto) error {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
@@ -280,7 +315,7 @@ func (client *client) UpdateConnectionShare(ctx context.Context, environmentId,
 	return nil
 }
 
-func (client *client) DeleteConnectionShare(ctx context.Context, environmentId, connectorName, connectionId, shareId string) error {
+func (client *Client) DeleteConnectionShare(ctx context.Context, environmentId, connectorName, connectionId, shareId string) error {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   helpers.BuildEnvironmentHostUri(environmentId, client.Api.GetConfig().Urls.PowerPlatformUrl),
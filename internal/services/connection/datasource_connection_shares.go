@@ -115,7 +115,7 @@ func (d *SharesDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	d.ConnectionsClient = newConnectionsClient(client.Api)
+	d.ConnectionsClient = NewConnectionsClient(client.Api)
 }
 func (d *SharesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
@@ -111,7 +111,7 @@ func (d *ConnectionsDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	d.ConnectionsClient = newConnectionsClient(client.Api)
+	d.ConnectionsClient = NewConnectionsClient(client.Api)
 }
 
 func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -143,7 +143,7 @@ func (d *ConnectionsDataSource) Read(ctx context.Context, req datasource.ReadReq
 	}
 }
 
-func ConvertFromConnectionDto(connection connectionDto) ConnectionsDataSourceModel {
+func ConvertFromConnectionDto(connection ConnectionDto) ConnectionsDataSourceModel {
 	nameConnectorSplit := strings.Split(connection.Properties.ApiId, "/")
 	nameConnector := nameConnectorSplit[len(nameConnectorSplit)-1]
 
@@ -4,10 +4,10 @@
 package connection
 
 type connectionArrayDto struct {
-	Value []connectionDto `json:"value"`
+	Value []ConnectionDto `json:"value"`
 }
 
-type connectionDto struct {
+type ConnectionDto struct {
 	Name       string                  `json:"name"`
 	Id         string                  `json:"id"`
 	Type       string                  `json:"type"`
@@ -34,7 +34,14 @@ type connectionPropertiesDto struct {
 }
 
 type statusDto struct {
-	Status string `json:"status"`
+	Status string          `json:"status"`
+	Target string          `json:"target,omitempty"`
+	Error  *statusErrorDto `json:"error,omitempty"`
+}
+
+type statusErrorDto struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
 }
 
 type createdByDto struct {
@@ -135,3 +142,22 @@ type shareConnectionResponsePropertiesDto struct {
 	NotifyShareTargetOption string         `json:"NotifyShareTargetOption"`
 	InviteGuestToTenant     bool           `json:"inviteGuestToTenant"`
 }
+
+type consentLinksRequestDto struct {
+	Parameters []consentLinkParameterDto `json:"parameters"`
+}
+
+type consentLinkParameterDto struct {
+	ParameterName string `json:"parameterName"`
+	RedirectUrl   string `json:"redirectUrl"`
+}
+
+type consentLinksResponseDto struct {
+	Value []consentLinkDto `json:"value"`
+}
+
+type consentLinkDto struct {
+	Link        string `json:"link"`
+	DisplayName string `json:"displayName"`
+	Status      string `json:"status"`
+}
@@ -12,7 +12,7 @@ import (
 
 type SharesDataSource struct {
 	helpers.TypeInfo
-	ConnectionsClient client
+	ConnectionsClient Client
 }
 
 type SharesListDataSourceModel struct {
@@ -36,7 +36,7 @@ type SharesPrincipalDataSourceModel struct {
 
 type ConnectionsDataSource struct {
 	helpers.TypeInfo
-	ConnectionsClient client
+	ConnectionsClient Client
 }
 
 type ConnectionsListDataSourceModel struct {
@@ -56,7 +56,7 @@ type ConnectionsDataSourceModel struct {
 
 type ShareResource struct {
 	helpers.TypeInfo
-	ConnectionsClient client
+	ConnectionsClient Client
 	EnvironmentClient environment.Client
 }
 
@@ -77,7 +77,7 @@ type SharePrincipalResourceModel struct {
 
 type Resource struct {
 	helpers.TypeInfo
-	ConnectionsClient client
+	ConnectionsClient Client
 	EnvironmentClient environment.Client
 }
 
@@ -90,4 +90,5 @@ type ResourceModel struct {
 	Status                  types.Set      `tfsdk:"status"`
 	ConnectionParameters    types.String   `tfsdk:"connection_parameters"`
 	ConnectionParametersSet types.String   `tfsdk:"connection_parameters_set"`
+	ConsentUrl              types.String   `tfsdk:"consent_url"`
 }
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
@@ -28,6 +29,17 @@ import (
 
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithImportState = &Resource{}
+var _ resource.ResourceWithValidateConfig = &Resource{}
+
+const (
+	// clientCredentialsParameterSet is the name of the connection parameter set of connectors that authenticate
+	// with the OAuth client credentials of a service principal.
+	clientCredentialsParameterSet = "oauthClientCredentials"
+	// connectionStatusError is the status of a connection that can't connect, e.g. because it waits for user consent.
+	connectionStatusError = "Error"
+	// unauthenticatedErrorCode is the error code of a connection status that waits for user consent.
+	unauthenticatedErrorCode = "Unauthenticated"
+)
 
 func NewConnectionResource() resource.Resource {
 	return &Resource{
@@ -103,13 +115,17 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				},
 			},
 			"connection_parameters_set": schema.StringAttribute{
-				MarkdownDescription: "Set of connection parameters. Json string containing the authentication connection parameters (if connection is interactive, leave blank), (for example)[https://learn.microsoft.com/en-us/power-automate/desktop-flows/alm/alm-connection#create-a-connection-using-your-service-principal]. Depending on required authentication parameters of a given connector, the connection parameters can vary. Due to how connection parameters and served by the platform, not all values are retrieved. If you don't want the connection to requried in-place-update all the time, consider using `ignore_changes` in the resource block.",
+				MarkdownDescription: "Set of connection parameters. Json string containing the authentication connection parameters (if connection is interactive, leave blank), (for example)[https://learn.microsoft.com/en-us/power-automate/desktop-flows/alm/alm-connection#create-a-connection-using-your-service-principal]. Depending on required authentication parameters of a given connector, the connection parameters can vary. Due to how connection parameters and served by the platform, not all values are retrieved. If you don't want the connection to requried in-place-update all the time, consider using `ignore_changes` in the resource block. Connectors that accept a service principal use the `oauthClientCredentials` set with the `token:clientId`, `token:clientSecret` and `token:TenantId` values, the status of such connections is checked after they are created or updated.",
 				Computed:            true,
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"consent_url": schema.StringAttribute{
+				MarkdownDescription: "Link a user signs in with to consent to the connection while it is unauthenticated, for example for connectors that require OAuth user consent. Empty once the connection is authenticated.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -123,6 +139,21 @@ func (d *Resource) ConfigValidators(ctx context.Context) []resource.ConfigValida
 	}
 }
 
+func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var config ResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ConnectionParametersSet.IsNull() || config.ConnectionParametersSet.IsUnknown() {
+		return
+	}
+
+	if err := validateClientCredentialsParameterSet(config.ConnectionParametersSet.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("connection_parameters_set"), "Invalid connection parameters set", err.Error())
+	}
+}
+
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
 	defer exitContext()
@@ -139,7 +170,7 @@ func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest,
 		)
 		return
 	}
-	r.ConnectionsClient = newConnectionsClient(client.Api)
+	r.ConnectionsClient = NewConnectionsClient(client.Api)
 	r.EnvironmentClient = environment.NewEnvironmentClient(client.Api)
 }
 
@@ -196,8 +227,12 @@ func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp
 	plan.Name = types.String(conectionState.Name)
 	plan.ConnectionParameters = normalizeConnectionParameter(plan.ConnectionParameters, conectionState.ConnectionParameters)
 	plan.ConnectionParametersSet = normalizeConnectionParameter(plan.ConnectionParametersSet, conectionState.ConnectionParametersSet)
+	plan.ConsentUrl = r.getConsentUrl(ctx, plan.EnvironmentId.ValueString(), plan.Name.ValueString(), *connection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if err := checkClientCredentialsStatus(plan.ConnectionParametersSet, *connection); err != nil {
+		resp.Diagnostics.AddError("Failed to create connection", err.Error())
+	}
 }
 
 func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -233,6 +268,7 @@ func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *res
 	state.Name = types.String(conectionState.Name)
 	state.ConnectionParameters = normalizeConnectionParameter(state.ConnectionParameters, conectionState.ConnectionParameters)
 	state.ConnectionParametersSet = normalizeConnectionParameter(state.ConnectionParametersSet, conectionState.ConnectionParametersSet)
+	state.ConsentUrl = r.getConsentUrl(ctx, state.EnvironmentId.ValueString(), state.Name.ValueString(), *connection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
@@ -282,8 +318,12 @@ func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp
 	plan.Status = types.SetValueMust(types.StringType, uniqueStatusValues(conectionState.Status))
 	plan.ConnectionParameters = normalizeConnectionParameter(plan.ConnectionParameters, conectionState.ConnectionParameters)
 	plan.ConnectionParametersSet = normalizeConnectionParameter(plan.ConnectionParametersSet, conectionState.ConnectionParametersSet)
+	plan.ConsentUrl = r.getConsentUrl(ctx, plan.EnvironmentId.ValueString(), plan.Name.ValueString(), *connection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if err := checkClientCredentialsStatus(plan.ConnectionParametersSet, *connection); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+	}
 }
 
 func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -335,3 +375,89 @@ func uniqueStatusValues(statuses []string) []attr.Value {
 	}
 	return values
 }
+
+// getConsentUrl returns the consent link of a connection that waits for user consent, and null otherwise.
+// Failing to get the link doesn't fail the operation, the connection itself is unaffected.
+func (r *Resource) getConsentUrl(ctx context.Context, environmentId, connectorName string, connection ConnectionDto) types.String {
+	parameterName, ok := unauthenticatedParameter(connection)
+	if !ok {
+		return types.StringNull()
+	}
+
+	link, err := r.ConnectionsClient.GetConsentLink(ctx, environmentId, connectorName, connection.Name, parameterName)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to get the consent link of connection '%s': %s", connection.Name, err.Error()))
+		return types.StringNull()
+	}
+	return types.StringValue(link)
+}
+
+// unauthenticatedParameter returns the connection parameter that waits for user consent, `token` unless a status names another one.
+func unauthenticatedParameter(connection ConnectionDto) (string, bool) {
+	for _, status := range connection.Properties.Statuses {
+		if status.Status == unauthenticatedErrorCode || (status.Error != nil && status.Error.Code == unauthenticatedErrorCode) {
+			if status.Target != "" {
+				return status.Target, true
+			}
+			return "token", true
+		}
+	}
+	return "", false
+}
+
+// checkClientCredentialsStatus returns an error when a connection that authenticates with client credentials can't connect.
+func checkClientCredentialsStatus(connectionParametersSet types.String, connection ConnectionDto) error {
+	if parameterSetName(connectionParametersSet.ValueString()) != clientCredentialsParameterSet {
+		return nil
+	}
+
+	for _, status := range connection.Properties.Statuses {
+		if status.Status != connectionStatusError {
+			continue
+		}
+		if status.Error != nil {
+			return fmt.Errorf("connection '%s' failed to authenticate with client credentials: %s: %s", connection.Name, status.Error.Code, status.Error.Message)
+		}
+		return fmt.Errorf("connection '%s' failed to authenticate with client credentials", connection.Name)
+	}
+	return nil
+}
+
+// parameterSetName returns the name of a connection parameters set, or an empty string when it can't be parsed.
+func parameterSetName(connectionParametersSet string) string {
+	parametersSet := struct {
+		Name string `json:"name"`
+	}{}
+	if err := json.Unmarshal([]byte(connectionParametersSet), &parametersSet); err != nil {
+		return ""
+	}
+	return parametersSet.Name
+}
+
+// validateClientCredentialsParameterSet checks that an `oauthClientCredentials` parameters set holds the client id,
+// client secret and tenant of the service principal. Other parameters sets are validated by the service.
+func validateClientCredentialsParameterSet(connectionParametersSet string) error {
+	parametersSet := struct {
+		Name   string         `json:"name"`
+		Values map[string]any `json:"values"`
+	}{}
+	// Invalid json is reported when the connection is created.
+	if err := json.Unmarshal([]byte(connectionParametersSet), &parametersSet); err != nil || parametersSet.Name != clientCredentialsParameterSet {
+		return nil
+	}
+
+	present := map[string]bool{}
+	for key := range parametersSet.Values {
+		present[strings.ToLower(key)] = true
+	}
+	missing := []string{}
+	for _, key := range []string{"token:clientId", "token:clientSecret", "token:TenantId"} {
+		if !present[strings.ToLower(key)] {
+			missing = append(missing, fmt.Sprintf("`%s`", key))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the %s parameters set requires the values %s", clientCredentialsParameterSet, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -136,7 +136,7 @@ func (r *ShareResource) Configure(ctx context.Context, req resource.ConfigureReq
 		)
 		return
 	}
-	r.ConnectionsClient = newConnectionsClient(client.Api)
+	r.ConnectionsClient = NewConnectionsClient(client.Api)
 	r.EnvironmentClient = environment.NewEnvironmentClient(client.Api)
 }
 
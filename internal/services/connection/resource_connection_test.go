@@ -491,3 +491,160 @@ func TestUnitConnectionsResource_Validate_Read_ParentDeleted(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitConnectionsResource_Validate_Create_Client_Credentials(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterRegexpResponder("PUT", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_commondataserviceforapps/connections/(.*)?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusCreated, httpmock.File("tests/resource/connections/Validate_Create_Client_Credentials/put_connection.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_commondataserviceforapps/connections/00000000000000000000000000000001\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/connections/Validate_Create_Client_Credentials/put_connection.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("DELETE", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_commondataserviceforapps/connections/00000000000000000000000000000001\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "powerplatform_connection" "dataverse" {
+						environment_id = "00000000-0000-0000-0000-000000000000"
+						name           = "shared_commondataserviceforapps"
+						display_name   = "Dataverse Service Principal"
+						connection_parameters_set = jsonencode({
+							"name" : "oauthClientCredentials",
+							"values" : {
+								"token:clientId" : { "value" : "00000000-0000-0000-0000-000000000002" },
+								"token:clientSecret" : { "value" : "secret" },
+								"token:TenantId" : { "value" : "00000000-0000-0000-0000-000000000003" }
+							}
+						})
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection.dataverse", "id", "00000000000000000000000000000001"),
+					resource.TestCheckResourceAttr("powerplatform_connection.dataverse", "status.#", "1"),
+					resource.TestCheckResourceAttr("powerplatform_connection.dataverse", "status.0", "Connected"),
+					resource.TestCheckNoResourceAttr("powerplatform_connection.dataverse", "consent_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitConnectionsResource_Validate_Create_Client_Credentials_Status_Error(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterRegexpResponder("PUT", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_commondataserviceforapps/connections/(.*)?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusCreated, httpmock.File("tests/resource/connections/Validate_Create_Client_Credentials/put_connection_error.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("DELETE", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_commondataserviceforapps/connections/00000000000000000000000000000001\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "powerplatform_connection" "dataverse" {
+						environment_id = "00000000-0000-0000-0000-000000000000"
+						name           = "shared_commondataserviceforapps"
+						display_name   = "Dataverse Service Principal"
+						connection_parameters_set = jsonencode({
+							"name" : "oauthClientCredentials",
+							"values" : {
+								"token:clientId" : { "value" : "00000000-0000-0000-0000-000000000002" },
+								"token:clientSecret" : { "value" : "wrong" },
+								"token:TenantId" : { "value" : "00000000-0000-0000-0000-000000000003" }
+							}
+						})
+					}`,
+				ExpectError: regexp.MustCompile("failed to authenticate with client credentials: Unauthorized: AADSTS7000215"),
+			},
+		},
+	})
+}
+
+func TestUnitConnectionsResource_Validate_Create_Client_Credentials_Missing_Values(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "powerplatform_connection" "dataverse" {
+						environment_id = "00000000-0000-0000-0000-000000000000"
+						name           = "shared_commondataserviceforapps"
+						display_name   = "Dataverse Service Principal"
+						connection_parameters_set = jsonencode({
+							"name" : "oauthClientCredentials",
+							"values" : {
+								"token:clientId" : { "value" : "00000000-0000-0000-0000-000000000002" }
+							}
+						})
+					}`,
+				ExpectError: regexp.MustCompile("requires the values `token:clientSecret`, `token:TenantId`"),
+			},
+		},
+	})
+}
+
+func TestUnitConnectionsResource_Validate_Create_Unauthenticated(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterRegexpResponder("PUT", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_office365/connections/(.*)?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusCreated, httpmock.File("tests/resource/connections/Validate_Create_Unauthenticated/put_connection.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_office365/connections/00000000000000000000000000000004\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/connections/Validate_Create_Unauthenticated/put_connection.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("POST", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_office365/connections/00000000000000000000000000000004/listConsentLinks\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/connections/Validate_Create_Unauthenticated/post_list_consent_links.json").String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("DELETE", regexp.MustCompile(`^https://000000000000000000000000000000\.00\.environment\.api\.powerplatform\.com/connectivity/connectors/shared_office365/connections/00000000000000000000000000000004\?%24filter=environment\+eq\+%2700000000-0000-0000-0000-000000000000%27&api-version=1$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "powerplatform_connection" "outlook" {
+						environment_id = "00000000-0000-0000-0000-000000000000"
+						name           = "shared_office365"
+						display_name   = "Office 365 Outlook"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection.outlook", "status.#", "1"),
+					resource.TestCheckResourceAttr("powerplatform_connection.outlook", "status.0", "Error"),
+					resource.TestCheckResourceAttr("powerplatform_connection.outlook", "consent_url", "https://login.microsoftonline.com/common/oauth2/authorize?client_id=00000000-0000-0000-0000-000000000005&response_type=code&state=00000000000000000000000000000004"),
+				),
+			},
+		},
+	})
+}
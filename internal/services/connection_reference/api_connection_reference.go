@@ -0,0 +1,126 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connection_reference
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/solution"
+)
+
+const connectionReferenceSelect = "connectionreferenceid,connectionreferencelogicalname,connectionreferencedisplayname,description,connectorid,connectionid"
+
+func newConnectionReferenceClient(apiClient *api.Client) client {
+	return client{
+		Api:            apiClient,
+		SolutionClient: solution.NewSolutionClient(apiClient),
+	}
+}
+
+type client struct {
+	Api            *api.Client
+	SolutionClient solution.Client
+}
+
+func (client *client) DataverseExists(ctx context.Context, environmentId string) (bool, error) {
+	return client.SolutionClient.DataverseExists(ctx, environmentId)
+}
+
+func (client *client) GetConnectionReference(ctx context.Context, environmentId, logicalName string) (*connectionReferenceDto, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	values.Add("$select", connectionReferenceSelect)
+	values.Add("$filter", fmt.Sprintf("connectionreferencelogicalname eq '%s'", strings.ReplaceAll(logicalName, "'", "''")))
+	apiUrl := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/connectionreferences", constants.DATAVERSE_API_VERSION), values)
+
+	response := connectionReferenceArrayDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	if len(response.Value) == 0 {
+		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("connection reference '%s' not found", logicalName))
+	}
+	return &response.Value[0], nil
+}
+
+func (client *client) CreateConnectionReference(ctx context.Context, environmentId string, connectionReference connectionReferenceDto) (*connectionReferenceDto, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/connectionreferences", constants.DATAVERSE_API_VERSION), nil)
+	// Creating a record isn't idempotent, a replayed request would fail on the duplicate logical name.
+	resp, err := client.Api.ExecuteWithoutRetry(ctx, nil, "POST", apiUrl, nil, connectionReference, []int{http.StatusCreated, http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return client.GetConnectionReference(ctx, environmentId, connectionReference.LogicalName)
+}
+
+func (client *client) UpdateConnectionReference(ctx context.Context, environmentId, connectionReferenceId string, connectionReference connectionReferenceDto) (*connectionReferenceDto, error) {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/connectionreferences(%s)", constants.DATAVERSE_API_VERSION, connectionReferenceId), nil)
+	resp, err := client.Api.Execute(ctx, nil, "PATCH", apiUrl, nil, connectionReference, []int{http.StatusNoContent, http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return client.GetConnectionReference(ctx, environmentId, connectionReference.LogicalName)
+}
+
+func (client *client) DeleteConnectionReference(ctx context.Context, environmentId, connectionReferenceId string) error {
+	environmentHost, err := client.SolutionClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	apiUrl := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/connectionreferences(%s)", constants.DATAVERSE_API_VERSION, connectionReferenceId), nil)
+	resp, err := client.Api.Execute(ctx, nil, "DELETE", apiUrl, nil, nil, []int{http.StatusNoContent, http.StatusNotFound, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+func connectionReferenceResourceId(environmentId, logicalName string) string {
+	return fmt.Sprintf("%s/%s", environmentId, logicalName)
+}
+
+func parseConnectionReferenceResourceId(id string) (environmentId string, logicalName string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid import id %q, expected {environment_id}/{logical_name}", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connection_reference
+
+type connectionReferenceArrayDto struct {
+	Value []connectionReferenceDto `json:"value"`
+}
+
+type connectionReferenceDto struct {
+	ConnectionReferenceId string `json:"connectionreferenceid,omitempty"`
+	LogicalName           string `json:"connectionreferencelogicalname"`
+	DisplayName           string `json:"connectionreferencedisplayname"`
+	Description           string `json:"description"`
+	ConnectorId           string `json:"connectorid"`
+	ConnectionId          string `json:"connectionid"`
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connection_reference
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+type Resource struct {
+	helpers.TypeInfo
+	ConnectionReferenceClient client
+}
+
+type ResourceModel struct {
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+	Id                    types.String   `tfsdk:"id"`
+	EnvironmentId         types.String   `tfsdk:"environment_id"`
+	LogicalName           types.String   `tfsdk:"logical_name"`
+	DisplayName           types.String   `tfsdk:"display_name"`
+	Description           types.String   `tfsdk:"description"`
+	ConnectorId           types.String   `tfsdk:"connector_id"`
+	ConnectionId          types.String   `tfsdk:"connection_id"`
+	ConnectionReferenceId types.String   `tfsdk:"connection_reference_id"`
+}
+
+func convertFromConnectionReferenceDto(environmentId string, connectionReference *connectionReferenceDto, timeoutValue timeouts.Value) ResourceModel {
+	model := ResourceModel{
+		Timeouts:              timeoutValue,
+		Id:                    types.StringValue(connectionReferenceResourceId(environmentId, connectionReference.LogicalName)),
+		EnvironmentId:         types.StringValue(environmentId),
+		LogicalName:           types.StringValue(connectionReference.LogicalName),
+		DisplayName:           types.StringValue(connectionReference.DisplayName),
+		Description:           types.StringNull(),
+		ConnectorId:           types.StringValue(connectionReference.ConnectorId),
+		ConnectionId:          types.StringValue(connectionReference.ConnectionId),
+		ConnectionReferenceId: types.StringValue(connectionReference.ConnectionReferenceId),
+	}
+	if connectionReference.Description != "" {
+		model.Description = types.StringValue(connectionReference.Description)
+	}
+	return model
+}
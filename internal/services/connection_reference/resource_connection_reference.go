@@ -0,0 +1,303 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connection_reference
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+// createdByResourceKey is the private state key that records whether the connection reference was created by the
+// resource. Connection references that already existed, typically because a solution deployed them, are only rebound.
+const createdByResourceKey = "created_by_resource"
+
+var _ resource.Resource = &Resource{}
+var _ resource.ResourceWithImportState = &Resource{}
+
+func NewConnectionReferenceResource() resource.Resource {
+	return &Resource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "connection_reference",
+		},
+	}
+}
+
+func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a connection reference in a Dataverse environment and binds it to a connection. When a connection reference with the same logical name already exists, typically because it was deployed by a solution, it is rebound to the connection instead of being created, and deleting this resource leaves it in place. Changes to the binding made outside of Terraform, for example in the maker portal, are detected as drift.\n\nAdditional Resources:\n\n* [Use a connection reference in a solution](https://learn.microsoft.com/power-apps/maker/data-platform/create-connection-reference)\n",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Read:   true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Composite resource id in the format `{environment_id}/{logical_name}`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse environment of the connection reference.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the connection reference, e.g. `contoso_sharedsql_1a2b3`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 100),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the connection reference. Defaults to the logical name for new connection references.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the connection reference.",
+				Optional:            true,
+			},
+			"connector_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the connector of the connection reference, e.g. `/providers/Microsoft.PowerApps/apis/shared_sql`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"connection_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the connection the connection reference is bound to, e.g. the `id` of a `powerplatform_connection`.",
+				Required:            true,
+			},
+			"connection_reference_id": schema.StringAttribute{
+				MarkdownDescription: "Dataverse id of the connection reference record.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.ConnectionReferenceClient = newConnectionReferenceClient(client.Api)
+}
+
+func (r *Resource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environmentId := plan.EnvironmentId.ValueString()
+	dataverseExists, err := r.ConnectionReferenceClient.DataverseExists(ctx, environmentId)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create connection reference", err.Error())
+		return
+	}
+	if !dataverseExists {
+		resp.Diagnostics.AddError("Failed to create connection reference", fmt.Sprintf("environment %s does not have Dataverse linked", environmentId))
+		return
+	}
+
+	existing, err := r.ConnectionReferenceClient.GetConnectionReference(ctx, environmentId, plan.LogicalName.ValueString())
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError("Failed to create connection reference", err.Error())
+		return
+	}
+
+	connectionReference := buildConnectionReferenceDto(&plan)
+	created := existing == nil
+	if created {
+		if connectionReference.DisplayName == "" {
+			connectionReference.DisplayName = connectionReference.LogicalName
+		}
+		existing, err = r.ConnectionReferenceClient.CreateConnectionReference(ctx, environmentId, connectionReference)
+	} else {
+		// A connection reference can't move to another connector, it is only rebound to another connection.
+		if !strings.EqualFold(existing.ConnectorId, connectionReference.ConnectorId) {
+			resp.Diagnostics.AddAttributeError(path.Root("connector_id"), "Failed to create connection reference",
+				fmt.Sprintf("connection reference '%s' already exists for connector '%s'", existing.LogicalName, existing.ConnectorId))
+			return
+		}
+		if connectionReference.DisplayName == "" {
+			connectionReference.DisplayName = existing.DisplayName
+		}
+		existing, err = r.ConnectionReferenceClient.UpdateConnectionReference(ctx, environmentId, existing.ConnectionReferenceId, connectionReference)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create connection reference", err.Error())
+		return
+	}
+
+	state := convertFromConnectionReferenceDto(environmentId, existing, plan.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, createdByResourceKey, fmt.Appendf(nil, "%t", created))...)
+}
+
+func (r *Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionReference, err := r.ConnectionReferenceClient.GetConnectionReference(ctx, state.EnvironmentId.ValueString(), state.LogicalName.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to read connection reference", err.Error())
+		return
+	}
+
+	// Use the connection the API returned, so that rebinding the connection reference outside of Terraform is detected as drift.
+	newState := convertFromConnectionReferenceDto(state.EnvironmentId.ValueString(), connectionReference, state.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan, state ResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectionReference := buildConnectionReferenceDto(&plan)
+	if connectionReference.DisplayName == "" {
+		connectionReference.DisplayName = state.DisplayName.ValueString()
+	}
+
+	updated, err := r.ConnectionReferenceClient.UpdateConnectionReference(ctx, plan.EnvironmentId.ValueString(), state.ConnectionReferenceId.ValueString(), connectionReference)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update connection reference", err.Error())
+		return
+	}
+
+	newState := convertFromConnectionReferenceDto(plan.EnvironmentId.ValueString(), updated, plan.Timeouts)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+func (r *Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state ResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdByResource, diags := req.Private.GetKey(ctx, createdByResourceKey)
+	resp.Diagnostics.Append(diags...)
+	if string(createdByResource) != "true" {
+		tflog.Debug(ctx, fmt.Sprintf("Connection reference '%s' was not created by %s, leaving it in place", state.LogicalName.ValueString(), r.FullTypeName()))
+		return
+	}
+
+	err := r.ConnectionReferenceClient.DeleteConnectionReference(ctx, state.EnvironmentId.ValueString(), state.ConnectionReferenceId.ValueString())
+	if err != nil {
+		// A parent environment deleted out of band means the connection reference is already gone.
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Failed to delete connection reference", err.Error())
+		return
+	}
+}
+
+func (r *Resource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, logicalName, err := parseConnectionReferenceResourceId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), connectionReferenceResourceId(environmentId, logicalName))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("logical_name"), logicalName)...)
+}
+
+func buildConnectionReferenceDto(plan *ResourceModel) connectionReferenceDto {
+	connectionReference := connectionReferenceDto{
+		LogicalName:  plan.LogicalName.ValueString(),
+		Description:  plan.Description.ValueString(),
+		ConnectorId:  plan.ConnectorId.ValueString(),
+		ConnectionId: plan.ConnectionId.ValueString(),
+	}
+	if !plan.DisplayName.IsUnknown() {
+		connectionReference.DisplayName = plan.DisplayName.ValueString()
+	}
+	return connectionReference
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connection_reference_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+const connectionReferencesUrl = "https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/connectionreferences"
+
+func TestAccConnectionReferenceResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+						language_code     = "1033"
+						currency_code     = "USD"
+						security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				resource "time_sleep" "wait_for_dataverse" {
+					create_duration = "120s"
+
+					depends_on = [powerplatform_environment.env]
+				}
+
+				resource "powerplatform_connection" "azure_openai_connection" {
+					environment_id = powerplatform_environment.env.id
+					name           = "shared_azureopenai"
+					display_name   = "OpenAI Connection ` + mocks.TestName() + `"
+
+					depends_on = [time_sleep.wait_for_dataverse]
+				}
+
+				resource "powerplatform_connection_reference" "azure_openai" {
+					environment_id = powerplatform_environment.env.id
+					logical_name   = "cr_azureopenai_` + mocks.TestName() + `"
+					connector_id   = "/providers/Microsoft.PowerApps/apis/shared_azureopenai"
+					connection_id  = powerplatform_connection.azure_openai_connection.id
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("powerplatform_connection_reference.azure_openai", "connection_id", "powerplatform_connection.azure_openai_connection", "id"),
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.azure_openai", "display_name", "cr_azureopenai_"+mocks.TestName()),
+					resource.TestCheckResourceAttrSet("powerplatform_connection_reference.azure_openai", "connection_reference_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitConnectionReferenceResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerEnvironmentResponder()
+
+	// The mocked API keeps the connection reference it was sent, so that the binding read back follows the applied changes.
+	var connectionReference map[string]any
+	deleted := false
+	httpmock.RegisterResponder("GET", connectionReferencesUrl+"?%24filter=connectionreferencelogicalname+eq+%27contoso_sharedsql_1a2b3%27&%24select=connectionreferenceid%2Cconnectionreferencelogicalname%2Cconnectionreferencedisplayname%2Cdescription%2Cconnectorid%2Cconnectionid",
+		func(req *http.Request) (*http.Response, error) {
+			if connectionReference == nil {
+				return httpmock.NewStringResponse(http.StatusOK, `{"value":[]}`), nil
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, map[string]any{"value": []any{connectionReference}})
+		})
+
+	httpmock.RegisterResponder("POST", connectionReferencesUrl,
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			connectionReference = map[string]any{}
+			if err := json.Unmarshal(body, &connectionReference); err != nil {
+				return nil, err
+			}
+			connectionReference["connectionreferenceid"] = "11111111-1111-1111-1111-111111111111"
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("Odata-Entityid", connectionReferencesUrl+"(11111111-1111-1111-1111-111111111111)")
+			return resp, nil
+		})
+
+	httpmock.RegisterResponder("PATCH", connectionReferencesUrl+"%2811111111-1111-1111-1111-111111111111%29",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &connectionReference); err != nil {
+				return nil, err
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("DELETE", connectionReferencesUrl+"%2811111111-1111-1111-1111-111111111111%29",
+		func(req *http.Request) (*http.Response, error) {
+			deleted = true
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	config := func(connectionId string) string {
+		return `
+		resource "powerplatform_connection_reference" "sql" {
+			environment_id = "00000000-0000-0000-0000-000000000001"
+			logical_name   = "contoso_sharedsql_1a2b3"
+			connector_id   = "/providers/Microsoft.PowerApps/apis/shared_sql"
+			connection_id  = "` + connectionId + `"
+		}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if !deleted {
+				return errors.New("connection reference created by the resource was not deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("00000000000000000000000000000001"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "id", "00000000-0000-0000-0000-000000000001/contoso_sharedsql_1a2b3"),
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "display_name", "contoso_sharedsql_1a2b3"),
+					resource.TestCheckNoResourceAttr("powerplatform_connection_reference.sql", "description"),
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "connection_id", "00000000000000000000000000000001"),
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "connection_reference_id", "11111111-1111-1111-1111-111111111111"),
+				),
+			},
+			{
+				Config: config("00000000000000000000000000000002"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "connection_id", "00000000000000000000000000000002"),
+				),
+			},
+			{
+				// Rebinding the connection reference in the maker portal is detected as drift.
+				PreConfig: func() {
+					connectionReference["connectionid"] = "00000000000000000000000000000003"
+				},
+				Config:             config("00000000000000000000000000000002"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("00000000000000000000000000000002"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "connection_id", "00000000000000000000000000000002"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_connection_reference.sql",
+				ImportState:       true,
+				ImportStateId:     "00000000-0000-0000-0000-000000000001/contoso_sharedsql_1a2b3",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitConnectionReferenceResource_Validate_Rebind_Existing(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerEnvironmentResponder()
+
+	connectionId := "00000000000000000000000000000001"
+	httpmock.RegisterResponder("GET", connectionReferencesUrl+"?%24filter=connectionreferencelogicalname+eq+%27contoso_sharedsql_1a2b3%27&%24select=connectionreferenceid%2Cconnectionreferencelogicalname%2Cconnectionreferencedisplayname%2Cdescription%2Cconnectorid%2Cconnectionid",
+		func(req *http.Request) (*http.Response, error) {
+			response := map[string]any{}
+			if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_CRUD/get_connection_references.json").String()), &response); err != nil {
+				return nil, err
+			}
+			response["value"].([]any)[0].(map[string]any)["connectionid"] = connectionId
+			return httpmock.NewJsonResponse(http.StatusOK, response)
+		})
+
+	httpmock.RegisterResponder("PATCH", connectionReferencesUrl+"%2811111111-1111-1111-1111-111111111111%29",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			raw, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return nil, err
+			}
+			if body["connectionreferencedisplayname"] != "SQL Server" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected display name"), nil
+			}
+			connectionId = body["connectionid"].(string)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	// The connection reference was deployed by a solution, so destroying the resource must not delete it.
+	httpmock.RegisterResponder("DELETE", connectionReferencesUrl+"%2811111111-1111-1111-1111-111111111111%29",
+		httpmock.NewStringResponder(http.StatusBadRequest, "connection reference deployed by a solution must not be deleted"))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_connection_reference" "sql" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					logical_name   = "contoso_sharedsql_1a2b3"
+					connector_id   = "/providers/Microsoft.PowerApps/apis/shared_sql"
+					connection_id  = "00000000000000000000000000000002"
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "display_name", "SQL Server"),
+					resource.TestCheckResourceAttr("powerplatform_connection_reference.sql", "connection_id", "00000000000000000000000000000002"),
+				),
+			},
+		},
+	})
+}
+
+func registerEnvironmentResponder() {
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Validate_CRUD/get_environment.json").String()))
+}
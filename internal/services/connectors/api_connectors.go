@@ -5,26 +5,30 @@ package connectors
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
 	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
 )
 
-func newConnectorsClient(apiClient *api.Client) client {
-	return client{
+func NewConnectorsClient(apiClient *api.Client) Client {
+	return Client{
 		Api: apiClient,
 	}
 }
 
-type client struct {
+type Client struct {
 	Api *api.Client
 }
 
-func (client *client) GetConnectors(ctx context.Context, environmentId string) ([]connectorDto, error) {
+func (client *Client) GetConnectors(ctx context.Context, environmentId string) ([]ConnectorDto, error) {
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
@@ -43,8 +47,7 @@ func (client *client) GetConnectors(ctx context.Context, environmentId string) (
 
 	apiUrl.RawQuery = values.Encode()
 
-	connectorArray := connectorArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &connectorArray)
+	connectors, _, err := api.ExecuteForAllPages[ConnectorDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch PowerApps connectors: %w", err)
 	}
@@ -60,10 +63,10 @@ func (client *client) GetConnectors(ctx context.Context, environmentId string) (
 		return nil, fmt.Errorf("failed to fetch unblockable connectors metadata: %w", err)
 	}
 
-	for inx, connector := range connectorArray.Value {
+	for inx, connector := range connectors {
 		for _, unblockableConnector := range unblockableConnectorArray {
 			if connector.Id == unblockableConnector.Id {
-				connectorArray.Value[inx].Properties.Unblockable = unblockableConnector.Metadata.Unblockable
+				connectors[inx].Properties.Unblockable = unblockableConnector.Metadata.Unblockable
 			}
 		}
 	}
@@ -79,7 +82,7 @@ func (client *client) GetConnectors(ctx context.Context, environmentId string) (
 		return nil, fmt.Errorf("failed to fetch virtual connectors metadata: %w", err)
 	}
 	for _, virutualConnector := range virtualConnectorArray {
-		connectorArray.Value = append(connectorArray.Value, connectorDto{
+		connectors = append(connectors, ConnectorDto{
 			Id:   virutualConnector.Id,
 			Name: virutualConnector.Metadata.Name,
 			Type: virutualConnector.Metadata.Type,
@@ -93,10 +96,132 @@ func (client *client) GetConnectors(ctx context.Context, environmentId string) (
 		})
 	}
 
-	for inx, connector := range connectorArray.Value {
+	for inx, connector := range connectors {
 		nameSplit := strings.Split(connector.Id, "/")
-		connectorArray.Value[inx].Name = nameSplit[len(nameSplit)-1]
+		connectors[inx].Name = nameSplit[len(nameSplit)-1]
 	}
 
-	return connectorArray.Value, nil
+	return connectors, nil
+}
+
+func (client *Client) GetConnector(ctx context.Context, environmentId, connectorName string) (*ConnectorDto, error) {
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
+		Path:   fmt.Sprintf("/providers/Microsoft.PowerApps/apis/%s", connectorName),
+	}
+	values := url.Values{}
+	values.Add(constants.API_VERSION_PARAM, constants.CONNECTORS_API_VERSION)
+
+	if environmentId == "" {
+		environmentId = "~Default"
+	}
+	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
+
+	apiUrl.RawQuery = values.Encode()
+
+	connector := ConnectorDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &connector)
+	if err != nil {
+		if resp != nil && resp.HttpResponse.StatusCode == http.StatusNotFound {
+			return nil, customerrors.WrapIntoProviderError(err, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("Connector '%s' not found", connectorName))
+		}
+		return nil, fmt.Errorf("failed to fetch PowerApps connector '%s': %w", connectorName, err)
+	}
+	return &connector, nil
+}
+
+// getConnectorOperations returns the operations of the OpenAPI definition of a connector, sorted by operation id.
+func getConnectorOperations(connector ConnectorDto) ([]connectorOperationDto, error) {
+	operations := []connectorOperationDto{}
+	if connector.Properties.Swagger == nil {
+		return operations, nil
+	}
+	for _, pathItem := range connector.Properties.Swagger.Paths {
+		for method, rawOperation := range pathItem {
+			switch strings.ToLower(method) {
+			case "get", "put", "post", "delete", "options", "head", "patch":
+			default:
+				// Path items also hold parameters and vendor extensions that aren't operations.
+				continue
+			}
+			operation := connectorOperationDto{}
+			if err := json.Unmarshal(rawOperation, &operation); err != nil {
+				return nil, fmt.Errorf("failed to parse operation '%s' of connector '%s': %w", method, connector.Name, err)
+			}
+			if operation.OperationId != "" {
+				operations = append(operations, operation)
+			}
+		}
+	}
+	sort.Slice(operations, func(i, j int) bool {
+		return operations[i].OperationId < operations[j].OperationId
+	})
+	return operations, nil
+}
+
+// supportsEndpointFiltering reports whether endpoint rules can be set for a connector in a data loss prevention policy.
+// See https://learn.microsoft.com/power-platform/admin/connector-endpoint-filtering for the supported connectors.
+func supportsEndpointFiltering(connectorName string) bool {
+	return slices.Contains([]string{
+		"http",
+		"httpwebhook",
+		"shared_webcontents",
+		"shared_sql",
+		"shared_azureblob",
+		"shared_smtp",
+		"shared_commondataserviceforapps",
+	}, strings.ToLower(connectorName))
+}
+
+func (client *Client) buildCustomConnectorUrl(environmentId, connectorName string) string {
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
+		Path:   "/providers/Microsoft.PowerApps/apis",
+	}
+	if connectorName != "" {
+		apiUrl.Path = fmt.Sprintf("/providers/Microsoft.PowerApps/apis/%s", connectorName)
+	}
+	values := url.Values{}
+	values.Add(constants.API_VERSION_PARAM, constants.CONNECTORS_API_VERSION)
+	values.Add("$filter", fmt.Sprintf("environment eq '%s'", environmentId))
+	apiUrl.RawQuery = values.Encode()
+	return apiUrl.String()
+}
+
+func (client *Client) CreateCustomConnector(ctx context.Context, environmentId string, connector CustomConnectorDto) (*CustomConnectorDto, error) {
+	created := CustomConnectorDto{}
+	// Creating a connector isn't idempotent, a replayed request would publish a second connector.
+	_, err := client.Api.ExecuteWithoutRetry(ctx, nil, "POST", client.buildCustomConnectorUrl(environmentId, ""), nil, connector, []int{http.StatusOK, http.StatusCreated}, &created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create custom connector: %w", err)
+	}
+	return &created, nil
+}
+
+func (client *Client) GetCustomConnector(ctx context.Context, environmentId, connectorName string) (*CustomConnectorDto, error) {
+	connector := CustomConnectorDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", client.buildCustomConnectorUrl(environmentId, connectorName), nil, nil, []int{http.StatusOK}, &connector)
+	if err != nil {
+		if resp != nil && resp.HttpResponse.StatusCode == http.StatusNotFound {
+			return nil, customerrors.WrapIntoProviderError(err, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("Custom connector '%s' not found", connectorName))
+		}
+		return nil, err
+	}
+	return &connector, nil
+}
+
+func (client *Client) UpdateCustomConnector(ctx context.Context, environmentId, connectorName string, connector CustomConnectorDto) (*CustomConnectorDto, error) {
+	updated := CustomConnectorDto{}
+	_, err := client.Api.Execute(ctx, nil, "PUT", client.buildCustomConnectorUrl(environmentId, connectorName), nil, connector, []int{http.StatusOK}, &updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update custom connector '%s': %w", connectorName, err)
+	}
+	return &updated, nil
+}
+
+func (client *Client) DeleteCustomConnector(ctx context.Context, environmentId, connectorName string) error {
+	_, err := client.Api.Execute(ctx, nil, "DELETE", client.buildCustomConnectorUrl(environmentId, connectorName), nil, nil, []int{http.StatusOK, http.StatusNoContent, http.StatusNotFound}, nil)
+	return err
 }
@@ -0,0 +1,306 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connectors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	securityTypeApiKey = "ApiKey"
+	securityTypeBasic  = "Basic"
+	securityTypeOAuth2 = "OAuth2"
+
+	identityProviderOAuth2 = "oauth2"
+	identityProviderAad    = "aad"
+
+	customConnectorRedirectUrl = "https://global.consent.azure-apim.net/redirect"
+	defaultIconBrandColor      = "#007ee5"
+)
+
+// customConnectorFiles holds the content of the files a custom connector is published from.
+type customConnectorFiles struct {
+	Definition map[string]any
+	Icon       []byte
+	CustomCode []byte
+	// Hash is the SHA-256 hash over the OpenAPI definition, the icon and the custom code.
+	Hash string
+}
+
+// customConnectorFileError is returned when one of the files of a custom connector can't be read.
+type customConnectorFileError struct {
+	// Attribute is the name of the attribute that refers to the file.
+	Attribute string
+	Err       error
+}
+
+func (e *customConnectorFileError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *customConnectorFileError) Unwrap() error {
+	return e.Err
+}
+
+// readCustomConnectorFiles reads the OpenAPI definition, the icon and the custom code of a custom connector.
+func readCustomConnectorFiles(model *CustomConnectorResourceModel) (*customConnectorFiles, error) {
+	files := customConnectorFiles{}
+	hash := sha256.New()
+
+	content, err := os.ReadFile(model.OpenApiFile.ValueString())
+	if err != nil {
+		return nil, &customConnectorFileError{Attribute: "openapi_file", Err: err}
+	}
+	files.Definition, err = parseOpenApiDefinition(content)
+	if err != nil {
+		return nil, &customConnectorFileError{Attribute: "openapi_file", Err: fmt.Errorf("invalid OpenAPI definition '%s': %w", model.OpenApiFile.ValueString(), err)}
+	}
+	partHash := sha256.Sum256(content)
+	hash.Write(partHash[:])
+
+	for _, part := range []struct {
+		attribute string
+		file      string
+		content   *[]byte
+	}{
+		{"icon_file", model.IconFile.ValueString(), &files.Icon},
+		{"custom_code_file", model.CustomCodeFile.ValueString(), &files.CustomCode},
+	} {
+		if part.file != "" {
+			*part.content, err = os.ReadFile(part.file)
+			if err != nil {
+				return nil, &customConnectorFileError{Attribute: part.attribute, Err: err}
+			}
+		}
+		partHash := sha256.Sum256(*part.content)
+		hash.Write(partHash[:])
+	}
+
+	files.Hash = hex.EncodeToString(hash.Sum(nil))
+	return &files, nil
+}
+
+// parseOpenApiDefinition parses a Swagger 2.0 definition in JSON format.
+func parseOpenApiDefinition(content []byte) (map[string]any, error) {
+	definition := map[string]any{}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if definition["swagger"] != "2.0" {
+		return nil, errors.New("only Swagger 2.0 definitions are supported, `swagger` must be \"2.0\"")
+	}
+	if host, ok := definition["host"].(string); !ok || host == "" {
+		return nil, errors.New("`host` is required")
+	}
+	return definition, nil
+}
+
+// hashOpenApiDefinition returns the SHA-256 hash of a definition as returned by the service, with its keys sorted.
+func hashOpenApiDefinition(definition map[string]any) (string, error) {
+	content, err := json.Marshal(definition)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// openApiOperationIds returns the sorted ids of the operations of a definition.
+func openApiOperationIds(definition map[string]any) []string {
+	operationIds := []string{}
+	paths, _ := definition["paths"].(map[string]any)
+	for _, pathItem := range paths {
+		operations, _ := pathItem.(map[string]any)
+		for _, operation := range operations {
+			if operation, ok := operation.(map[string]any); ok {
+				if operationId, ok := operation["operationId"].(string); ok && operationId != "" {
+					operationIds = append(operationIds, operationId)
+				}
+			}
+		}
+	}
+	sort.Strings(operationIds)
+	return operationIds
+}
+
+// backendServiceUrl returns the url of the API behind a connector from the scheme, host and base path of its definition.
+func backendServiceUrl(definition map[string]any) string {
+	scheme := "https"
+	if schemes, ok := definition["schemes"].([]any); ok && len(schemes) > 0 {
+		if value, ok := schemes[0].(string); ok {
+			scheme = value
+		}
+	}
+	basePath, _ := definition["basePath"].(string)
+	return fmt.Sprintf("%s://%s%s", scheme, definition["host"], strings.TrimSuffix(basePath, "/"))
+}
+
+// buildSecurity returns the connection parameters of a connector and the matching security definitions of its OpenAPI
+// definition. Without security, the connector has no connection parameters and keeps the security definitions of its file.
+func buildSecurity(ctx context.Context, security *CustomConnectorSecurityModel) (map[string]any, map[string]any, error) {
+	if security == nil {
+		return map[string]any{}, nil, nil
+	}
+
+	secureString := func(displayName, description string, tabIndex int) map[string]any {
+		return map[string]any{
+			"type": "securestring",
+			"uiDefinition": map[string]any{
+				"displayName": displayName,
+				"description": description,
+				"tooltip":     description,
+				"constraints": map[string]any{
+					"tabIndex":  tabIndex,
+					"clearText": false,
+					"required":  "true",
+				},
+			},
+		}
+	}
+
+	switch security.Type.ValueString() {
+	case securityTypeApiKey:
+		location := security.ApiKeyLocation.ValueString()
+		if location == "" {
+			location = "header"
+		}
+		return map[string]any{
+			"api_key": secureString("API Key", "The API Key for this API", 2),
+		}, map[string]any{
+			"API Key": map[string]any{
+				"type": "apiKey",
+				"in":   location,
+				"name": security.ApiKeyName.ValueString(),
+			},
+		}, nil
+	case securityTypeBasic:
+		return map[string]any{
+			"username": secureString("Username", "The username for this API", 2),
+			"password": secureString("Password", "The password for this API", 3),
+		}, map[string]any{
+			"basic_auth": map[string]any{
+				"type": "basic",
+			},
+		}, nil
+	case securityTypeOAuth2:
+		scopes := []string{}
+		if !security.Scopes.IsNull() {
+			if diags := security.Scopes.ElementsAs(ctx, &scopes, false); diags.HasError() {
+				return nil, nil, fmt.Errorf("failed to convert scopes: %v", diags)
+			}
+		}
+
+		identityProvider := security.IdentityProvider.ValueString()
+		if identityProvider == "" {
+			identityProvider = identityProviderOAuth2
+		}
+		authorizationUrl, tokenUrl := security.AuthorizationUrl.ValueString(), security.TokenUrl.ValueString()
+		customParameters := map[string]any{
+			"authorizationUrl": map[string]any{"value": authorizationUrl},
+			"tokenUrl":         map[string]any{"value": tokenUrl},
+			"refreshUrl":       map[string]any{"value": security.RefreshUrl.ValueString()},
+		}
+		if identityProvider == identityProviderAad {
+			authorizationUrl, tokenUrl = "https://login.microsoftonline.com/common/oauth2/authorize", "https://login.microsoftonline.com/common/oauth2/token"
+			customParameters = map[string]any{
+				"loginUri":              map[string]any{"value": "https://login.microsoftonline.com"},
+				"tenantId":              map[string]any{"value": "common"},
+				"resourceUri":           map[string]any{"value": security.ResourceUrl.ValueString()},
+				"enableOnbehalfOfLogin": map[string]any{"value": "false"},
+			}
+		}
+
+		return map[string]any{
+			"token": map[string]any{
+				"type": "oauthSetting",
+				"oAuthSettings": map[string]any{
+					"identityProvider": identityProvider,
+					"clientId":         security.ClientId.ValueString(),
+					"clientSecret":     security.ClientSecret.ValueString(),
+					"scopes":           scopes,
+					"redirectMode":     "GlobalPerConnector",
+					"redirectUrl":      customConnectorRedirectUrl,
+					"properties": map[string]any{
+						"IsFirstParty": "False",
+					},
+					"customParameters": customParameters,
+				},
+			},
+		}, map[string]any{
+			"oauth2_auth": map[string]any{
+				"type":             "oauth2",
+				"flow":             "accessCode",
+				"authorizationUrl": authorizationUrl,
+				"tokenUrl":         tokenUrl,
+				"scopes":           map[string]any{},
+			},
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported security type '%s'", security.Type.ValueString())
+	}
+}
+
+// buildCustomConnectorDto builds the custom connector that is published from a resource model and its files.
+func buildCustomConnectorDto(ctx context.Context, model *CustomConnectorResourceModel, files *customConnectorFiles) (*CustomConnectorDto, error) {
+	connectionParameters, securityDefinitions, err := buildSecurity(ctx, model.Security)
+	if err != nil {
+		return nil, err
+	}
+	if securityDefinitions != nil {
+		files.Definition["securityDefinitions"] = securityDefinitions
+	}
+
+	connector := CustomConnectorDto{
+		Properties: customConnectorPropertiesDto{
+			DisplayName:             model.DisplayName.ValueString(),
+			Description:             model.Description.ValueString(),
+			IconBrandColor:          model.IconBrandColor.ValueString(),
+			Capabilities:            []string{},
+			Environment:             customConnectorEnvironmentDto{Name: model.EnvironmentId.ValueString()},
+			BackendService:          customConnectorBackendDto{ServiceUrl: backendServiceUrl(files.Definition)},
+			ConnectionParameters:    connectionParameters,
+			PolicyTemplateInstances: []policyTemplateInstanceDto{},
+			Swagger:                 files.Definition,
+		},
+	}
+	if len(files.Icon) > 0 {
+		connector.Properties.IconUri = fmt.Sprintf("data:%s;base64,%s", http.DetectContentType(files.Icon), base64.StdEncoding.EncodeToString(files.Icon))
+	}
+
+	for _, policyTemplate := range model.PolicyTemplates {
+		parameters := map[string]string{}
+		if diags := policyTemplate.Parameters.ElementsAs(ctx, &parameters, false); diags.HasError() {
+			return nil, fmt.Errorf("failed to convert parameters of policy template '%s': %v", policyTemplate.TemplateId.ValueString(), diags)
+		}
+		connector.Properties.PolicyTemplateInstances = append(connector.Properties.PolicyTemplateInstances, policyTemplateInstanceDto{
+			TemplateId: policyTemplate.TemplateId.ValueString(),
+			Title:      policyTemplate.Title.ValueString(),
+			Parameters: parameters,
+		})
+	}
+
+	if len(files.CustomCode) > 0 {
+		connector.Properties.Script = "data:text/plain;base64," + base64.StdEncoding.EncodeToString(files.CustomCode)
+		connector.Properties.ScriptOperations = openApiOperationIds(files.Definition)
+		if !model.CustomCodeOperations.IsNull() {
+			connector.Properties.ScriptOperations = []string{}
+			if diags := model.CustomCodeOperations.ElementsAs(ctx, &connector.Properties.ScriptOperations, false); diags.HasError() {
+				return nil, fmt.Errorf("failed to convert custom code operations: %v", diags)
+			}
+			sort.Strings(connector.Properties.ScriptOperations)
+		}
+	}
+
+	return &connector, nil
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &ActionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ActionsDataSource{}
+)
+
+func NewConnectorActionsDataSource() datasource.DataSource {
+	return &ActionsDataSource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "connector_actions",
+		},
+	}
+}
+
+func (d *ActionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	d.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = d.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (d *ActionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the actions of connectors together with whether the connectors support endpoint filtering. The action ids can be used in the `action_rules` of a Data Loss Prevention Policy, and the connectors that support endpoint filtering in its `endpoint_rules`.\n\nAdditional Resources:\n\n* [Connector action control](https://learn.microsoft.com/power-platform/admin/connector-action-control)\n* [Connector endpoint filtering](https://learn.microsoft.com/power-platform/admin/connector-endpoint-filtering)\n",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Read: true,
+			}),
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the environment to read the connectors from. If not specified, defaults to 'Default' environment which returns tenant-level connectors.",
+				Optional:            true,
+			},
+			"connector_ids": schema.SetAttribute{
+				MarkdownDescription: "Ids of the connectors, e.g. `/providers/Microsoft.PowerApps/apis/shared_sql`, as returned by the `powerplatform_connectors` data source",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"connectors": schema.ListNestedAttribute{
+				MarkdownDescription: "List of Connectors with their actions",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name",
+							Computed:            true,
+						},
+						"supports_endpoint_filtering": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if endpoint rules can be set for the connector in a Data Loss Prevention policy",
+							Computed:            true,
+						},
+						"actions": schema.ListNestedAttribute{
+							MarkdownDescription: "Actions and triggers of the connector, sorted by id",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"id": schema.StringAttribute{
+										MarkdownDescription: "Id of the action, to be used as `action_id` in the action rules of a Data Loss Prevention policy",
+										Computed:            true,
+									},
+									"summary": schema.StringAttribute{
+										MarkdownDescription: "Summary",
+										Computed:            true,
+									},
+									"description": schema.StringAttribute{
+										MarkdownDescription: "Description",
+										Computed:            true,
+									},
+									"deprecated": schema.BoolAttribute{
+										MarkdownDescription: "Indicates if the action is deprecated",
+										Computed:            true,
+									},
+									"trigger": schema.BoolAttribute{
+										MarkdownDescription: "Indicates if the action is a trigger",
+										Computed:            true,
+									},
+									"visibility": schema.StringAttribute{
+										MarkdownDescription: "Visibility of the action in the designer (\"important\", \"advanced\", \"internal\" or empty)",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ActionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.ConnectorsClient = NewConnectorsClient(client.Api)
+}
+
+func (d *ActionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	var state ActionsListDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectorIds := []string{}
+	resp.Diagnostics.Append(state.ConnectorIds.ElementsAs(ctx, &connectorIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sort.Strings(connectorIds)
+
+	state.Connectors = []ActionsConnectorDataSourceModel{}
+	for _, connectorId := range connectorIds {
+		nameSplit := strings.Split(connectorId, "/")
+		connectorName := nameSplit[len(nameSplit)-1]
+
+		connectorModel := ActionsConnectorDataSourceModel{
+			Id:                        types.StringValue(connectorId),
+			Name:                      types.StringValue(connectorName),
+			DisplayName:               types.StringValue(connectorName),
+			SupportsEndpointFiltering: types.BoolValue(supportsEndpointFiltering(connectorName)),
+			Actions:                   []ActionDataSourceModel{},
+		}
+
+		// Virtual connectors such as HTTP have no API definition and are addressed by their name only.
+		if strings.HasPrefix(connectorId, "/providers/") {
+			connector, err := d.ConnectorsClient.GetConnector(ctx, state.EnvironmentId.ValueString(), connectorName)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading connector '%s' in %s", connectorId, d.FullTypeName()), err.Error())
+				return
+			}
+			operations, err := getConnectorOperations(*connector)
+			if err != nil {
+				resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading connector '%s' in %s", connectorId, d.FullTypeName()), err.Error())
+				return
+			}
+			connectorModel.DisplayName = types.StringValue(connector.Properties.DisplayName)
+			for _, operation := range operations {
+				connectorModel.Actions = append(connectorModel.Actions, convertFromConnectorOperationDto(operation))
+			}
+		}
+
+		state.Connectors = append(state.Connectors, connectorModel)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
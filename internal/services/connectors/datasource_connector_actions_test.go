@@ -0,0 +1,114 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connectors_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestAccConnectorActionsDataSource_Validate_Read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_connector_actions" "sql" {
+					connector_ids = ["/providers/Microsoft.PowerApps/apis/shared_sql"]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.sql", "connectors.#", "1"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.sql", "connectors.0.name", "shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.sql", "connectors.0.supports_endpoint_filtering", "true"),
+					resource.TestMatchResourceAttr("data.powerplatform_connector_actions.sql", "connectors.0.display_name", regexp.MustCompile(helpers.StringRegex)),
+					resource.TestMatchResourceAttr("data.powerplatform_connector_actions.sql", "connectors.0.actions.0.id", regexp.MustCompile(helpers.StringRegex)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitConnectorActionsDataSource_Validate_Read(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.powerapps.com/providers/Microsoft.PowerApps/apis/shared_sql?%24filter=environment+eq+%27~Default%27&api-version=2019-05-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/Actions_Validate_Read/get_api_shared_sql.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_connector_actions" "actions" {
+					connector_ids = ["/providers/Microsoft.PowerApps/apis/shared_sql", "Http"]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.#", "2"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.id", "/providers/Microsoft.PowerApps/apis/shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.name", "shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.display_name", "SQL Server"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.supports_endpoint_filtering", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.#", "4"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.0.id", "DeleteItem"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.0.summary", "Delete row [DEPRECATED]"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.0.deprecated", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.0.trigger", "false"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.0.visibility", "advanced"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.1.id", "GetItems_V2"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.1.summary", "Get rows (V2)"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.1.description", "This operation gets rows from a table."),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.1.deprecated", "false"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.2.id", "GetOnNewItems_V2"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.2.trigger", "true"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.3.id", "PostItem_V2"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.0.actions.3.visibility", ""),
+
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.1.id", "Http"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.1.supports_endpoint_filtering", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_connector_actions.actions", "connectors.1.actions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitConnectorActionsDataSource_Validate_Not_Found(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.powerapps.com/providers/Microsoft.PowerApps/apis/shared_unknown?%24filter=environment+eq+%27~Default%27&api-version=2019-05-01`,
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"code":"ApiNotFound","message":"The API 'shared_unknown' could not be found."}}`))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_connector_actions" "actions" {
+					connector_ids = ["/providers/Microsoft.PowerApps/apis/shared_unknown"]
+				}`,
+
+				ExpectError: regexp.MustCompile("Connector 'shared_unknown' not found"),
+			},
+		},
+	})
+}
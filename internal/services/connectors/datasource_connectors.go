@@ -114,7 +114,7 @@ func (d *DataSource) Configure(ctx context.Context, req datasource.ConfigureRequ
 		return
 	}
 
-	d.ConnectorsClient = newConnectorsClient(client.Api)
+	d.ConnectorsClient = NewConnectorsClient(client.Api)
 }
 
 func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -3,7 +3,9 @@
 
 package connectors
 
-type connectorDto struct {
+import "encoding/json"
+
+type ConnectorDto struct {
 	Name       string                 `json:"name"`
 	Id         string                 `json:"id"`
 	Type       string                 `json:"type"`
@@ -11,17 +13,15 @@ type connectorDto struct {
 }
 
 type connectorPropertiesDto struct {
-	DisplayName string `json:"displayName"`
-	Description string `json:"description"`
-	Tier        string `json:"tier"`
-	Publisher   string `json:"publisher"`
+	DisplayName string               `json:"displayName"`
+	Description string               `json:"description"`
+	Tier        string               `json:"tier"`
+	Publisher   string               `json:"publisher"`
+	CreatedTime string               `json:"createdTime"`
+	Swagger     *connectorSwaggerDto `json:"swagger,omitempty"`
 	Unblockable bool
 }
 
-type connectorArrayDto struct {
-	Value []connectorDto `json:"value"`
-}
-
 type unblockableConnectorDto struct {
 	Id       string                          `json:"id"`
 	Metadata unblockableConnectorMetadataDto `json:"metadata"`
@@ -42,3 +42,51 @@ type virtualConnectorMetadataDto struct {
 	Type             string `json:"type"`
 	DisplayName      string `json:"displayName"`
 }
+
+type connectorSwaggerDto struct {
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+type connectorOperationDto struct {
+	OperationId string `json:"operationId"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Deprecated  bool   `json:"deprecated"`
+	Visibility  string `json:"x-ms-visibility"`
+	Trigger     string `json:"x-ms-trigger"`
+}
+
+type CustomConnectorDto struct {
+	Name       string                       `json:"name,omitempty"`
+	Id         string                       `json:"id,omitempty"`
+	Properties customConnectorPropertiesDto `json:"properties"`
+}
+
+type customConnectorPropertiesDto struct {
+	DisplayName             string                        `json:"displayName"`
+	Description             string                        `json:"description"`
+	IconUri                 string                        `json:"iconUri,omitempty"`
+	IconBrandColor          string                        `json:"iconBrandColor"`
+	Capabilities            []string                      `json:"capabilities"`
+	Environment             customConnectorEnvironmentDto `json:"environment"`
+	BackendService          customConnectorBackendDto     `json:"backendService"`
+	ConnectionParameters    map[string]any                `json:"connectionParameters"`
+	PolicyTemplateInstances []policyTemplateInstanceDto   `json:"policyTemplateInstances"`
+	Swagger                 map[string]any                `json:"swagger"`
+	Script                  string                        `json:"script,omitempty"`
+	ScriptOperations        []string                      `json:"scriptOperations,omitempty"`
+}
+
+type customConnectorEnvironmentDto struct {
+	Name string `json:"name"`
+}
+
+type customConnectorBackendDto struct {
+	ServiceUrl string `json:"serviceUrl"`
+}
+
+type policyTemplateInstanceDto struct {
+	TemplateId string            `json:"templateId"`
+	Title      string            `json:"title"`
+	Parameters map[string]string `json:"parameters"`
+}
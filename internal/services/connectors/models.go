@@ -11,7 +11,7 @@ import (
 
 type DataSource struct {
 	helpers.TypeInfo
-	ConnectorsClient client
+	ConnectorsClient Client
 }
 
 type ListDataSourceModel struct {
@@ -31,7 +31,7 @@ type DataSourceModel struct {
 	Unblockable types.Bool   `tfsdk:"unblockable"`
 }
 
-func convertFromConnectorDto(connectorDto connectorDto) DataSourceModel {
+func convertFromConnectorDto(connectorDto ConnectorDto) DataSourceModel {
 	return DataSourceModel{
 		Id:          types.StringValue(connectorDto.Id),
 		Name:        types.StringValue(connectorDto.Name),
@@ -43,3 +43,84 @@ func convertFromConnectorDto(connectorDto connectorDto) DataSourceModel {
 		Unblockable: types.BoolValue(connectorDto.Properties.Unblockable),
 	}
 }
+
+type ActionsDataSource struct {
+	helpers.TypeInfo
+	ConnectorsClient Client
+}
+
+type ActionsListDataSourceModel struct {
+	Timeouts      timeouts.Value                    `tfsdk:"timeouts"`
+	EnvironmentId types.String                      `tfsdk:"environment_id"`
+	ConnectorIds  types.Set                         `tfsdk:"connector_ids"`
+	Connectors    []ActionsConnectorDataSourceModel `tfsdk:"connectors"`
+}
+
+type ActionsConnectorDataSourceModel struct {
+	Id                        types.String            `tfsdk:"id"`
+	Name                      types.String            `tfsdk:"name"`
+	DisplayName               types.String            `tfsdk:"display_name"`
+	SupportsEndpointFiltering types.Bool              `tfsdk:"supports_endpoint_filtering"`
+	Actions                   []ActionDataSourceModel `tfsdk:"actions"`
+}
+
+type ActionDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Summary     types.String `tfsdk:"summary"`
+	Description types.String `tfsdk:"description"`
+	Deprecated  types.Bool   `tfsdk:"deprecated"`
+	Trigger     types.Bool   `tfsdk:"trigger"`
+	Visibility  types.String `tfsdk:"visibility"`
+}
+
+func convertFromConnectorOperationDto(operation connectorOperationDto) ActionDataSourceModel {
+	return ActionDataSourceModel{
+		Id:          types.StringValue(operation.OperationId),
+		Summary:     types.StringValue(operation.Summary),
+		Description: types.StringValue(operation.Description),
+		Deprecated:  types.BoolValue(operation.Deprecated),
+		Trigger:     types.BoolValue(operation.Trigger != ""),
+		Visibility:  types.StringValue(operation.Visibility),
+	}
+}
+
+type CustomConnectorResource struct {
+	helpers.TypeInfo
+	ConnectorsClient Client
+}
+
+type CustomConnectorResourceModel struct {
+	Timeouts             timeouts.Value                       `tfsdk:"timeouts"`
+	Id                   types.String                         `tfsdk:"id"`
+	EnvironmentId        types.String                         `tfsdk:"environment_id"`
+	DisplayName          types.String                         `tfsdk:"display_name"`
+	Description          types.String                         `tfsdk:"description"`
+	OpenApiFile          types.String                         `tfsdk:"openapi_file"`
+	IconFile             types.String                         `tfsdk:"icon_file"`
+	IconBrandColor       types.String                         `tfsdk:"icon_brand_color"`
+	Security             *CustomConnectorSecurityModel        `tfsdk:"security"`
+	PolicyTemplates      []CustomConnectorPolicyTemplateModel `tfsdk:"policy_templates"`
+	CustomCodeFile       types.String                         `tfsdk:"custom_code_file"`
+	CustomCodeOperations types.Set                            `tfsdk:"custom_code_operations"`
+	DefinitionHash       types.String                         `tfsdk:"definition_hash"`
+}
+
+type CustomConnectorSecurityModel struct {
+	Type             types.String `tfsdk:"type"`
+	ApiKeyName       types.String `tfsdk:"api_key_name"`
+	ApiKeyLocation   types.String `tfsdk:"api_key_location"`
+	IdentityProvider types.String `tfsdk:"identity_provider"`
+	ClientId         types.String `tfsdk:"client_id"`
+	ClientSecret     types.String `tfsdk:"client_secret"`
+	AuthorizationUrl types.String `tfsdk:"authorization_url"`
+	TokenUrl         types.String `tfsdk:"token_url"`
+	RefreshUrl       types.String `tfsdk:"refresh_url"`
+	ResourceUrl      types.String `tfsdk:"resource_url"`
+	Scopes           types.List   `tfsdk:"scopes"`
+}
+
+type CustomConnectorPolicyTemplateModel struct {
+	TemplateId types.String `tfsdk:"template_id"`
+	Title      types.String `tfsdk:"title"`
+	Parameters types.Map    `tfsdk:"parameters"`
+}
@@ -0,0 +1,478 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connectors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+// remoteDefinitionHashKey is the private state key of the hash of the definition as last published by the provider.
+const remoteDefinitionHashKey = "remote_definition_hash"
+
+var _ resource.Resource = &CustomConnectorResource{}
+var _ resource.ResourceWithImportState = &CustomConnectorResource{}
+var _ resource.ResourceWithModifyPlan = &CustomConnectorResource{}
+var _ resource.ResourceWithValidateConfig = &CustomConnectorResource{}
+
+func NewCustomConnectorResource() resource.Resource {
+	return &CustomConnectorResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "custom_connector",
+		},
+	}
+}
+
+func (r *CustomConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *CustomConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Power Platform Custom Connector Resource publishes a custom connector to an environment from an OpenAPI (Swagger 2.0) definition file, together with its icon, security, policy templates and custom code. Changes to the files are detected through a hash of their content, and changes made to the definition outside of Terraform are detected and overwritten on the next apply.\n\nAdditional Resources:\n\n* [Custom connectors overview](https://learn.microsoft.com/connectors/custom-connectors)\n* [Create a custom connector from an OpenAPI definition](https://learn.microsoft.com/connectors/custom-connectors/define-openapi-definition)\n",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				Read:   true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the custom connector, e.g. `shared_contoso-20api-5f1e2d3c4b5a6978`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the environment to publish the custom connector to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the custom connector",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the custom connector",
+				Optional:            true,
+			},
+			"openapi_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the OpenAPI definition of the connector. Only Swagger 2.0 definitions in JSON format are supported. The url of the API is taken from the `schemes`, `host` and `basePath` of the definition.",
+				Required:            true,
+			},
+			"icon_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the icon of the connector, a PNG or JPEG image",
+				Optional:            true,
+			},
+			"icon_brand_color": schema.StringAttribute{
+				MarkdownDescription: "Background color of the icon as a hexadecimal color code, e.g. `#007ee5`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultIconBrandColor),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^#[0-9a-fA-F]{6}$`), "must be a hexadecimal color code such as `#007ee5`"),
+				},
+			},
+			"security": schema.SingleNestedAttribute{
+				MarkdownDescription: "Authentication of the connections to the connector. When not set, the security definitions of the OpenAPI file are kept and connections have no parameters.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Type of authentication, either `ApiKey`, `Basic` or `OAuth2`",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(securityTypeApiKey, securityTypeBasic, securityTypeOAuth2),
+						},
+					},
+					"api_key_name": schema.StringAttribute{
+						MarkdownDescription: "Name of the header or query parameter that holds the API key. Required when `type` is `ApiKey`.",
+						Optional:            true,
+					},
+					"api_key_location": schema.StringAttribute{
+						MarkdownDescription: "Location of the API key, either `header` or `query`. Defaults to `header`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("header", "query"),
+						},
+					},
+					"identity_provider": schema.StringAttribute{
+						MarkdownDescription: "Identity provider of OAuth2, either `oauth2` for a generic provider or `aad` for Microsoft Entra ID. Defaults to `oauth2`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(identityProviderOAuth2, identityProviderAad),
+						},
+					},
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "Client id of the OAuth2 application. Required when `type` is `OAuth2`.",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "Client secret of the OAuth2 application. Required when `type` is `OAuth2`. The secret is never returned by the service, changes made outside of Terraform are not detected.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"authorization_url": schema.StringAttribute{
+						MarkdownDescription: "Authorization url of the generic OAuth2 provider. Required when `identity_provider` is `oauth2`.",
+						Optional:            true,
+					},
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "Token url of the generic OAuth2 provider. Required when `identity_provider` is `oauth2`.",
+						Optional:            true,
+					},
+					"refresh_url": schema.StringAttribute{
+						MarkdownDescription: "Refresh url of the generic OAuth2 provider",
+						Optional:            true,
+					},
+					"resource_url": schema.StringAttribute{
+						MarkdownDescription: "Resource url of the API in Microsoft Entra ID. Required when `identity_provider` is `aad`.",
+						Optional:            true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "OAuth2 scopes requested for the connections",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"policy_templates": schema.ListNestedAttribute{
+				MarkdownDescription: "Policy templates applied to the requests of the connector, e.g. `setheader` or `routerequesttoendpoint`",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"template_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the policy template",
+							Required:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "Title of the policy",
+							Required:            true,
+						},
+						"parameters": schema.MapAttribute{
+							MarkdownDescription: "Parameters of the policy template, e.g. `x-ms-apimTemplateParameter.name`",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"custom_code_file": schema.StringAttribute{
+				MarkdownDescription: "Path of the C# custom code of the connector",
+				Optional:            true,
+			},
+			"custom_code_operations": schema.SetAttribute{
+				MarkdownDescription: "Ids of the operations the custom code runs for. Defaults to every operation of the definition.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"definition_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the OpenAPI definition, the icon and the custom code that were published. Empty when the definition was changed outside of Terraform.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *CustomConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var config CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.CustomCodeOperations.IsNull() && config.CustomCodeFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("custom_code_operations"), "Missing custom code", "`custom_code_operations` can only be set together with `custom_code_file`.")
+	}
+
+	security := config.Security
+	if security == nil || security.Type.IsUnknown() {
+		return
+	}
+
+	required := map[string]types.String{}
+	switch security.Type.ValueString() {
+	case securityTypeApiKey:
+		required["api_key_name"] = security.ApiKeyName
+	case securityTypeOAuth2:
+		required["client_id"] = security.ClientId
+		required["client_secret"] = security.ClientSecret
+		switch security.IdentityProvider.ValueString() {
+		case identityProviderAad:
+			required["resource_url"] = security.ResourceUrl
+		case "", identityProviderOAuth2:
+			if !security.IdentityProvider.IsUnknown() {
+				required["authorization_url"] = security.AuthorizationUrl
+				required["token_url"] = security.TokenUrl
+			}
+		}
+	}
+
+	for _, name := range []string{"api_key_name", "client_id", "client_secret", "authorization_url", "token_url", "resource_url"} {
+		if value, ok := required[name]; ok && value.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("security").AtName(name), "Missing security attribute", fmt.Sprintf("`%s` is required for security type '%s'.", name, security.Type.ValueString()))
+		}
+	}
+}
+
+func (r *CustomConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.ConnectorsClient = NewConnectorsClient(client.Api)
+}
+
+// ModifyPlan plans the hash of the files of the connector, so that changes to their content are detected.
+func (r *CustomConnectorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.OpenApiFile.IsUnknown() || plan.IconFile.IsUnknown() || plan.CustomCodeFile.IsUnknown() {
+		return
+	}
+
+	files, err := readCustomConnectorFiles(&plan)
+	if err != nil {
+		var fileErr *customConnectorFileError
+		if errors.As(err, &fileErr) {
+			resp.Diagnostics.AddAttributeError(path.Root(fileErr.Attribute), "Invalid custom connector file", err.Error())
+			return
+		}
+		resp.Diagnostics.AddError("Invalid custom connector files", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition_hash"), files.Hash)...)
+}
+
+func (r *CustomConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, hash, err := r.buildConnector(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	created, err := r.ConnectorsClient.CreateCustomConnector(ctx, plan.EnvironmentId.ValueString(), *connector)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(created.Name)
+	plan.DefinitionHash = types.StringValue(hash)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.storeRemoteDefinitionHash(ctx, plan.EnvironmentId.ValueString(), created.Name, resp.Private)...)
+}
+
+func (r *CustomConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, err := r.ConnectorsClient.GetCustomConnector(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	state.DisplayName = types.StringValue(connector.Properties.DisplayName)
+	state.Description = types.StringNull()
+	if connector.Properties.Description != "" {
+		state.Description = types.StringValue(connector.Properties.Description)
+	}
+	state.IconBrandColor = types.StringValue(connector.Properties.IconBrandColor)
+
+	remoteHash, err := hashOpenApiDefinition(connector.Properties.Swagger)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+	storedHash, diags := req.Private.GetKey(ctx, remoteDefinitionHashKey)
+	resp.Diagnostics.Append(diags...)
+	if storedHash == nil || string(storedHash) != fmt.Sprintf("%q", remoteHash) {
+		// The definition was changed outside of Terraform, or the connector was imported.
+		tflog.Debug(ctx, fmt.Sprintf("Definition of custom connector '%s' differs from the published one", state.Id.ValueString()))
+		state.DefinitionHash = types.StringValue("")
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("READ: %s with id %s", r.FullTypeName(), state.Id.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CustomConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan, state CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, hash, err := r.buildConnector(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+	connector.Name = state.Id.ValueString()
+
+	_, err = r.ConnectorsClient.UpdateCustomConnector(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), *connector)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	plan.Id = state.Id
+	plan.DefinitionHash = types.StringValue(hash)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	resp.Diagnostics.Append(r.storeRemoteDefinitionHash(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), resp.Private)...)
+}
+
+func (r *CustomConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state CustomConnectorResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.ConnectorsClient.DeleteCustomConnector(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+		return
+	}
+}
+
+func (r *CustomConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id/connector_name', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// buildConnector reads the files of the connector and builds the connector to publish, together with the hash of its files.
+func (r *CustomConnectorResource) buildConnector(ctx context.Context, model *CustomConnectorResourceModel) (*CustomConnectorDto, string, error) {
+	files, err := readCustomConnectorFiles(model)
+	if err != nil {
+		return nil, "", err
+	}
+	connector, err := buildCustomConnectorDto(ctx, model, files)
+	if err != nil {
+		return nil, "", err
+	}
+	return connector, files.Hash, nil
+}
+
+// privateState is implemented by the private state of the responses of the resource.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// storeRemoteDefinitionHash stores the hash of the definition as returned by the service after it was published, so that
+// Read can detect changes made outside of Terraform.
+func (r *CustomConnectorResource) storeRemoteDefinitionHash(ctx context.Context, environmentId, connectorName string, private privateState) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	connector, err := r.ConnectorsClient.GetCustomConnector(ctx, environmentId, connectorName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return diags
+	}
+	hash, err := hashOpenApiDefinition(connector.Properties.Swagger)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return diags
+	}
+	value, err := json.Marshal(hash)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, remoteDefinitionHashKey, value)
+}
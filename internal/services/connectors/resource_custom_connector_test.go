@@ -0,0 +1,281 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package connectors_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+const customConnectorDefinition = `{
+	"swagger": "2.0",
+	"info": { "title": "Contoso API", "version": "1.0" },
+	"host": "api.contoso.com",
+	"basePath": "/v1",
+	"schemes": ["https"],
+	"paths": {
+		"/orders": {
+			"get": { "operationId": "ListOrders", "summary": "List orders", "responses": { "200": { "description": "OK" } } },
+			"post": { "operationId": "CreateOrder", "summary": "Create an order", "responses": { "201": { "description": "Created" } } }
+		}
+	}
+}`
+
+func TestAccCustomConnectorResource_Validate_Create(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	require.NoError(t, os.WriteFile(file, []byte(customConnectorDefinition), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "environment" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+				}
+
+				resource "powerplatform_custom_connector" "contoso" {
+					environment_id = powerplatform_environment.environment.id
+					display_name   = "Contoso API"
+					description    = "Orders of Contoso"
+					openapi_file   = "` + filepath.ToSlash(file) + `"
+
+					security = {
+						type         = "ApiKey"
+						api_key_name = "X-Api-Key"
+					}
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_custom_connector.contoso", "id", regexp.MustCompile(`^shared_`)),
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "display_name", "Contoso API"),
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "icon_brand_color", "#007ee5"),
+					resource.TestMatchResourceAttr("powerplatform_custom_connector.contoso", "definition_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitCustomConnectorResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	const connectorUrl = `https://api.powerapps.com/providers/Microsoft.PowerApps/apis/shared_contoso-20api-5f1e2d3c4b5a6978?%24filter=environment+eq+%2700000000-0000-0000-0000-000000000001%27&api-version=2019-05-01`
+
+	// The mock publishes the connector it receives, so that reading it returns what was last sent.
+	var published map[string]any
+	publish := func(req *http.Request) (*http.Response, error) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		published = map[string]any{}
+		if err := json.Unmarshal(body, &published); err != nil {
+			return nil, err
+		}
+
+		properties := published["properties"].(map[string]any)
+		oauthSettings := properties["connectionParameters"].(map[string]any)["token"].(map[string]any)["oAuthSettings"].(map[string]any)
+		swagger := properties["swagger"].(map[string]any)
+		if !strings.HasPrefix(properties["backendService"].(map[string]any)["serviceUrl"].(string), "https://api.contoso.com/v") ||
+			oauthSettings["clientSecret"] != "secret" ||
+			swagger["securityDefinitions"].(map[string]any)["oauth2_auth"] == nil {
+			return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected connector"), nil
+		}
+
+		// The client secret is never returned by the service.
+		delete(oauthSettings, "clientSecret")
+		published["name"] = "shared_contoso-20api-5f1e2d3c4b5a6978"
+		published["id"] = "/providers/Microsoft.PowerApps/apis/shared_contoso-20api-5f1e2d3c4b5a6978"
+		return httpmock.NewJsonResponse(http.StatusOK, published)
+	}
+
+	httpmock.RegisterResponder("POST", `https://api.powerapps.com/providers/Microsoft.PowerApps/apis?%24filter=environment+eq+%2700000000-0000-0000-0000-000000000001%27&api-version=2019-05-01`, publish)
+	httpmock.RegisterResponder("PUT", connectorUrl, publish)
+
+	httpmock.RegisterResponder("GET", connectorUrl,
+		func(req *http.Request) (*http.Response, error) {
+			if published == nil {
+				return httpmock.NewStringResponse(http.StatusNotFound, ""), nil
+			}
+			return httpmock.NewJsonResponse(http.StatusOK, published)
+		})
+
+	httpmock.RegisterResponder("DELETE", connectorUrl,
+		func(req *http.Request) (*http.Response, error) {
+			published = nil
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	writeFile := func(content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	}
+	config := `
+	resource "powerplatform_custom_connector" "contoso" {
+		environment_id   = "00000000-0000-0000-0000-000000000001"
+		display_name     = "Contoso API"
+		openapi_file     = "` + filepath.ToSlash(file) + `"
+		icon_brand_color = "#da3b01"
+
+		security = {
+			type              = "OAuth2"
+			client_id         = "00000000-0000-0000-0000-000000000002"
+			client_secret     = "secret"
+			authorization_url = "https://login.contoso.com/authorize"
+			token_url         = "https://login.contoso.com/token"
+			scopes            = ["orders.read"]
+		}
+
+		policy_templates = [
+			{
+				template_id = "setheader"
+				title       = "Set the tenant"
+				parameters = {
+					"x-ms-apimTemplateParameter.name"  = "X-Tenant"
+					"x-ms-apimTemplateParameter.value" = "contoso"
+				}
+			},
+		]
+	}`
+
+	var firstHash string
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeFile(customConnectorDefinition)
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "id", "shared_contoso-20api-5f1e2d3c4b5a6978"),
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "display_name", "Contoso API"),
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "icon_brand_color", "#da3b01"),
+					resource.TestCheckResourceAttr("powerplatform_custom_connector.contoso", "security.client_secret", "secret"),
+					resource.TestMatchResourceAttr("powerplatform_custom_connector.contoso", "definition_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrWith("powerplatform_custom_connector.contoso", "definition_hash", func(value string) error {
+						firstHash = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile(strings.Replace(customConnectorDefinition, `"/v1"`, `"/v2"`, 1))
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("powerplatform_custom_connector.contoso", "definition_hash", func(value string) error {
+						if value == firstHash {
+							return errors.New("definition_hash did not change after the definition changed")
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				// A change of the definition outside of Terraform is detected and published again.
+				PreConfig: func() {
+					published["properties"].(map[string]any)["swagger"].(map[string]any)["host"] = "api.fabrikam.com"
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitCustomConnectorResource_Validate_Security(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_custom_connector" "contoso" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					display_name   = "Contoso API"
+					openapi_file   = "openapi.json"
+
+					security = {
+						type          = "OAuth2"
+						client_id     = "00000000-0000-0000-0000-000000000002"
+						client_secret = "secret"
+					}
+				}`,
+
+				ExpectError: regexp.MustCompile("`authorization_url` is required for security type 'OAuth2'"),
+			},
+		},
+	})
+}
+
+func TestUnitCustomConnectorResource_Validate_Invalid_Definition(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{ "openapi": "3.0.1", "host": "api.contoso.com" }`), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_custom_connector" "contoso" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					display_name   = "Contoso API"
+					openapi_file   = "` + filepath.ToSlash(file) + `"
+				}`,
+
+				ExpectError: regexp.MustCompile("only Swagger 2.0 definitions are supported"),
+			},
+		},
+	})
+}
+
+func TestUnitCustomConnectorResource_Validate_Missing_Icon_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "openapi.json")
+	require.NoError(t, os.WriteFile(file, []byte(customConnectorDefinition), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_custom_connector" "contoso" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					display_name   = "Contoso API"
+					openapi_file   = "` + filepath.ToSlash(file) + `"
+					icon_file      = "missing.png"
+				}`,
+
+				// The error points at the icon file, not at the OpenAPI definition.
+				ExpectError: regexp.MustCompile(`(?s)Invalid custom connector file.*icon_file\s*=\s*"missing\.png".*missing\.png`),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ package currencies
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -34,20 +33,10 @@ func (client *client) GetCurrenciesByLocation(ctx context.Context, location stri
 	values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
 	apiUrl.RawQuery = values.Encode()
 
-	currencies := currenciesDto{}
-
-	response, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, nil)
-	if err != nil {
-		return currencies, err
-	}
-
-	defer response.HttpResponse.Body.Close()
-
-	err = json.Unmarshal(response.BodyAsBytes, &currencies)
-
+	currencies, _, err := api.ExecuteForAllPages[currenciesArrayDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
-		return currencies, err
+		return currenciesDto{}, err
 	}
 
-	return currencies, nil
+	return currenciesDto{Value: currencies}, nil
 }
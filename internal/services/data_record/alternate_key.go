@@ -0,0 +1,272 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+)
+
+// formatAlternateKey formats the columns of an alternate key as the key segment of a Dataverse url,
+// for example `accountnumber='ACC-001',address1_postalcode='98052'`. Columns are sorted by name, so the result is stable.
+func formatAlternateKey(alternateKey map[string]any) (string, error) {
+	if len(alternateKey) == 0 {
+		return "", errors.New("alternate_key must contain at least one column")
+	}
+
+	parts := make([]string, 0, len(alternateKey))
+	for _, column := range sortedKeys(alternateKey) {
+		switch value := alternateKey[column].(type) {
+		case string:
+			parts = append(parts, fmt.Sprintf("%s='%s'", column, strings.ReplaceAll(value, "'", "''")))
+		case float64:
+			parts = append(parts, fmt.Sprintf("%s=%s", column, strconv.FormatFloat(value, 'f', -1, 64)))
+		case int64:
+			parts = append(parts, fmt.Sprintf("%s=%d", column, value))
+		case bool:
+			parts = append(parts, fmt.Sprintf("%s=%t", column, value))
+		default:
+			return "", fmt.Errorf("alternate_key column '%s' must be a string, number or bool", column)
+		}
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// getTableLogicalNameAndRecordKeyFromMap returns the table of a record reference and the key that addresses the record in a url,
+// which is either the id from `data_record_id` or the formatted `alternate_key`.
+func getTableLogicalNameAndRecordKeyFromMap(nestedMap map[string]any) (tableLogicalName string, recordKey string, err error) {
+	tableLogicalName, ok := nestedMap["table_logical_name"].(string)
+	if !ok {
+		return "", "", errors.New("table_logical_name field is missing or not a string")
+	}
+
+	alternateKey, hasAlternateKey := nestedMap["alternate_key"].(map[string]any)
+	dataRecordId, hasDataRecordId := nestedMap["data_record_id"].(string)
+	switch {
+	case hasAlternateKey && hasDataRecordId:
+		return "", "", errors.New("only one of data_record_id and alternate_key can be set")
+	case hasAlternateKey:
+		recordKey, err = formatAlternateKey(alternateKey)
+		if err != nil {
+			return "", "", err
+		}
+		return tableLogicalName, recordKey, nil
+	case hasDataRecordId:
+		return tableLogicalName, dataRecordId, nil
+	default:
+		return "", "", errors.New("one of data_record_id or alternate_key must be set")
+	}
+}
+
+// resolveRecordReference returns the table and the id of a record reference. References by alternate key are looked up.
+func resolveRecordReference(ctx context.Context, client *client, environmentId string, nestedMap map[string]any) (tableLogicalName string, dataRecordId string, err error) {
+	tableLogicalName, recordKey, err := getTableLogicalNameAndRecordKeyFromMap(nestedMap)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := nestedMap["alternate_key"]; !ok {
+		return tableLogicalName, recordKey, nil
+	}
+
+	dataRecordId, err = client.GetDataRecordIdByKey(ctx, environmentId, tableLogicalName, recordKey)
+	if err != nil {
+		return "", "", err
+	}
+	return tableLogicalName, dataRecordId, nil
+}
+
+// GetDataRecordIdByKey returns the id of the record of a table that is addressed by recordKey.
+func (client *client) GetDataRecordIdByKey(ctx context.Context, environmentId, tableLogicalName, recordKey string) (string, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return "", err
+	}
+
+	entityDefinition, err := getEntityDefinition(ctx, client, environmentId, tableLogicalName)
+	if err != nil {
+		return "", err
+	}
+
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   environmentHost,
+		Path:   fmt.Sprintf("/api/data/%s/%s(%s)", constants.DATAVERSE_API_VERSION, entityDefinition.LogicalCollectionName, recordKey),
+	}
+	values := url.Values{}
+	values.Add("$select", entityDefinition.PrimaryIDAttribute)
+	apiUrl.RawQuery = values.Encode()
+
+	record := map[string]any{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &record)
+	if err != nil {
+		return "", err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return "", err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return "", customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("record %s(%s) not found", tableLogicalName, recordKey))
+	}
+
+	dataRecordId, ok := record[entityDefinition.PrimaryIDAttribute].(string)
+	if !ok {
+		return "", fmt.Errorf("%s field is missing or not a string", entityDefinition.PrimaryIDAttribute)
+	}
+	return dataRecordId, nil
+}
+
+// convertAlternateKeyReferenceToState converts a record reference by alternate key back into its state value.
+func convertAlternateKeyReferenceToState(nestedMap map[string]any) (types.Object, error) {
+	tableLogicalName, ok := nestedMap["table_logical_name"].(string)
+	if !ok {
+		return types.Object{}, errors.New("table_logical_name field is missing or not a string")
+	}
+	alternateKey, ok := nestedMap["alternate_key"].(map[string]any)
+	if !ok {
+		return types.Object{}, errors.New("alternate_key field is missing or not an object")
+	}
+
+	keyTypes, keyValues := map[string]attr.Type{}, map[string]attr.Value{}
+	for column, value := range alternateKey {
+		switch typedValue := value.(type) {
+		case string:
+			keyTypes[column], keyValues[column] = types.StringType, types.StringValue(typedValue)
+		case float64:
+			keyTypes[column], keyValues[column] = types.Float64Type, types.Float64Value(typedValue)
+		case bool:
+			keyTypes[column], keyValues[column] = types.BoolType, types.BoolValue(typedValue)
+		default:
+			return types.Object{}, fmt.Errorf("alternate_key column '%s' must be a string, number or bool", column)
+		}
+	}
+	keyObject, diags := types.ObjectValue(keyTypes, keyValues)
+	if diags.HasError() {
+		return types.Object{}, fmt.Errorf("failed to create object value: %v", diags)
+	}
+
+	reference, diags := types.ObjectValue(
+		map[string]attr.Type{
+			"table_logical_name": types.StringType,
+			"alternate_key":      keyObject.Type(context.Background()),
+		},
+		map[string]attr.Value{
+			"table_logical_name": types.StringValue(tableLogicalName),
+			"alternate_key":      keyObject,
+		},
+	)
+	if diags.HasError() {
+		return types.Object{}, fmt.Errorf("failed to create object value: %v", diags)
+	}
+	return reference, nil
+}
+
+// isAlternateKeyReference reports whether a column value is a record reference by alternate key.
+func isAlternateKeyReference(value any) bool {
+	nestedMap, ok := value.(map[string]any)
+	if !ok {
+		return false
+	}
+	_, ok = nestedMap["alternate_key"]
+	return ok
+}
+
+// alternateKeyLookupToState returns the configured lookup by alternate key when it still resolves to the record the lookup column points to.
+func alternateKeyLookupToState(ctx context.Context, client *client, environmentId string, stateLookup any, lookupRecordId any) (types.Object, bool, error) {
+	nestedMap, ok := stateLookup.(map[string]any)
+	if !ok {
+		return types.Object{}, false, nil
+	}
+	currentRecordId, ok := lookupRecordId.(string)
+	if !ok {
+		return types.Object{}, false, nil
+	}
+
+	_, dataRecordId, err := resolveRecordReference(ctx, client, environmentId, nestedMap)
+	if errors.Is(err, customerrors.ErrObjectNotFound) {
+		return types.Object{}, false, nil
+	}
+	if err != nil {
+		return types.Object{}, false, err
+	}
+	if !strings.EqualFold(dataRecordId, currentRecordId) {
+		return types.Object{}, false, nil
+	}
+
+	reference, err := convertAlternateKeyReferenceToState(nestedMap)
+	if err != nil {
+		return types.Object{}, false, err
+	}
+	return reference, true, nil
+}
+
+// alternateKeyRelationToState returns the configured relation when all of its records are referenced by alternate key
+// and they still resolve to exactly the records that are related.
+func alternateKeyRelationToState(ctx context.Context, client *client, environmentId, tableLogicalName, relationLogicalName string, relatedRecords []any, stateItems []any) (types.Set, bool, error) {
+	if len(stateItems) == 0 || len(stateItems) != len(relatedRecords) {
+		return types.Set{}, false, nil
+	}
+	for _, item := range stateItems {
+		if !isAlternateKeyReference(item) {
+			return types.Set{}, false, nil
+		}
+	}
+
+	relationTableLogicalName, err := client.GetEntityRelationDefinitionInfo(ctx, environmentId, tableLogicalName, relationLogicalName)
+	if err != nil {
+		return types.Set{}, false, errors.New("error getting entity relation definition info: " + err.Error())
+	}
+	relationDefinition, err := getEntityDefinition(ctx, client, environmentId, relationTableLogicalName)
+	if err != nil {
+		return types.Set{}, false, errors.New("error getting entity definition: " + err.Error())
+	}
+
+	relatedIds := map[string]bool{}
+	for _, rawItem := range relatedRecords {
+		item, ok := rawItem.(map[string]any)
+		if !ok {
+			return types.Set{}, false, errors.New("error asserting rawItem to map[string]any")
+		}
+		if relatedId, ok := item[relationDefinition.PrimaryIDAttribute].(string); ok {
+			relatedIds[strings.ToLower(relatedId)] = true
+		}
+	}
+
+	elements := make([]attr.Value, 0, len(stateItems))
+	for _, item := range stateItems {
+		nestedMap, _ := item.(map[string]any)
+		_, dataRecordId, err := resolveRecordReference(ctx, client, environmentId, nestedMap)
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			return types.Set{}, false, nil
+		}
+		if err != nil {
+			return types.Set{}, false, err
+		}
+		if !relatedIds[strings.ToLower(dataRecordId)] {
+			return types.Set{}, false, nil
+		}
+
+		reference, err := convertAlternateKeyReferenceToState(nestedMap)
+		if err != nil {
+			return types.Set{}, false, err
+		}
+		elements = append(elements, reference)
+	}
+
+	relation, diags := types.SetValue(elements[0].Type(ctx), elements)
+	if diags.HasError() {
+		// the referenced records use different alternate keys, which a set can't hold.
+		return types.Set{}, false, nil
+	}
+	return relation, true, nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitFormatAlternateKey(t *testing.T) {
+	recordKey, err := formatAlternateKey(map[string]any{
+		"name":          "O'Neil",
+		"accountnumber": "ACC-001",
+		"revenue":       float64(1000),
+		"creditonhold":  false,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "accountnumber='ACC-001',creditonhold=false,name='O''Neil',revenue=1000", recordKey)
+
+	_, err = formatAlternateKey(map[string]any{})
+	require.Error(t, err)
+
+	_, err = formatAlternateKey(map[string]any{"address": map[string]any{}})
+	require.ErrorContains(t, err, "alternate_key column 'address' must be a string, number or bool")
+}
+
+func TestUnitGetTableLogicalNameAndRecordKeyFromMap(t *testing.T) {
+	tableLogicalName, recordKey, err := getTableLogicalNameAndRecordKeyFromMap(map[string]any{
+		"table_logical_name": "account",
+		"alternate_key":      map[string]any{"accountnumber": "ACC-001"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "account", tableLogicalName)
+	require.Equal(t, "accountnumber='ACC-001'", recordKey)
+
+	_, recordKey, err = getTableLogicalNameAndRecordKeyFromMap(map[string]any{
+		"table_logical_name": "account",
+		"data_record_id":     "00000000-0000-0000-0000-000000000020",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "00000000-0000-0000-0000-000000000020", recordKey)
+
+	_, _, err = getTableLogicalNameAndRecordKeyFromMap(map[string]any{
+		"table_logical_name": "account",
+		"data_record_id":     "00000000-0000-0000-0000-000000000020",
+		"alternate_key":      map[string]any{"accountnumber": "ACC-001"},
+	})
+	require.ErrorContains(t, err, "only one of data_record_id and alternate_key can be set")
+}
+
+func TestUnitApplyDataRecord_UpsertsByAlternateKey(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	var body map[string]any
+	httpmock.RegisterResponder("PATCH", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/accounts%28accountnumber=%27ACC-001%27%29`,
+		func(req *http.Request) (*http.Response, error) {
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", "https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/accounts(00000000-0000-0000-0000-000000000020)")
+			return resp, nil
+		})
+
+	client := newTestDataRecordClient()
+	record, err := client.ApplyDataRecord(context.Background(), "", "00000000-0000-0000-0000-000000000001", "account",
+		map[string]any{"accountnumber": "ACC-001"},
+		map[string]any{
+			"name": "Contoso",
+			"primarycontactid": map[string]any{
+				"table_logical_name": "contact",
+				"alternate_key":      map[string]any{"emailaddress1": "john@contoso.com"},
+			},
+		})
+
+	require.NoError(t, err)
+	require.Equal(t, "00000000-0000-0000-0000-000000000020", record.Id)
+	require.Equal(t, map[string]any{
+		"name":                        "Contoso",
+		"primarycontactid@odata.bind": "/contacts(emailaddress1='john@contoso.com')",
+	}, body)
+}
+
+func TestUnitAlternateKeyLookupToState(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/contacts%28emailaddress1=%27john@contoso.com%27%29?%24select=contactid`,
+		httpmock.NewStringResponder(http.StatusOK, `{"contactid":"00000000-0000-0000-0000-000000000010"}`))
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/contacts%28emailaddress1=%27gone@contoso.com%27%29?%24select=contactid`,
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"code":"0x80040217","message":"A record with the specified key values does not exist in contact entity"}}`))
+
+	client := newTestDataRecordClient()
+	lookup := map[string]any{
+		"table_logical_name": "contact",
+		"alternate_key":      map[string]any{"emailaddress1": "john@contoso.com"},
+	}
+
+	value, ok, err := alternateKeyLookupToState(context.Background(), &client, "00000000-0000-0000-0000-000000000001", lookup, "00000000-0000-0000-0000-000000000010")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, `{"alternate_key":{"emailaddress1":"john@contoso.com"},"table_logical_name":"contact"}`, value.String())
+
+	_, ok, err = alternateKeyLookupToState(context.Background(), &client, "00000000-0000-0000-0000-000000000001", lookup, "00000000-0000-0000-0000-000000000011")
+	require.NoError(t, err)
+	require.False(t, ok, "a lookup that points to another record must be reported as drift")
+
+	_, ok, err = alternateKeyLookupToState(context.Background(), &client, "00000000-0000-0000-0000-000000000001", map[string]any{
+		"table_logical_name": "contact",
+		"alternate_key":      map[string]any{"emailaddress1": "gone@contoso.com"},
+	}, "00000000-0000-0000-0000-000000000010")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -11,11 +11,14 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
 	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
 	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
 )
 
 func newDataRecordClient(apiClient *api.Client) client {
@@ -97,7 +100,7 @@ func (client *client) getEnvironment(ctx context.Context, environmentId string)
 	return &env, nil
 }
 
-func (client *client) GetDataRecordsByODataQuery(ctx context.Context, environmentId, query string, headers map[string]string) (*ODataQueryResponse, error) {
+func (client *client) GetDataRecordsByODataQuery(ctx context.Context, environmentId, query string, headers map[string]string, pagingOptions *api.PagingOptions) (*ODataQueryResponse, error) {
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
 	if err != nil {
 		return nil, err
@@ -110,8 +113,8 @@ func (client *client) GetDataRecordsByODataQuery(ctx context.Context, environmen
 
 	apiUrl := fmt.Sprintf("https://%s/api/data/%s/%s", environmentHost, constants.DATAVERSE_API_VERSION, query)
 
-	response := map[string]any{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, h, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+	pages := client.Api.NewPageIterator(nil, apiUrl, h, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, pagingOptions)
+	resp, pageRecords, err := pages.Next(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute OData query: %w", err)
 	}
@@ -122,6 +125,11 @@ func (client *client) GetDataRecordsByODataQuery(ctx context.Context, environmen
 		return nil, err
 	}
 
+	response := map[string]any{}
+	if err := resp.MarshallTo(&response); err != nil {
+		return nil, fmt.Errorf("failed to execute OData query: %w", err)
+	}
+
 	var totalRecords *int64
 	if rawCount, exists := response["@Microsoft.Dynamics.CRM.totalrecordcount"]; exists && rawCount != nil {
 		if count, ok := rawCount.(float64); ok {
@@ -137,16 +145,23 @@ func (client *client) GetDataRecordsByODataQuery(ctx context.Context, environmen
 
 	records := []map[string]any{}
 	if response["value"] != nil {
-		valueSlice, ok := response["value"].([]any)
-		if !ok {
+		if _, ok := response["value"].([]any); !ok {
 			return nil, errors.New("value field is not of type []any")
 		}
-		for _, item := range valueSlice {
-			value, ok := item.(map[string]any)
-			if !ok {
-				return nil, errors.New("item is not of type map[string]any")
+		for {
+			for _, item := range pageRecords {
+				value := map[string]any{}
+				if err := json.Unmarshal(item, &value); err != nil {
+					return nil, errors.New("item is not of type map[string]any")
+				}
+				records = append(records, value)
+			}
+			if !pages.HasNext() {
+				break
+			}
+			if _, pageRecords, err = pages.Next(ctx); err != nil {
+				return nil, fmt.Errorf("failed to execute OData query: %w", err)
 			}
-			records = append(records, value)
 		}
 	} else {
 		records = append(records, response)
@@ -410,25 +425,12 @@ func getEntityRelationDefinitionManyToMany(mapResponse map[string]any, entityLog
 	return tableName, nil
 }
 
-func (client *client) GetEntityAttributesDefinition(ctx context.Context, environmentId string, entityLogicalName string) ([]attributesApiBodyDto, error) {
+func (client *client) GetEntityAttributesDefinition(ctx context.Context, environmentId string, entityLogicalName string) ([]dataverse_metadata.AttributeMetadataDto, error) {
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
 	if err != nil {
 		return nil, err
 	}
-	apiUrl := fmt.Sprintf("https://%s/api/data/%s/EntityDefinitions(LogicalName='%s')/Attributes?$select=LogicalName", environmentHost, constants.DATAVERSE_API_VERSION, entityLogicalName)
-
-	results := attributesApiResponseDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &results)
-	if err != nil {
-		return nil, err
-	}
-	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
-		return nil, err
-	}
-	if err := client.Api.HandleNotFoundResponse(resp); err != nil {
-		return nil, err
-	}
-	return results.Value, nil
+	return dataverse_metadata.GetEntityAttributesDefinition(ctx, client.Api, environmentHost, entityLogicalName, url.Values{"$select": {"LogicalName"}})
 }
 
 func (client *client) GetEntityRelationDefinitionInfo(ctx context.Context, environmentId string, entityLogicalName string, relationLogicalName string) (tableName string, err error) {
@@ -480,7 +482,8 @@ func (client *client) GetEntityRelationDefinitionInfo(ctx context.Context, envir
 	return tableName, nil
 }
 
-func (client *client) ApplyDataRecord(ctx context.Context, recordId, environmentId, tableName string, columns map[string]any) (*dataRecordDto, error) {
+// ApplyDataRecord creates or updates a record. A record without id is upserted by its alternate key when one is given.
+func (client *client) ApplyDataRecord(ctx context.Context, recordId, environmentId, tableName string, alternateKey map[string]any, columns map[string]any) (*dataRecordDto, error) {
 	result := dataRecordDto{}
 
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
@@ -494,7 +497,7 @@ func (client *client) ApplyDataRecord(ctx context.Context, recordId, environment
 		if nestedMap, ok := value.(map[string]any); ok {
 			delete(columns, key)
 			if len(nestedMap) > 0 {
-				tableLogicalName, dataRecordId, err := getTableLogicalNameAndDataRecordIdFromMap(nestedMap)
+				tableLogicalName, recordKey, err := getTableLogicalNameAndRecordKeyFromMap(nestedMap)
 				if err != nil {
 					return nil, err
 				}
@@ -504,7 +507,7 @@ func (client *client) ApplyDataRecord(ctx context.Context, recordId, environment
 					return nil, err
 				}
 
-				columns[fmt.Sprintf("%s@odata.bind", key)] = fmt.Sprintf("/%s(%s)", entityDefinition.LogicalCollectionName, dataRecordId)
+				columns[fmt.Sprintf("%s@odata.bind", key)] = fmt.Sprintf("/%s(%s)", entityDefinition.LogicalCollectionName, recordKey)
 			}
 		} else if nestedMapList, ok := value.([]any); ok {
 			delete(columns, key)
@@ -529,6 +532,14 @@ func (client *client) ApplyDataRecord(ctx context.Context, recordId, environment
 		// if we are referencing the record by its primaryId and its not empty (update or delete) then we send an update
 		method = "PATCH"
 		apiPath = fmt.Sprintf("%s(%s)", apiPath, recordId)
+	} else if len(alternateKey) > 0 {
+		// addressing a record by its alternate key creates it or updates the record that already has this key.
+		recordKey, err := formatAlternateKey(alternateKey)
+		if err != nil {
+			return nil, err
+		}
+		method = "PATCH"
+		apiPath = fmt.Sprintf("%s(%s)", apiPath, recordKey)
 	}
 
 	apiUrl := &url.URL{
@@ -565,12 +576,10 @@ func (client *client) ApplyDataRecord(ctx context.Context, recordId, environment
 			return nil, err
 		}
 	} else if response.HttpResponse.Header.Get(constants.HEADER_ODATA_ENTITY_ID) != "" {
-		re := regexp.MustCompile("[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}")
-		match := re.FindAllStringSubmatch(response.HttpResponse.Header.Get(constants.HEADER_ODATA_ENTITY_ID), -1)
-		if len(match) == 0 {
-			return nil, errors.New("no entity record id returned from the odata-entityid header")
+		result.Id, err = getRecordIdFromEntityIdHeader(response.HttpResponse.Header.Get(constants.HEADER_ODATA_ENTITY_ID))
+		if err != nil {
+			return nil, err
 		}
-		result.Id = match[len(match)-1][0]
 	} else {
 		return nil, errors.New("no entity record id returned from the API")
 	}
@@ -608,9 +617,13 @@ func (client *client) DeleteDataRecord(ctx context.Context, recordId string, env
 					return errors.New("nestedItem is not of type map[string]any")
 				}
 
-				dataRecordId, ok := nestedMap["data_record_id"].(string)
-				if !ok {
-					return errors.New("data_record_id field is missing or not a string")
+				_, dataRecordId, err := resolveRecordReference(ctx, client, environmentId, nestedMap)
+				if errors.Is(err, customerrors.ErrObjectNotFound) {
+					// the related record is gone and so is its relation.
+					continue
+				}
+				if err != nil {
+					return err
 				}
 
 				apiUrl := &url.URL{
@@ -645,16 +658,15 @@ func (client *client) DeleteDataRecord(ctx context.Context, recordId string, env
 	return nil
 }
 
-func getTableLogicalNameAndDataRecordIdFromMap(nestedMap map[string]any) (tableLogicalName string, dataRecordId string, err error) {
-	tableLogicalName, ok := nestedMap["table_logical_name"].(string)
-	if !ok {
-		return "", "", errors.New("table_logical_name field is missing or not a string")
-	}
-	id, ok := nestedMap["data_record_id"].(string)
-	if !ok {
-		return "", "", errors.New("data_record_id field is missing or not a string")
+var recordIdRegex = regexp.MustCompile("[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}")
+
+// getRecordIdFromEntityIdHeader returns the id of the record the `OData-EntityId` header points to.
+func getRecordIdFromEntityIdHeader(entityId string) (string, error) {
+	match := recordIdRegex.FindAllStringSubmatch(entityId, -1)
+	if len(match) == 0 {
+		return "", errors.New("no entity record id returned from the odata-entityid header")
 	}
-	return tableLogicalName, id, nil
+	return match[len(match)-1][0], nil
 }
 
 func applyRelations(ctx context.Context, client *client, relations map[string]any, environmentId string, parentRecordId string, entityDefinition *entityDefinitionsDto) error {
@@ -700,60 +712,43 @@ func applyRelation(ctx context.Context, environmentHost string, entityDefinition
 		return err
 	}
 
-	var toBeDeleted = make([]relationApiBodyDto, 0)
-
-	for _, existingRelation := range existingRelationsResponse.Value {
-		shouldDelete := true
-		for _, nestedItem := range nestedMapList {
-			nestedMap, ok := nestedItem.(map[string]any)
-			if !ok {
-				return errors.New("nestedItem is not of type map[string]any")
-			}
-
-			tableLogicalName, dataRecordId, err := getTableLogicalNameAndDataRecordIdFromMap(nestedMap)
-			if err != nil {
-				return err
-			}
-
-			relationEntityDefinition, err := getEntityDefinition(ctx, client, environmentId, tableLogicalName)
-			if err != nil {
-				return err
-			}
-			if existingRelation.OdataID == fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, relationEntityDefinition.LogicalCollectionName, dataRecordId) {
-				shouldDelete = false
-				break
-			}
+	relatedRecordUrls := make([]string, 0, len(nestedMapList))
+	for _, nestedItem := range nestedMapList {
+		nestedMap, ok := nestedItem.(map[string]any)
+		if !ok {
+			return errors.New("nestedItem is not of type map[string]any")
 		}
-		if shouldDelete {
-			toBeDeleted = append(toBeDeleted, existingRelation)
+
+		tableLogicalName, dataRecordId, err := resolveRecordReference(ctx, client, environmentId, nestedMap)
+		if err != nil {
+			return err
 		}
-	}
 
-	for _, relation := range toBeDeleted {
-		_, err = client.Api.Execute(ctx, nil, "DELETE", relation.OdataID, nil, nil, []int{http.StatusOK, http.StatusNoContent}, nil)
+		relationEntityDefinition, err := getEntityDefinition(ctx, client, environmentId, tableLogicalName)
 		if err != nil {
 			return err
 		}
+		relatedRecordUrls = append(relatedRecordUrls, fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, relationEntityDefinition.LogicalCollectionName, dataRecordId))
 	}
 
-	for _, nestedItem := range nestedMapList {
-		nestedMap, ok := nestedItem.(map[string]any)
-		if !ok {
-			return errors.New("nestedItem is not of type map[string]any")
-		}
+	var toBeDeleted = make([]relationApiBodyDto, 0)
 
-		tableLogicalName, dataRecordId, err := getTableLogicalNameAndDataRecordIdFromMap(nestedMap)
-		if err != nil {
-			return err
+	for _, existingRelation := range existingRelationsResponse.Value {
+		if !slices.Contains(relatedRecordUrls, existingRelation.OdataID) {
+			toBeDeleted = append(toBeDeleted, existingRelation)
 		}
+	}
 
-		entityDefinition, err := getEntityDefinition(ctx, client, environmentId, tableLogicalName)
+	for _, relation := range toBeDeleted {
+		_, err = client.Api.Execute(ctx, nil, "DELETE", relation.OdataID, nil, nil, []int{http.StatusOK, http.StatusNoContent}, nil)
 		if err != nil {
 			return err
 		}
+	}
 
+	for _, relatedRecordUrl := range relatedRecordUrls {
 		relation := relationApiBodyDto{
-			OdataID: fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, entityDefinition.LogicalCollectionName, dataRecordId),
+			OdataID: relatedRecordUrl,
 		}
 		resp, err := client.Api.Execute(ctx, nil, "POST", apiUrl.String(), nil, relation, []int{http.StatusOK, http.StatusNoContent, http.StatusForbidden, http.StatusNotFound}, nil)
 		if err != nil {
@@ -768,3 +763,199 @@ func applyRelation(ctx context.Context, environmentHost string, entityDefinition
 	}
 	return nil
 }
+
+// entityDefinitionCache keeps the entity definitions that are needed while building change sets for many records.
+type entityDefinitionCache struct {
+	client        *client
+	environmentId string
+	definitions   map[string]*entityDefinitionsDto
+}
+
+func newEntityDefinitionCache(client *client, environmentId string) *entityDefinitionCache {
+	return &entityDefinitionCache{
+		client:        client,
+		environmentId: environmentId,
+		definitions:   map[string]*entityDefinitionsDto{},
+	}
+}
+
+func (cache *entityDefinitionCache) get(ctx context.Context, tableLogicalName string) (*entityDefinitionsDto, error) {
+	if definition, ok := cache.definitions[tableLogicalName]; ok {
+		return definition, nil
+	}
+	definition, err := getEntityDefinition(ctx, cache.client, cache.environmentId, tableLogicalName)
+	if err != nil {
+		return nil, err
+	}
+	cache.definitions[tableLogicalName] = definition
+	return definition, nil
+}
+
+// BuildUpsertChangeSet builds the change set that creates a record, or updates it when recordId is set.
+// Lookup columns are bound with `@odata.bind` and the collection-valued relations in newColumns are associated and
+// disassociated according to their difference to oldColumns, all within the same change set.
+func (client *client) BuildUpsertChangeSet(ctx context.Context, definitions *entityDefinitionCache, environmentHost, tableLogicalName, recordId string, oldColumns, newColumns map[string]any) (*batchChangeSet, error) {
+	tableDefinition, err := definitions.get(ctx, tableLogicalName)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]any{}
+	relations := map[string][]any{}
+	for key, value := range newColumns {
+		switch typedValue := value.(type) {
+		case map[string]any:
+			if len(typedValue) == 0 {
+				continue
+			}
+			lookupTableLogicalName, recordKey, err := getTableLogicalNameAndRecordKeyFromMap(typedValue)
+			if err != nil {
+				return nil, err
+			}
+			lookupDefinition, err := definitions.get(ctx, lookupTableLogicalName)
+			if err != nil {
+				return nil, err
+			}
+			body[fmt.Sprintf("%s@odata.bind", key)] = fmt.Sprintf("/%s(%s)", lookupDefinition.LogicalCollectionName, recordKey)
+		case []any:
+			relations[key] = typedValue
+		default:
+			body[key] = value
+		}
+	}
+
+	collectionUrl := fmt.Sprintf("https://%s/api/data/%s/%s", environmentHost, constants.DATAVERSE_API_VERSION, tableDefinition.LogicalCollectionName)
+
+	if primaryId, ok := body[tableDefinition.PrimaryIDAttribute]; ok && recordId == "" {
+		// Sending the primary id creates the record with that id or updates it when it already exists.
+		recordId = fmt.Sprintf("%v", primaryId)
+	}
+
+	changeSet := batchChangeSet{}
+	recordUrl := ""
+	if recordId == "" {
+		changeSet.Requests = append(changeSet.Requests, batchRequest{Method: "POST", Url: collectionUrl, Body: body})
+	} else {
+		recordUrl = fmt.Sprintf("%s(%s)", collectionUrl, recordId)
+		changeSet.Requests = append(changeSet.Requests, batchRequest{Method: "PATCH", Url: recordUrl, Body: body})
+	}
+
+	for _, key := range sortedKeys(relations) {
+		newRelated, err := getRelatedRecordUrls(ctx, definitions, environmentHost, relations[key])
+		if err != nil {
+			return nil, err
+		}
+		oldRelated := map[string]string{}
+		if oldRelation, ok := oldColumns[key].([]any); ok && recordId != "" {
+			oldRelated, err = getRelatedRecordUrls(ctx, definitions, environmentHost, oldRelation)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, relatedUrl := range sortedKeys(oldRelated) {
+			if _, ok := newRelated[relatedUrl]; ok {
+				continue
+			}
+			changeSet.Requests = append(changeSet.Requests, batchRequest{
+				Method: "DELETE",
+				Url:    fmt.Sprintf("%s/%s(%s)/$ref", recordUrl, key, oldRelated[relatedUrl]),
+			})
+		}
+		for _, relatedUrl := range sortedKeys(newRelated) {
+			if _, ok := oldRelated[relatedUrl]; ok {
+				continue
+			}
+			request := batchRequest{
+				Method: "POST",
+				Url:    fmt.Sprintf("%s/%s/$ref", recordUrl, key),
+				Body:   relationApiBodyDto{OdataID: relatedUrl},
+			}
+			if recordUrl == "" {
+				request.Url = fmt.Sprintf("%s/$ref", key)
+				request.ReferencesFirstRequest = true
+			}
+			changeSet.Requests = append(changeSet.Requests, request)
+		}
+	}
+
+	return &changeSet, nil
+}
+
+// BuildDeleteChangeSet builds the change set that deletes a record.
+func (client *client) BuildDeleteChangeSet(ctx context.Context, definitions *entityDefinitionCache, environmentHost, tableLogicalName, recordId string) (*batchChangeSet, error) {
+	tableDefinition, err := definitions.get(ctx, tableLogicalName)
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangeSet{
+		Requests: []batchRequest{
+			{
+				Method: "DELETE",
+				Url:    fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, tableDefinition.LogicalCollectionName, recordId),
+			},
+		},
+	}, nil
+}
+
+// GetDataRecordsByIds returns the records of a table with the given ids, keyed by id. Records that don't exist are left out.
+func (client *client) GetDataRecordsByIds(ctx context.Context, environmentId, tableLogicalName string, recordIds []string) (map[string]map[string]any, error) {
+	tableDefinition, err := getEntityDefinition(ctx, client, environmentId, tableLogicalName)
+	if err != nil {
+		return nil, err
+	}
+
+	const idsPerQuery = 50
+	records := map[string]map[string]any{}
+	for start := 0; start < len(recordIds); start += idsPerQuery {
+		end := min(start+idsPerQuery, len(recordIds))
+
+		conditions := make([]string, 0, end-start)
+		for _, recordId := range recordIds[start:end] {
+			conditions = append(conditions, fmt.Sprintf("%s eq %s", tableDefinition.PrimaryIDAttribute, recordId))
+		}
+		values := url.Values{}
+		values.Add("$filter", strings.Join(conditions, " or "))
+
+		response, err := client.GetDataRecordsByODataQuery(ctx, environmentId, fmt.Sprintf("%s?%s", tableDefinition.LogicalCollectionName, values.Encode()), nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range response.Records {
+			if recordId, ok := record[tableDefinition.PrimaryIDAttribute].(string); ok {
+				records[strings.ToLower(recordId)] = record
+			}
+		}
+	}
+	return records, nil
+}
+
+// getRelatedRecordUrls returns the urls of the related records, keyed by url, with the record id as value.
+func getRelatedRecordUrls(ctx context.Context, definitions *entityDefinitionCache, environmentHost string, relatedRecords []any) (map[string]string, error) {
+	urls := map[string]string{}
+	for _, item := range relatedRecords {
+		nestedMap, ok := item.(map[string]any)
+		if !ok {
+			return nil, errors.New("nestedItem is not of type map[string]any")
+		}
+		tableLogicalName, dataRecordId, err := resolveRecordReference(ctx, definitions.client, definitions.environmentId, nestedMap)
+		if err != nil {
+			return nil, err
+		}
+		definition, err := definitions.get(ctx, tableLogicalName)
+		if err != nil {
+			return nil, err
+		}
+		urls[fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, definition.LogicalCollectionName, dataRecordId)] = dataRecordId
+	}
+	return urls, nil
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+// maxBatchRequests is the number of requests sent in a single $batch request. Dataverse accepts up to 1000,
+// smaller batches keep the duration of a single request well below the service timeout.
+const maxBatchRequests = 100
+
+// batchRequest is a single operation of a change set.
+type batchRequest struct {
+	Method string
+	// Url is the absolute url of the request. When ReferencesFirstRequest is set, it is a path relative to
+	// the entity created by the first request of the same change set, for example `contact_customer_accounts/$ref`.
+	Url                    string
+	ReferencesFirstRequest bool
+	Body                   any
+}
+
+// batchChangeSet is a group of requests that Dataverse executes atomically.
+type batchChangeSet struct {
+	Requests []batchRequest
+}
+
+// batchChangeSetResult is the outcome of a change set.
+type batchChangeSetResult struct {
+	// StatusCodes and EntityIds hold the status code and the `OData-EntityId` header of every response of the change set.
+	StatusCodes []int
+	EntityIds   []string
+	Err         error
+}
+
+// ExecuteBatch sends the change sets as Dataverse `$batch` requests. Change sets are never split across requests
+// and failing change sets don't stop the remaining ones, so every change set gets its own result.
+func (client *client) ExecuteBatch(ctx context.Context, environmentHost string, changeSets []batchChangeSet) []batchChangeSetResult {
+	results := make([]batchChangeSetResult, 0, len(changeSets))
+
+	for start := 0; start < len(changeSets); {
+		end, requests := start, 0
+		for end < len(changeSets) && (end == start || requests+len(changeSets[end].Requests) <= maxBatchRequests) {
+			requests += len(changeSets[end].Requests)
+			end++
+		}
+
+		chunkResults, err := client.executeBatchChunk(ctx, environmentHost, changeSets[start:end])
+		if err != nil {
+			// The whole batch was rejected, none of the change sets that are left can be sent.
+			for range changeSets[start:] {
+				results = append(results, batchChangeSetResult{Err: err})
+			}
+			return results
+		}
+		results = append(results, chunkResults...)
+		start = end
+	}
+
+	return results
+}
+
+func (client *client) executeBatchChunk(ctx context.Context, environmentHost string, changeSets []batchChangeSet) ([]batchChangeSetResult, error) {
+	body, contentType, err := buildBatchBody(changeSets)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	headers.Set("Accept", "application/json")
+	headers.Set("OData-Version", "4.0")
+	headers.Set("OData-MaxVersion", "4.0")
+	headers.Set("Prefer", "odata.continue-on-error")
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/$batch", constants.DATAVERSE_API_VERSION), nil)
+
+	tflog.Debug(ctx, fmt.Sprintf("Sending batch with %d change sets", len(changeSets)))
+
+	// A retried batch would apply the change sets that already succeeded again, creating duplicate records.
+	resp, err := client.Api.ExecuteWithoutRetry(ctx, nil, "POST", apiUrl, headers, &body, []int{http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute batch request: %w", err)
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	results, err := parseBatchResponse(resp.GetHeader("Content-Type"), resp.BodyAsBytes)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(changeSets) {
+		return nil, fmt.Errorf("batch response contains %d results for %d change sets", len(results), len(changeSets))
+	}
+	return results, nil
+}
+
+// buildBatchBody writes the change sets as a multipart/mixed body.
+// Content-IDs are unique within the batch, so requests can reference the entity created by the first request of their change set.
+func buildBatchBody(changeSets []batchChangeSet) (string, string, error) {
+	var body bytes.Buffer
+	batchWriter := multipart.NewWriter(&body)
+	if err := batchWriter.SetBoundary("batch_" + uuid.NewString()); err != nil {
+		return "", "", err
+	}
+
+	contentId := 0
+	for _, changeSet := range changeSets {
+		var changeSetBody bytes.Buffer
+		changeSetWriter := multipart.NewWriter(&changeSetBody)
+		if err := changeSetWriter.SetBoundary("changeset_" + uuid.NewString()); err != nil {
+			return "", "", err
+		}
+
+		firstContentId := contentId + 1
+		for _, request := range changeSet.Requests {
+			contentId++
+
+			part, err := changeSetWriter.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {"application/http"},
+				"Content-Transfer-Encoding": {"binary"},
+				"Content-Id":                {fmt.Sprintf("%d", contentId)},
+			})
+			if err != nil {
+				return "", "", err
+			}
+
+			requestUrl := request.Url
+			if request.ReferencesFirstRequest {
+				requestUrl = fmt.Sprintf("$%d/%s", firstContentId, strings.TrimPrefix(request.Url, "/"))
+			}
+
+			fmt.Fprintf(part, "%s %s HTTP/1.1\r\n", request.Method, requestUrl)
+			if request.Body == nil {
+				fmt.Fprint(part, "\r\n")
+				continue
+			}
+
+			requestBody, err := json.Marshal(request.Body)
+			if err != nil {
+				return "", "", err
+			}
+			fmt.Fprint(part, "Content-Type: application/json; type=entry\r\n\r\n")
+			if _, err := part.Write(requestBody); err != nil {
+				return "", "", err
+			}
+			fmt.Fprint(part, "\r\n")
+		}
+		if err := changeSetWriter.Close(); err != nil {
+			return "", "", err
+		}
+
+		part, err := batchWriter.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {"multipart/mixed; boundary=" + changeSetWriter.Boundary()},
+		})
+		if err != nil {
+			return "", "", err
+		}
+		if _, err := part.Write(changeSetBody.Bytes()); err != nil {
+			return "", "", err
+		}
+	}
+	if err := batchWriter.Close(); err != nil {
+		return "", "", err
+	}
+
+	return body.String(), "multipart/mixed; boundary=" + batchWriter.Boundary(), nil
+}
+
+// parseBatchResponse reads one result per change set from a multipart/mixed batch response.
+// A successful change set is answered with a nested multipart/mixed part, a failed one with a single error response.
+func parseBatchResponse(contentType string, body []byte) ([]batchChangeSetResult, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return nil, fmt.Errorf("unexpected batch response content type '%s'", contentType)
+	}
+
+	results := []batchChangeSetResult{}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read batch response: %w", err)
+		}
+
+		result := batchChangeSetResult{}
+		partMediaType, partParams, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read batch response: %w", err)
+		}
+
+		if strings.HasPrefix(partMediaType, "multipart/") {
+			changeSetReader := multipart.NewReader(part, partParams["boundary"])
+			for {
+				changeSetPart, err := changeSetReader.NextPart()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					return nil, fmt.Errorf("failed to read batch response: %w", err)
+				}
+				if err := readBatchOperationResponse(changeSetPart, &result); err != nil {
+					return nil, err
+				}
+			}
+		} else if err := readBatchOperationResponse(part, &result); err != nil {
+			return nil, err
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func readBatchOperationResponse(part io.Reader, result *batchChangeSetResult) error {
+	resp, err := http.ReadResponse(bufio.NewReader(part), nil)
+	if err != nil {
+		return fmt.Errorf("failed to read batch operation response: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read batch operation response: %w", err)
+	}
+
+	result.StatusCodes = append(result.StatusCodes, resp.StatusCode)
+	result.EntityIds = append(result.EntityIds, resp.Header.Get(constants.HEADER_ODATA_ENTITY_ID))
+
+	if resp.StatusCode >= http.StatusBadRequest && result.Err == nil {
+		errorResponse := struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}{}
+		if json.Unmarshal(body, &errorResponse) == nil && errorResponse.Error.Message != "" {
+			result.Err = fmt.Errorf("%s: %s", resp.Status, errorResponse.Error.Message)
+		} else {
+			result.Err = fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+const testBatchEnvironmentHost = "00000000-0000-0000-0000-000000000001.crm4.dynamics.com"
+
+func newTestDataRecordClient() client {
+	cfg := &config.ProviderConfig{
+		TestMode: true,
+		Urls:     config.ProviderConfigUrls{BapiUrl: "api.bap.microsoft.com"},
+	}
+	return newDataRecordClient(api.NewApiClientBase(cfg, api.NewAuthBase(cfg)))
+}
+
+func registerBatchEntityDefinitionMocks() {
+	httpmock.RegisterNoResponder(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("no responder found for %s %s", req.Method, req.URL)
+	})
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Create/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Create/get_entitydefinition_contact.json").String()), nil
+		})
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27account%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Create/get_entitydefinition_account.json").String()), nil
+		})
+}
+
+// readBatchRequests returns the raw operations of every change set of a batch request body.
+func readBatchRequests(t *testing.T, contentType string, body []byte) [][]string {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(contentType)
+	require.NoError(t, err)
+
+	changeSets := [][]string{}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		_, partParams, err := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		require.NoError(t, err)
+
+		operations := []string{}
+		changeSetReader := multipart.NewReader(part, partParams["boundary"])
+		for {
+			operation, err := changeSetReader.NextPart()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			content, err := io.ReadAll(operation)
+			require.NoError(t, err)
+			operations = append(operations, fmt.Sprintf("%s|%s", operation.Header.Get("Content-Id"), string(content)))
+		}
+		changeSets = append(changeSets, operations)
+	}
+	return changeSets
+}
+
+func TestUnitBuildUpsertChangeSet_CreateWithRelations(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	client := newTestDataRecordClient()
+	definitions := newEntityDefinitionCache(&client, "00000000-0000-0000-0000-000000000001")
+
+	changeSet, err := client.BuildUpsertChangeSet(context.Background(), definitions, testBatchEnvironmentHost, "account", "", nil, map[string]any{
+		"name":             "Contoso",
+		"primarycontactid": map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000010"},
+		"contact_customer_accounts": []any{
+			map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000011"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, changeSet.Requests, 2)
+	require.Equal(t, "POST", changeSet.Requests[0].Method)
+	require.Equal(t, "https://"+testBatchEnvironmentHost+"/api/data/v9.2/accounts", changeSet.Requests[0].Url)
+	require.Equal(t, map[string]any{"name": "Contoso", "primarycontactid@odata.bind": "/contacts(00000000-0000-0000-0000-000000000010)"}, changeSet.Requests[0].Body)
+	require.True(t, changeSet.Requests[1].ReferencesFirstRequest)
+	require.Equal(t, "contact_customer_accounts/$ref", changeSet.Requests[1].Url)
+
+	body, contentType, err := buildBatchBody([]batchChangeSet{*changeSet, *changeSet})
+	require.NoError(t, err)
+
+	changeSets := readBatchRequests(t, contentType, []byte(body))
+	require.Len(t, changeSets, 2)
+	require.True(t, strings.HasPrefix(changeSets[0][0], "1|POST https://"+testBatchEnvironmentHost+"/api/data/v9.2/accounts HTTP/1.1"))
+	require.True(t, strings.HasPrefix(changeSets[0][1], "2|POST $1/contact_customer_accounts/$ref HTTP/1.1"))
+	require.True(t, strings.HasPrefix(changeSets[1][1], "4|POST $3/contact_customer_accounts/$ref HTTP/1.1"), "content ids must reference the first request of their own change set")
+}
+
+func TestUnitBuildUpsertChangeSet_UpdateDiffsRelations(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	client := newTestDataRecordClient()
+	definitions := newEntityDefinitionCache(&client, "00000000-0000-0000-0000-000000000001")
+
+	changeSet, err := client.BuildUpsertChangeSet(context.Background(), definitions, testBatchEnvironmentHost, "account", "00000000-0000-0000-0000-000000000020",
+		map[string]any{
+			"name": "Contoso",
+			"contact_customer_accounts": []any{
+				map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000011"},
+				map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000012"},
+			},
+		},
+		map[string]any{
+			"name": "Contoso Ltd",
+			"contact_customer_accounts": []any{
+				map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000012"},
+				map[string]any{"table_logical_name": "contact", "data_record_id": "00000000-0000-0000-0000-000000000013"},
+			},
+		})
+	require.NoError(t, err)
+
+	accountUrl := "https://" + testBatchEnvironmentHost + "/api/data/v9.2/accounts(00000000-0000-0000-0000-000000000020)"
+	require.Len(t, changeSet.Requests, 3)
+	require.Equal(t, batchRequest{Method: "PATCH", Url: accountUrl, Body: map[string]any{"name": "Contoso Ltd"}}, changeSet.Requests[0])
+	require.Equal(t, batchRequest{Method: "DELETE", Url: accountUrl + "/contact_customer_accounts(00000000-0000-0000-0000-000000000011)/$ref"}, changeSet.Requests[1])
+	require.Equal(t, "POST", changeSet.Requests[2].Method)
+	require.Equal(t, accountUrl+"/contact_customer_accounts/$ref", changeSet.Requests[2].Url)
+	require.Equal(t, relationApiBodyDto{OdataID: "https://" + testBatchEnvironmentHost + "/api/data/v9.2/contacts(00000000-0000-0000-0000-000000000013)"}, changeSet.Requests[2].Body)
+}
+
+func TestUnitExecuteBatch_ReportsResultPerChangeSet(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	httpmock.RegisterResponder("POST", "https://"+testBatchEnvironmentHost+"/api/data/v9.2/$batch",
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Prefer") != "odata.continue-on-error" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+			body, _ := io.ReadAll(req.Body)
+			if changeSets := readBatchRequests(t, req.Header.Get("Content-Type"), body); len(changeSets) != 2 {
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+
+			responseBody := strings.ReplaceAll(`--batchresponse_1
+Content-Type: multipart/mixed; boundary=changesetresponse_1
+
+--changesetresponse_1
+Content-Type: application/http
+Content-Transfer-Encoding: binary
+Content-ID: 1
+
+HTTP/1.1 204 No Content
+OData-Version: 4.0
+OData-EntityId: https://`+testBatchEnvironmentHost+`/api/data/v9.2/contacts(00000000-0000-0000-0000-000000000010)
+
+
+--changesetresponse_1--
+--batchresponse_1
+Content-Type: application/http
+Content-Transfer-Encoding: binary
+
+HTTP/1.1 400 Bad Request
+Content-Type: application/json; odata.metadata=minimal
+OData-Version: 4.0
+
+{"error":{"code":"0x80048d19","message":"Error identified in Payload provided by the user for Entity :'contacts'"}}
+--batchresponse_1--
+`, "\n", "\r\n")
+
+			resp := httpmock.NewStringResponse(http.StatusOK, responseBody)
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary=batchresponse_1")
+			return resp, nil
+		})
+
+	client := newTestDataRecordClient()
+	definitions := newEntityDefinitionCache(&client, "00000000-0000-0000-0000-000000000001")
+
+	first, err := client.BuildUpsertChangeSet(context.Background(), definitions, testBatchEnvironmentHost, "contact", "", nil, map[string]any{"firstname": "John"})
+	require.NoError(t, err)
+	second, err := client.BuildUpsertChangeSet(context.Background(), definitions, testBatchEnvironmentHost, "contact", "", nil, map[string]any{"firstname": 1})
+	require.NoError(t, err)
+
+	results := client.ExecuteBatch(context.Background(), testBatchEnvironmentHost, []batchChangeSet{*first, *second})
+
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, []int{http.StatusNoContent}, results[0].StatusCodes)
+	recordId, err := getRecordIdFromEntityIdHeader(results[0].EntityIds[0])
+	require.NoError(t, err)
+	require.Equal(t, "00000000-0000-0000-0000-000000000010", recordId)
+	require.ErrorContains(t, results[1].Err, "Error identified in Payload provided by the user for Entity :'contacts'")
+}
+
+func TestUnitExecuteBatch_KeepsChangeSetsTogether(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	batchSizes := []int{}
+	httpmock.RegisterResponder("POST", "https://"+testBatchEnvironmentHost+"/api/data/v9.2/$batch",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			changeSets := readBatchRequests(t, req.Header.Get("Content-Type"), body)
+
+			requests := 0
+			responseBody := ""
+			for _, changeSet := range changeSets {
+				requests += len(changeSet)
+				responseBody += "--batchresponse_1\r\nContent-Type: application/http\r\nContent-Transfer-Encoding: binary\r\n\r\nHTTP/1.1 204 No Content\r\n\r\n\r\n"
+			}
+			responseBody += "--batchresponse_1--\r\n"
+			batchSizes = append(batchSizes, requests)
+
+			resp := httpmock.NewStringResponse(http.StatusOK, responseBody)
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary=batchresponse_1")
+			return resp, nil
+		})
+
+	client := newTestDataRecordClient()
+
+	// 40 change sets of 3 requests each: 33 fit into the first batch, the change set that would exceed the limit starts the next one.
+	changeSets := []batchChangeSet{}
+	for i := 0; i < 40; i++ {
+		changeSets = append(changeSets, batchChangeSet{Requests: []batchRequest{
+			{Method: "DELETE", Url: fmt.Sprintf("https://%s/api/data/v9.2/contacts(%d)", testBatchEnvironmentHost, i)},
+			{Method: "DELETE", Url: fmt.Sprintf("https://%s/api/data/v9.2/accounts(%d)", testBatchEnvironmentHost, i)},
+			{Method: "DELETE", Url: fmt.Sprintf("https://%s/api/data/v9.2/leads(%d)", testBatchEnvironmentHost, i)},
+		}})
+	}
+
+	results := client.ExecuteBatch(context.Background(), testBatchEnvironmentHost, changeSets)
+
+	require.Len(t, results, 40)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+	}
+	require.Equal(t, []int{99, 21}, batchSizes)
+}
+
+func TestUnitExecuteBatch_DoesNotRetryTransientFailure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	// The service may have applied the change sets before answering with a transient status, so sending them again could create duplicates.
+	httpmock.RegisterResponder("POST", "https://"+testBatchEnvironmentHost+"/api/data/v9.2/$batch",
+		httpmock.NewStringResponder(http.StatusServiceUnavailable, ""))
+
+	client := newTestDataRecordClient()
+
+	results := client.ExecuteBatch(context.Background(), testBatchEnvironmentHost, []batchChangeSet{
+		{Requests: []batchRequest{{Method: "POST", Url: "https://" + testBatchEnvironmentHost + "/api/data/v9.2/contacts", Body: map[string]any{"firstname": "John"}}}},
+	})
+
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err)
+	require.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://"+testBatchEnvironmentHost+"/api/data/v9.2/$batch"])
+}
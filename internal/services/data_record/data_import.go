@@ -0,0 +1,214 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
+)
+
+const (
+	importFormatCsv  = "csv"
+	importFormatJson = "json"
+)
+
+// importRow is a row of an import file, with its fields mapped to the columns of the table.
+type importRow struct {
+	// Number is the position of the row in the file, starting at 1 for the first row with data.
+	Number  int
+	Key     string
+	Columns map[string]any
+}
+
+// readImportFile returns the content of an import file together with its SHA-256 hash.
+func readImportFile(file string) ([]byte, string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", err
+	}
+	hash := sha256.Sum256(content)
+	return content, hex.EncodeToString(hash[:]), nil
+}
+
+// importFileFormat returns the configured format of an import file, or derives it from the file extension.
+func importFileFormat(file, format string) (string, error) {
+	if format != "" {
+		return format, nil
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".csv":
+		return importFormatCsv, nil
+	case ".json":
+		return importFormatJson, nil
+	default:
+		return "", fmt.Errorf("the format of '%s' can't be derived from its extension, set `format` to `csv` or `json`", file)
+	}
+}
+
+// readImportRows parses the content of an import file. CSV files start with a header row and all their values are strings,
+// JSON files hold an array of objects. When columnMapping is set, only the mapped fields are imported, under the name of their column.
+// Every row must have a unique value in keyColumn.
+func readImportRows(content []byte, format string, columnMapping map[string]string, keyColumn string) ([]importRow, error) {
+	var records []map[string]any
+	switch format {
+	case importFormatCsv:
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+		lines, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CSV: %w", err)
+		}
+		if len(lines) == 0 {
+			return nil, errors.New("CSV file has no header row")
+		}
+		for _, line := range lines[1:] {
+			record := map[string]any{}
+			for i, field := range lines[0] {
+				record[strings.TrimSpace(field)] = line[i]
+			}
+			records = append(records, record)
+		}
+	case importFormatJson:
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&records); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON, expected an array of objects: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format '%s'", format)
+	}
+
+	rows := make([]importRow, 0, len(records))
+	keys := map[string]int{}
+	for i, record := range records {
+		row := importRow{Number: i + 1, Columns: map[string]any{}}
+		for field, value := range record {
+			column := field
+			if columnMapping != nil {
+				mappedColumn, ok := columnMapping[field]
+				if !ok {
+					continue
+				}
+				column = mappedColumn
+			}
+			if number, ok := value.(json.Number); ok {
+				if intValue, err := number.Int64(); err == nil {
+					value = intValue
+				} else {
+					value, _ = number.Float64()
+				}
+			}
+			row.Columns[column] = value
+		}
+
+		key, ok := row.Columns[keyColumn]
+		if !ok || key == nil || key == "" {
+			return nil, fmt.Errorf("row %d has no value in key column '%s'", row.Number, keyColumn)
+		}
+		row.Key = fmt.Sprintf("%v", key)
+		if previous, ok := keys[row.Key]; ok {
+			return nil, fmt.Errorf("rows %d and %d have the same value '%s' in key column '%s'", previous, row.Number, row.Key, keyColumn)
+		}
+		keys[row.Key] = row.Number
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// convertCsvColumns converts the string values of a CSV row to the types of their columns. Empty values clear the column.
+func convertCsvColumns(columns map[string]any, attributeTypes map[string]string) (map[string]any, error) {
+	converted := make(map[string]any, len(columns))
+	for _, column := range sortedKeys(columns) {
+		value, ok := columns[column].(string)
+		if !ok {
+			converted[column] = columns[column]
+			continue
+		}
+		attributeType, ok := attributeTypes[column]
+		if !ok {
+			return nil, fmt.Errorf("column '%s' doesn't exist in the table", column)
+		}
+		if value == "" {
+			converted[column] = nil
+			continue
+		}
+
+		var err error
+		switch attributeType {
+		case "Integer", "BigInt", "Picklist", "State", "Status":
+			converted[column], err = strconv.ParseInt(value, 10, 64)
+		case "Decimal", "Double", "Money":
+			converted[column], err = strconv.ParseFloat(value, 64)
+		case "Boolean":
+			converted[column], err = strconv.ParseBool(value)
+		default:
+			converted[column] = value
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' of column '%s' is not a valid %s value", value, column, attributeType)
+		}
+	}
+	return converted, nil
+}
+
+// GetEntityAttributeTypes returns the attribute type of every column of a table, by logical name of the column.
+func (client *client) GetEntityAttributeTypes(ctx context.Context, environmentId, entityLogicalName string) (map[string]string, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := dataverse_metadata.GetEntityAttributesDefinition(ctx, client.Api, environmentHost, entityLogicalName, url.Values{"$select": {"LogicalName,AttributeType"}})
+	if err != nil {
+		return nil, err
+	}
+
+	attributeTypes := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		attributeTypes[attribute.LogicalName] = attribute.AttributeType
+	}
+	return attributeTypes, nil
+}
+
+// BuildImportChangeSet builds the change set that upserts a row of an import file. The row is addressed by its id when
+// the key column is the primary id column of the table, and by the alternate key of the key column otherwise.
+func (client *client) BuildImportChangeSet(environmentHost string, tableDefinition *entityDefinitionsDto, keyColumn string, columns map[string]any) (*batchChangeSet, error) {
+	recordKey := fmt.Sprintf("%v", columns[keyColumn])
+	if keyColumn != tableDefinition.PrimaryIDAttribute {
+		var err error
+		recordKey, err = formatAlternateKey(map[string]any{keyColumn: columns[keyColumn]})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body := make(map[string]any, len(columns))
+	for column, value := range columns {
+		if column != keyColumn {
+			body[column] = value
+		}
+	}
+
+	return &batchChangeSet{
+		Requests: []batchRequest{
+			{
+				Method: "PATCH",
+				Url:    fmt.Sprintf("https://%s/api/data/%s/%s(%s)", environmentHost, constants.DATAVERSE_API_VERSION, tableDefinition.LogicalCollectionName, url.PathEscape(recordKey)),
+				Body:   body,
+			},
+		},
+	}, nil
+}
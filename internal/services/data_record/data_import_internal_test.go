@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitReadImportRows_Csv(t *testing.T) {
+	content := []byte("\ufeffCode,Name,Comment\nDE,Germany,skipped\nFR,\"France, Republic\",\n")
+
+	rows, err := readImportRows(content, importFormatCsv, map[string]string{"Code": "cr123_code", "Name": "cr123_name"}, "cr123_code")
+	require.NoError(t, err)
+	require.Equal(t, []importRow{
+		{Number: 1, Key: "DE", Columns: map[string]any{"cr123_code": "DE", "cr123_name": "Germany"}},
+		{Number: 2, Key: "FR", Columns: map[string]any{"cr123_code": "FR", "cr123_name": "France, Republic"}},
+	}, rows)
+}
+
+func TestUnitReadImportRows_Json(t *testing.T) {
+	content := []byte(`[{"cr123_code": 49, "cr123_rate": 1.5, "cr123_active": true}, {"cr123_code": 33, "cr123_rate": 2, "cr123_active": false}]`)
+
+	rows, err := readImportRows(content, importFormatJson, nil, "cr123_code")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, "49", rows[0].Key)
+	require.Equal(t, map[string]any{"cr123_code": int64(49), "cr123_rate": 1.5, "cr123_active": true}, rows[0].Columns)
+	require.Equal(t, int64(2), rows[1].Columns["cr123_rate"])
+}
+
+func TestUnitReadImportRows_Invalid_Keys(t *testing.T) {
+	_, err := readImportRows([]byte("code,name\nDE,Germany\nDE,Deutschland\n"), importFormatCsv, nil, "code")
+	require.ErrorContains(t, err, "rows 1 and 2 have the same value 'DE' in key column 'code'")
+
+	_, err = readImportRows([]byte("code,name\n,Germany\n"), importFormatCsv, nil, "code")
+	require.ErrorContains(t, err, "row 1 has no value in key column 'code'")
+
+	_, err = readImportRows([]byte(`{"code": "DE"}`), importFormatJson, nil, "code")
+	require.ErrorContains(t, err, "expected an array of objects")
+}
+
+func TestUnitImportFileFormat(t *testing.T) {
+	format, err := importFileFormat("data/countries.CSV", "")
+	require.NoError(t, err)
+	require.Equal(t, importFormatCsv, format)
+
+	format, err = importFileFormat("data/countries.txt", importFormatJson)
+	require.NoError(t, err)
+	require.Equal(t, importFormatJson, format)
+
+	_, err = importFileFormat("data/countries.txt", "")
+	require.Error(t, err)
+}
+
+func TestUnitConvertCsvColumns(t *testing.T) {
+	attributeTypes := map[string]string{
+		"cr123_name":     "String",
+		"cr123_count":    "Integer",
+		"cr123_category": "Picklist",
+		"cr123_rate":     "Decimal",
+		"cr123_active":   "Boolean",
+		"cr123_comment":  "Memo",
+	}
+
+	columns, err := convertCsvColumns(map[string]any{
+		"cr123_name":     "Germany",
+		"cr123_count":    "42",
+		"cr123_category": "100000001",
+		"cr123_rate":     "1.25",
+		"cr123_active":   "true",
+		"cr123_comment":  "",
+	}, attributeTypes)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"cr123_name":     "Germany",
+		"cr123_count":    int64(42),
+		"cr123_category": int64(100000001),
+		"cr123_rate":     1.25,
+		"cr123_active":   true,
+		"cr123_comment":  nil,
+	}, columns)
+
+	_, err = convertCsvColumns(map[string]any{"cr123_count": "many"}, attributeTypes)
+	require.ErrorContains(t, err, "value 'many' of column 'cr123_count' is not a valid Integer value")
+
+	_, err = convertCsvColumns(map[string]any{"cr123_unknown": "x"}, attributeTypes)
+	require.ErrorContains(t, err, "column 'cr123_unknown' doesn't exist in the table")
+}
+
+func TestUnitBuildImportChangeSet(t *testing.T) {
+	client := newTestDataRecordClient()
+	tableDefinition := &entityDefinitionsDto{PrimaryIDAttribute: "cr123_countryid", LogicalCollectionName: "cr123_countries"}
+
+	changeSet, err := client.BuildImportChangeSet(testBatchEnvironmentHost, tableDefinition, "cr123_code", map[string]any{"cr123_code": "D'E", "cr123_name": "Germany"})
+	require.NoError(t, err)
+	require.Len(t, changeSet.Requests, 1)
+	require.Equal(t, "PATCH", changeSet.Requests[0].Method)
+	require.Equal(t, "https://"+testBatchEnvironmentHost+"/api/data/v9.2/cr123_countries(cr123_code=%27D%27%27E%27)", changeSet.Requests[0].Url)
+	require.Equal(t, map[string]any{"cr123_name": "Germany"}, changeSet.Requests[0].Body)
+
+	changeSet, err = client.BuildImportChangeSet(testBatchEnvironmentHost, tableDefinition, "cr123_countryid", map[string]any{"cr123_countryid": "00000000-0000-0000-0000-000000000010", "cr123_name": "Germany"})
+	require.NoError(t, err)
+	require.Equal(t, "https://"+testBatchEnvironmentHost+"/api/data/v9.2/cr123_countries(00000000-0000-0000-0000-000000000010)", changeSet.Requests[0].Url)
+
+	changeSet, err = client.BuildImportChangeSet(testBatchEnvironmentHost, tableDefinition, "cr123_number", map[string]any{"cr123_number": int64(49)})
+	require.NoError(t, err)
+	require.Equal(t, "https://"+testBatchEnvironmentHost+"/api/data/v9.2/cr123_countries(cr123_number=49)", changeSet.Requests[0].Url)
+}
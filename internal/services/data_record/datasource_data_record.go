@@ -9,10 +9,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -126,7 +128,8 @@ func (d *DataRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 			"entity_collection": schema.StringAttribute{
 				MarkdownDescription: "Value of the enitiy (collection of the query)[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-data-web-api#entity-collections]. " +
 					"Example:\n\n * $metadata#systemusers \n\n*systemusers \n\n*systemusers(<GUID>) \n\n*systemusers(<GUID>)/systemuserroles_association " +
-					"\n\n*contacts(firstname='Joe',emailaddress1='joe@contoso.com') when using (alternate key(s))[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/use-alternate-key-reference-record?tabs=webapi] for single record retrieval",
+					"\n\n*contacts(firstname='Joe',emailaddress1='joe@contoso.com') when using (alternate key(s))[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/use-alternate-key-reference-record?tabs=webapi] for single record retrieval" +
+					"\n\nWhen `fetch_xml` is used, this is the entity collection of the `entity` of the FetchXML query, for example `accounts`.",
 				Required: true,
 			},
 			"select":   selectListAttributeSchema,
@@ -134,6 +137,13 @@ func (d *DataRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 			"filter":   filterSchema,
 			"order_by": orderbySchema,
 			"top":      topSchema,
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of records Dataverse returns per page, sent as `Prefer: odata.maxpagesize`. All pages are read, so this only changes the number of requests. \n\nMore information on (Page results)[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-data-web-api#page-results]",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 5000),
+				},
+			},
 			"apply": schema.StringAttribute{
 				MarkdownDescription: "Apply the aggregation function to the data records. \n\nMore information on (OData Apply)[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-data-web-api#aggregate-data]",
 				Required:            false,
@@ -150,6 +160,11 @@ func (d *DataRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 				Required:            false,
 				Optional:            true,
 			},
+			"fetch_xml": schema.StringAttribute{
+				MarkdownDescription: "FetchXML query to run against the entity collection instead of an OData query. All pages are read using paging cookies, unless the `fetch` element sets `top` or `page`. Columns of linked entities are returned as an object named after the alias of the `link-entity`, aliased columns such as aggregates are returned by their alias. \n\nMore information on (FetchXML)[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/fetchxml/overview]",
+				Required:            false,
+				Optional:            true,
+			},
 
 			"return_total_rows_count": schema.BoolAttribute{
 				MarkdownDescription: "Should total records count be also retrived. \n\nMore information on (OData Count)[https://learn.microsoft.com/en-us/power-apps/developer/data-platform/webapi/query-data-web-api#count-number-of-rows]",
@@ -173,12 +188,19 @@ func (d *DataRecordDataSource) Schema(ctx context.Context, req datasource.Schema
 }
 
 func (d *DataRecordDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
-	return []datasource.ConfigValidator{
+	validators := []datasource.ConfigValidator{
 		datasourcevalidator.Conflicting(
 			path.MatchRoot("user_query"),
 			path.MatchRoot("saved_query"),
 		),
 	}
+	for _, attribute := range []string{"select", "expand", "filter", "order_by", "top", "page_size", "apply", "saved_query", "user_query"} {
+		validators = append(validators, datasourcevalidator.Conflicting(
+			path.MatchRoot("fetch_xml"),
+			path.MatchRoot(attribute),
+		))
+	}
+	return validators
 }
 
 func (d *DataRecordDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
@@ -217,18 +239,33 @@ func (d *DataRecordDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	query, headers, err := BuildODataQueryFromModel(&config)
-	tflog.Debug(ctx, fmt.Sprintf("Query: %s", query))
-	tflog.Debug(ctx, fmt.Sprintf("Headers: %v", headers))
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to build OData query", err.Error())
-	}
-	tflog.Debug(ctx, fmt.Sprintf("Query: %s", query))
+	var queryRespnse *ODataQueryResponse
+	if !config.FetchXml.IsNull() {
+		var err error
+		queryRespnse, err = d.DataRecordClient.GetDataRecordsByFetchXml(ctx, config.EnvironmentId.ValueString(), config.EntityCollection.ValueString(), config.FetchXml.ValueString(), config.ReturnTotalRowsCount.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get data records", err.Error())
+			return
+		}
+	} else {
+		query, headers, err := BuildODataQueryFromModel(&config)
+		tflog.Debug(ctx, fmt.Sprintf("Query: %s", query))
+		tflog.Debug(ctx, fmt.Sprintf("Headers: %v", headers))
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to build OData query", err.Error())
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Query: %s", query))
 
-	queryRespnse, err := d.DataRecordClient.GetDataRecordsByODataQuery(ctx, config.EnvironmentId.ValueString(), query, headers)
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to get data records", err.Error())
-		return
+		// Stop paging once `top` records are read, in case Dataverse pages the result instead of applying `$top` to it.
+		pagingOptions := &api.PagingOptions{
+			MaxPageSize: int(config.PageSize.ValueInt64()),
+			MaxRecords:  int(config.Top.ValueInt64()),
+		}
+		queryRespnse, err = d.DataRecordClient.GetDataRecordsByODataQuery(ctx, config.EnvironmentId.ValueString(), query, headers, pagingOptions)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to get data records", err.Error())
+			return
+		}
 	}
 
 	if queryRespnse.TotalRecord != nil {
@@ -5,6 +5,8 @@ package data_record_test
 
 import (
 	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -918,3 +920,156 @@ func TestUnitDataRecordDatasource_Validate_Expand_Lookup(t *testing.T) {
 		t.Errorf("Odata query should have been run in '%s' unit test", mocks.TestName())
 	}
 }
+
+func TestAccDataRecordDatasource_Validate_FetchXml(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: BootstrapDataRecordTest(mocks.TestName()) +
+					`
+					data "powerplatform_data_records" "data_query" {
+						environment_id    = powerplatform_environment.data_env.id
+						entity_collection = "accounts"
+						fetch_xml         = <<-EOT
+							<fetch count="2">
+								<entity name="account">
+									<attribute name="name" />
+									<link-entity name="contact" from="contactid" to="primarycontactid" alias="pc" link-type="outer">
+										<attribute name="firstname" />
+									</link-entity>
+								</entity>
+							</fetch>
+						EOT
+
+						depends_on = [
+						  powerplatform_data_record.contact1,
+						  powerplatform_data_record.contact2,
+						  powerplatform_data_record.contact3,
+						  powerplatform_data_record.contact4,
+						  powerplatform_data_record.contact5,
+						  powerplatform_data_record.account1,
+						]
+					  }
+					`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_records.data_query", "rows.#", "1"),
+					resource.TestCheckResourceAttrSet("data.powerplatform_data_records.data_query", "rows.0.name"),
+					resource.TestCheckResourceAttrSet("data.powerplatform_data_records.data_query", "rows.0.pc.firstname"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordDatasource_Validate_FetchXml(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/accounts\?fetchXml=.*page%3D%221%22`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_FetchXml/get_accounts_page_1.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/accounts\?fetchXml=.*page%3D%222%22`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_FetchXml/get_accounts_page_2.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "powerplatform_data_records" "data_query" {
+						environment_id          = "00000000-0000-0000-0000-000000000001"
+						entity_collection       = "accounts"
+						return_total_rows_count = true
+						fetch_xml               = <<-EOT
+							<fetch count="2">
+								<entity name="account">
+									<attribute name="name" />
+									<link-entity name="contact" from="contactid" to="primarycontactid" alias="pc" link-type="outer">
+										<attribute name="firstname" />
+									</link-entity>
+								</entity>
+							</fetch>
+						EOT
+					  }`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("data.powerplatform_data_records.data_query", tfjsonpath.New("total_rows_count"), knownvalue.Int64Exact(3)),
+					statecheck.ExpectKnownValue("data.powerplatform_data_records.data_query", tfjsonpath.New("rows").AtSliceIndex(0).AtMapKey("pc").AtMapKey("firstname"), knownvalue.StringExact("John")),
+					statecheck.ExpectKnownValue("data.powerplatform_data_records.data_query", tfjsonpath.New("rows").AtSliceIndex(2).AtMapKey("name"), knownvalue.StringExact("Fabrikam")),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_records.data_query", "rows.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordDatasource_Validate_Page_Size(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	pagesRead := 0
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/contacts\?`),
+		func(req *http.Request) (*http.Response, error) {
+			if !strings.Contains(req.Header.Get("Prefer"), "odata.maxpagesize=2") {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "page size must be sent as Prefer: odata.maxpagesize"), nil
+			}
+			pagesRead++
+			if req.URL.Query().Get("page") == "" {
+				return httpmock.NewStringResponse(http.StatusOK, `{
+					"@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#contacts(fullname)",
+					"value":[{"contactid":"00000000-0000-0000-0000-000000000011","fullname":"One"},{"contactid":"00000000-0000-0000-0000-000000000012","fullname":"Two"}],
+					"@odata.nextLink":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/contacts?$select=fullname&page=2"
+				}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{
+				"@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#contacts(fullname)",
+				"value":[{"contactid":"00000000-0000-0000-0000-000000000013","fullname":"Three"},{"contactid":"00000000-0000-0000-0000-000000000014","fullname":"Four"}],
+				"@odata.nextLink":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/contacts?$select=fullname&page=3"
+			}`), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions?%24filter=LogicalCollectionName+eq+%27contacts%27&%24select=PrimaryIdAttribute%2CLogicalCollectionName%2CLogicalName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/get_entitydefinition_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "powerplatform_data_records" "data_query" {
+						environment_id    = "00000000-0000-0000-0000-000000000001"
+						entity_collection = "contacts"
+						select            = ["fullname"]
+						top               = 3
+						page_size         = 2
+					  }`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_records.data_query", "rows.#", "3"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_records.data_query", "rows.2.fullname", "Three"),
+				),
+			},
+		},
+	})
+
+	if pagesRead != 2 {
+		t.Errorf("expected paging to stop after 2 pages once top records were read, read %d pages", pagesRead)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+)
+
+const fetchXmlPreferHeader = `odata.include-annotations="Microsoft.Dynamics.CRM.fetchxmlpagingcookie,Microsoft.Dynamics.CRM.morerecords,Microsoft.Dynamics.CRM.totalrecordcount,Microsoft.Dynamics.CRM.totalrecordcountlimitexceeded"`
+
+var (
+	fetchElementRegex         = regexp.MustCompile(`<fetch\b[^>]*>`)
+	fetchPagingAttributeRegex = regexp.MustCompile(`\s(?:page|paging-cookie|returntotalrecordcount)\s*=\s*(?:"[^"]*"|'[^']*')`)
+	fetchSinglePageRegex      = regexp.MustCompile(`\s(?:top|page)\s*=\s*(?:"[^"]*"|'[^']*')`)
+)
+
+// fetchXmlPagingCookieDto is the value of the `@Microsoft.Dynamics.CRM.fetchxmlpagingcookie` annotation.
+type fetchXmlPagingCookieDto struct {
+	PageNumber   int    `xml:"pagenumber,attr"`
+	PagingCookie string `xml:"pagingcookie,attr"`
+}
+
+// GetDataRecordsByFetchXml runs a FetchXML query against an entity collection and reads all of its pages.
+// Queries that set `top` or `page` on the fetch element are read as a single page, as they ask for a specific set of records.
+func (client *client) GetDataRecordsByFetchXml(ctx context.Context, environmentId, entityCollection, fetchXml string, returnTotalRowsCount bool) (*ODataQueryResponse, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchElement := fetchElementRegex.FindString(fetchXml)
+	if fetchElement == "" {
+		return nil, errors.New("fetch_xml must contain a <fetch> element")
+	}
+	singlePage := fetchSinglePageRegex.MatchString(fetchElement)
+
+	result := &ODataQueryResponse{
+		Records:         []map[string]any{},
+		TablePluralName: entityCollection,
+	}
+
+	page, pagingCookie := 1, ""
+	for {
+		query := fetchXml
+		if !singlePage {
+			query, err = setFetchXmlPaging(fetchXml, page, pagingCookie, returnTotalRowsCount && page == 1)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		values := url.Values{}
+		values.Add("fetchXml", query)
+		apiUrl := fmt.Sprintf("https://%s/api/data/%s/%s?%s", environmentHost, constants.DATAVERSE_API_VERSION, entityCollection, values.Encode())
+
+		headers := http.Header{}
+		headers.Set("Prefer", fetchXmlPreferHeader)
+
+		response := map[string]any{}
+		resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, headers, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute FetchXML query: %w", err)
+		}
+		if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+			return nil, err
+		}
+		if err := client.Api.HandleNotFoundResponse(resp); err != nil {
+			return nil, err
+		}
+
+		if page == 1 {
+			if odataCtx, ok := response["@odata.context"].(string); ok {
+				result.TableMetadataUrl = odataCtx
+			}
+			if count, ok := response["@Microsoft.Dynamics.CRM.totalrecordcount"].(float64); ok && count >= 0 {
+				totalRecords := int64(count)
+				result.TotalRecord = &totalRecords
+			}
+			if limitExceeded, ok := response["@Microsoft.Dynamics.CRM.totalrecordcountlimitexceeded"].(bool); ok {
+				result.TotalRecordLimitExceeded = &limitExceeded
+			}
+		}
+
+		records, ok := response["value"].([]any)
+		if !ok {
+			return nil, errors.New("value field is not of type []any")
+		}
+		for _, rawRecord := range records {
+			record, ok := rawRecord.(map[string]any)
+			if !ok {
+				return nil, errors.New("item is not of type map[string]any")
+			}
+			result.Records = append(result.Records, mapFetchXmlRecord(record))
+		}
+
+		moreRecords, _ := response["@Microsoft.Dynamics.CRM.morerecords"].(bool)
+		if singlePage || !moreRecords {
+			break
+		}
+
+		cookie, _ := response["@Microsoft.Dynamics.CRM.fetchxmlpagingcookie"].(string)
+		nextPage, nextPagingCookie, err := parseFetchXmlPagingCookie(cookie)
+		if err != nil {
+			return nil, err
+		}
+		if nextPage <= page {
+			nextPage = page + 1
+		}
+		page, pagingCookie = nextPage, nextPagingCookie
+		tflog.Debug(ctx, fmt.Sprintf("Reading page %d of FetchXML query", page))
+	}
+
+	return result, nil
+}
+
+// setFetchXmlPaging sets the page, the paging cookie of the previous page and the total count request on the fetch element.
+func setFetchXmlPaging(fetchXml string, page int, pagingCookie string, returnTotalRowsCount bool) (string, error) {
+	location := fetchElementRegex.FindStringIndex(fetchXml)
+	if location == nil {
+		return "", errors.New("fetch_xml must contain a <fetch> element")
+	}
+
+	attributes := fmt.Sprintf(` page="%d"`, page)
+	if pagingCookie != "" {
+		var escapedCookie bytes.Buffer
+		if err := xml.EscapeText(&escapedCookie, []byte(pagingCookie)); err != nil {
+			return "", err
+		}
+		attributes += fmt.Sprintf(` paging-cookie="%s"`, escapedCookie.String())
+	}
+	if returnTotalRowsCount {
+		attributes += ` returntotalrecordcount="true"`
+	}
+
+	fetchElement := fetchPagingAttributeRegex.ReplaceAllString(fetchXml[location[0]:location[1]], "")
+	fetchElement = "<fetch" + attributes + strings.TrimPrefix(fetchElement, "<fetch")
+
+	return fetchXml[:location[0]] + fetchElement + fetchXml[location[1]:], nil
+}
+
+// parseFetchXmlPagingCookie returns the number of the next page and its paging cookie from the paging cookie annotation,
+// for example `<cookie pagenumber="2" pagingcookie="%253ccookie%2520page%253d%25221%2522%253e...%253c%252fcookie%253e" istracking="False" />`.
+// The paging cookie inside the annotation is url encoded twice.
+func parseFetchXmlPagingCookie(cookie string) (int, string, error) {
+	if cookie == "" {
+		return 0, "", nil
+	}
+
+	dto := fetchXmlPagingCookieDto{}
+	if err := xml.Unmarshal([]byte(cookie), &dto); err != nil {
+		return 0, "", fmt.Errorf("failed to read FetchXML paging cookie: %w", err)
+	}
+
+	pagingCookie := dto.PagingCookie
+	for range 2 {
+		decoded, err := url.QueryUnescape(pagingCookie)
+		if err != nil {
+			return 0, "", fmt.Errorf("failed to decode FetchXML paging cookie: %w", err)
+		}
+		pagingCookie = decoded
+	}
+	return dto.PageNumber, pagingCookie, nil
+}
+
+// mapFetchXmlRecord nests the columns of linked entities, that FetchXML returns as `<alias>.<column>`, under their alias.
+// Aliased columns of the root entity, for example aggregates, are kept as they are.
+func mapFetchXmlRecord(record map[string]any) map[string]any {
+	result := make(map[string]any, len(record))
+	linked := map[string]map[string]any{}
+
+	for key, value := range record {
+		alias, column, isLinked := strings.Cut(key, ".")
+		if !isLinked || strings.Contains(alias, "@") || alias == "" || column == "" {
+			result[key] = value
+			continue
+		}
+		if linked[alias] == nil {
+			linked[alias] = map[string]any{}
+		}
+		linked[alias][column] = value
+	}
+
+	for alias, columns := range linked {
+		if _, exists := result[alias]; exists {
+			// a column of the root entity already uses the alias as name, keep the linked columns flat.
+			for column, value := range columns {
+				result[alias+"."+column] = value
+			}
+			continue
+		}
+		result[alias] = columns
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSetFetchXmlPaging(t *testing.T) {
+	fetchXml := `<fetch page='3' count="2"><entity name="account"><attribute name="name" /></entity></fetch>`
+
+	query, err := setFetchXmlPaging(fetchXml, 2, `<cookie page="1"><accountid last="{A}" first="{B}" /></cookie>`, true)
+
+	require.NoError(t, err)
+	require.Equal(t, `<fetch page="2" paging-cookie="&lt;cookie page=&#34;1&#34;&gt;&lt;accountid last=&#34;{A}&#34; first=&#34;{B}&#34; /&gt;&lt;/cookie&gt;" returntotalrecordcount="true" count="2"><entity name="account"><attribute name="name" /></entity></fetch>`, query)
+
+	_, err = setFetchXmlPaging(`<entity name="account" />`, 1, "", false)
+	require.Error(t, err)
+}
+
+func TestUnitParseFetchXmlPagingCookie(t *testing.T) {
+	page, cookie, err := parseFetchXmlPagingCookie(`<cookie pagenumber="2" pagingcookie="%253ccookie%2520page%253d%25221%2522%253e%253caccountid%2520last%253d%2522%257b7062B974-7F8E-E711-8108-000D3A10B5F5%257d%2522%2520first%253d%2522%257bAB6C4F36-7F8E-E711-8108-000D3A10B5F5%257d%2522%2520%252f%253e%253c%252fcookie%253e" istracking="False" />`)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, page)
+	require.Equal(t, `<cookie page="1"><accountid last="{7062B974-7F8E-E711-8108-000D3A10B5F5}" first="{AB6C4F36-7F8E-E711-8108-000D3A10B5F5}" /></cookie>`, cookie)
+}
+
+func TestUnitMapFetchXmlRecord(t *testing.T) {
+	record := mapFetchXmlRecord(map[string]any{
+		"@odata.etag":  `W/"1"`,
+		"name":         "Contoso",
+		"total":        float64(42),
+		"pc.fullname":  "John Doe",
+		"pc.contactid": "00000000-0000-0000-0000-000000000010",
+		"name.suffix":  "Ltd",
+	})
+
+	require.Equal(t, map[string]any{
+		"@odata.etag": `W/"1"`,
+		"name":        "Contoso",
+		"total":       float64(42),
+		"pc": map[string]any{
+			"fullname":  "John Doe",
+			"contactid": "00000000-0000-0000-0000-000000000010",
+		},
+		"name.suffix": "Ltd",
+	}, record)
+}
+
+func TestUnitGetDataRecordsByFetchXml_FollowsPagingCookies(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	pages := []string{}
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/accounts\?fetchXml=`,
+		func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Prefer") != fetchXmlPreferHeader {
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+			query, err := url.ParseQuery(req.URL.RawQuery)
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, query.Get("fetchXml"))
+
+			if len(pages) == 1 {
+				return httpmock.NewStringResponse(http.StatusOK, `{
+					"@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#accounts",
+					"@Microsoft.Dynamics.CRM.totalrecordcount":3,
+					"@Microsoft.Dynamics.CRM.totalrecordcountlimitexceeded":false,
+					"@Microsoft.Dynamics.CRM.morerecords":true,
+					"@Microsoft.Dynamics.CRM.fetchxmlpagingcookie":"<cookie pagenumber=\"2\" pagingcookie=\"%253ccookie%2520page%253d%25221%2522%253e%253c%252fcookie%253e\" istracking=\"False\" />",
+					"value":[{"name":"A","pc.fullname":"John"},{"name":"B","pc.fullname":"Jane"}]}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{
+				"@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#accounts",
+				"@Microsoft.Dynamics.CRM.morerecords":false,
+				"value":[{"name":"C"}]}`), nil
+		})
+
+	client := newTestDataRecordClient()
+	response, err := client.GetDataRecordsByFetchXml(context.Background(), "00000000-0000-0000-0000-000000000001", "accounts",
+		`<fetch count="2"><entity name="account"><attribute name="name" /><link-entity name="contact" from="contactid" to="primarycontactid" alias="pc" link-type="outer"><attribute name="fullname" /></link-entity></entity></fetch>`, true)
+
+	require.NoError(t, err)
+	require.Len(t, pages, 2)
+	require.Contains(t, pages[0], `<fetch page="1" returntotalrecordcount="true" count="2">`)
+	require.Contains(t, pages[1], `<fetch page="2" paging-cookie="&lt;cookie page=&#34;1&#34;&gt;&lt;/cookie&gt;" count="2">`)
+	require.Len(t, response.Records, 3)
+	require.Equal(t, map[string]any{"name": "A", "pc": map[string]any{"fullname": "John"}}, response.Records[0])
+	require.Equal(t, int64(3), *response.TotalRecord)
+	require.False(t, *response.TotalRecordLimitExceeded)
+}
+
+func TestUnitGetDataRecordsByFetchXml_ReadsSinglePageWithTop(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerBatchEntityDefinitionMocks()
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/accounts\?fetchXml=`,
+		httpmock.NewStringResponder(http.StatusOK, `{"@Microsoft.Dynamics.CRM.morerecords":true,"value":[{"name":"A"}]}`))
+
+	fetchXml := `<fetch top="1"><entity name="account"><attribute name="name" /></entity></fetch>`
+	client := newTestDataRecordClient()
+	response, err := client.GetDataRecordsByFetchXml(context.Background(), "00000000-0000-0000-0000-000000000001", "accounts", fetchXml, false)
+
+	require.NoError(t, err)
+	require.Len(t, response.Records, 1)
+	require.Equal(t, 1, httpmock.GetTotalCallCount()-httpmock.GetCallCountInfo()["GET https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01"])
+}
@@ -32,11 +32,13 @@ type DataRecordListDataSourceModel struct {
 	Apply                       types.String   `tfsdk:"apply"`
 	OrderBy                     types.String   `tfsdk:"order_by"`
 	Top                         types.Int64    `tfsdk:"top"`
+	PageSize                    types.Int64    `tfsdk:"page_size"`
 	ReturnTotalRowsCount        types.Bool     `tfsdk:"return_total_rows_count"`
 	TotalRowsCount              types.Int64    `tfsdk:"total_rows_count"`
 	TotalRowsCountLimitExceeded types.Bool     `tfsdk:"total_rows_count_limit_exceeded"`
 	SavedQuery                  types.String   `tfsdk:"saved_query"`
 	UserQuery                   types.String   `tfsdk:"user_query"`
+	FetchXml                    types.String   `tfsdk:"fetch_xml"`
 	Expand                      []ExpandModel  `tfsdk:"expand"`
 	Rows                        types.Dynamic  `tfsdk:"rows"`
 }
@@ -54,4 +56,40 @@ type DataRecordResourceModel struct {
 	EnvironmentId    types.String   `tfsdk:"environment_id"`
 	TableLogicalName types.String   `tfsdk:"table_logical_name"`
 	Columns          types.Dynamic  `tfsdk:"columns"`
+	AlternateKey     types.Dynamic  `tfsdk:"alternate_key"`
+
+	SensitiveColumns        types.Dynamic `tfsdk:"sensitive_columns"`
+	SensitiveColumnsVersion types.String  `tfsdk:"sensitive_columns_version"`
+}
+
+type DataRecordsResource struct {
+	helpers.TypeInfo
+	DataRecordClient client
+}
+
+type DataRecordsResourceModel struct {
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	Id               types.String   `tfsdk:"id"`
+	EnvironmentId    types.String   `tfsdk:"environment_id"`
+	TableLogicalName types.String   `tfsdk:"table_logical_name"`
+	Records          types.Dynamic  `tfsdk:"records"`
+	RecordIds        types.Map      `tfsdk:"record_ids"`
+}
+
+type DataRecordImportResource struct {
+	helpers.TypeInfo
+	DataRecordClient client
+}
+
+type DataRecordImportResourceModel struct {
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
+	Id               types.String   `tfsdk:"id"`
+	EnvironmentId    types.String   `tfsdk:"environment_id"`
+	TableLogicalName types.String   `tfsdk:"table_logical_name"`
+	File             types.String   `tfsdk:"file"`
+	Format           types.String   `tfsdk:"format"`
+	ColumnMapping    types.Map      `tfsdk:"column_mapping"`
+	KeyColumn        types.String   `tfsdk:"key_column"`
+	ContentHash      types.String   `tfsdk:"content_hash"`
+	RowIds           types.Map      `tfsdk:"row_ids"`
 }
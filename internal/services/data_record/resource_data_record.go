@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,8 +19,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -103,13 +106,59 @@ func (r *DataRecordResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"columns": schema.DynamicAttribute{
-				MarkdownDescription: "Columns of the data record table",
+				MarkdownDescription: "Columns of the data record table. Lookup columns and relations reference records with `table_logical_name` and either `data_record_id` or `alternate_key`, an object of the key columns of an alternate key of the referenced table.",
 				Required:            true,
 			},
+			"alternate_key": schema.DynamicAttribute{
+				MarkdownDescription: "Columns of an alternate key of the table as an object, for example `{ accountnumber = \"ACC-001\" }`. When set, the record is created with an upsert by this key, so the record that already has the key is updated instead of creating a duplicate. This lets the same configuration target matching records in different environments without knowing their ids.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.RequiresReplace(),
+				},
+			},
+			"sensitive_columns": schema.DynamicAttribute{
+				MarkdownDescription: "Columns that hold secrets, such as API keys or passwords, as an object of string, number or bool values, for example `{ cr123_apikey = var.api_key }`. The values are sent when the record is created or updated but are never stored in state and never read back, so changes to them are not detected. Bump `sensitive_columns_version` to send changed values. Requires Terraform 1.11 or later.",
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"sensitive_columns_version": schema.StringAttribute{
+				MarkdownDescription: "Version of the values in `sensitive_columns`. Changing it, for example by bumping a counter or by supplying a hash of the secrets, updates the record with the current values of `sensitive_columns`.",
+				Optional:            true,
+			},
 		},
 	}
 }
 
+func (r *DataRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var columns, sensitiveColumns types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("columns"), &columns)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("sensitive_columns"), &sensitiveColumns)...)
+	if resp.Diagnostics.HasError() || sensitiveColumns.IsNull() || sensitiveColumns.IsUnknown() {
+		return
+	}
+
+	sensitiveAttributes, ok := dynamicObjectAttributes(sensitiveColumns)
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("sensitive_columns"), "Invalid sensitive columns", "`sensitive_columns` must be an object of column values, for example `{ cr123_apikey = var.api_key }`.")
+		return
+	}
+	columnAttributes, _ := dynamicObjectAttributes(columns)
+	for _, name := range sortedKeys(sensitiveAttributes) {
+		if _, ok := columnAttributes[name]; ok {
+			resp.Diagnostics.AddAttributeError(path.Root("sensitive_columns"), "Duplicate column", fmt.Sprintf("Column '%s' is set in both `columns` and `sensitive_columns`.", name))
+		}
+		switch sensitiveAttributes[name].(type) {
+		case basetypes.StringValue, basetypes.NumberValue, basetypes.Int64Value, basetypes.Float64Value, basetypes.BoolValue:
+		default:
+			resp.Diagnostics.AddAttributeError(path.Root("sensitive_columns"), "Unsupported sensitive column", fmt.Sprintf("Column '%s' must be a string, number or bool. Lookups and relations can only be set in `columns`.", name))
+		}
+	}
+}
+
 func (r *DataRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
 	defer exitContext()
@@ -154,7 +203,20 @@ func (r *DataRecordResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	dr, err := r.DataRecordClient.ApplyDataRecord(ctx, plan.Id.ValueString(), plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), mapColumns)
+	alternateKey := plan.AlternateKey.String()
+	mapAlternateKey, err := convertResourceModelToMap(&alternateKey)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting alternate key to map: %s", err.Error()), err.Error())
+		return
+	}
+
+	mapColumns, err = withSensitiveColumns(ctx, req.Config, mapColumns)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting sensitive columns to map: %s", err.Error()), err.Error())
+		return
+	}
+
+	dr, err := r.DataRecordClient.ApplyDataRecord(ctx, plan.Id.ValueString(), plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), mapAlternateKey, mapColumns)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
 		return
@@ -235,7 +297,13 @@ func (r *DataRecordResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	dr, err := r.DataRecordClient.ApplyDataRecord(ctx, state.Id.ValueString(), plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), mapColumns)
+	mapColumns, err = withSensitiveColumns(ctx, req.Config, mapColumns)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting sensitive columns to map: %s", err.Error()), err.Error())
+		return
+	}
+
+	dr, err := r.DataRecordClient.ApplyDataRecord(ctx, state.Id.ValueString(), plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), nil, mapColumns)
 	if err != nil {
 		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
 		return
@@ -290,7 +358,7 @@ func (r *DataRecordResource) Delete(ctx context.Context, req resource.DeleteRequ
 		}
 
 		if len(attributes) > 0 {
-			_, err = r.DataRecordClient.ApplyDataRecord(ctx, state.Id.ValueString(), state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), attributes)
+			_, err = r.DataRecordClient.ApplyDataRecord(ctx, state.Id.ValueString(), state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), nil, attributes)
 			if err != nil {
 				resp.Diagnostics.AddError(fmt.Sprintf("Client error when disabling %s", r.FullTypeName()), err.Error())
 				return
@@ -353,6 +421,41 @@ func convertResourceModelToMap(columnsAsString *string) (mapColumns map[string]a
 	return mapColumns, nil
 }
 
+// withSensitiveColumns adds the write-only sensitive columns of the configuration to the columns that are sent to Dataverse.
+// Write-only values are only available in the configuration, never in the plan or state.
+func withSensitiveColumns(ctx context.Context, config tfsdk.Config, mapColumns map[string]any) (map[string]any, error) {
+	var sensitiveColumns types.Dynamic
+	if diags := config.GetAttribute(ctx, path.Root("sensitive_columns"), &sensitiveColumns); diags.HasError() {
+		return nil, fmt.Errorf("failed to read sensitive columns: %v", diags)
+	}
+	if sensitiveColumns.IsNull() || sensitiveColumns.IsUnknown() || sensitiveColumns.IsUnderlyingValueNull() {
+		return mapColumns, nil
+	}
+
+	sensitiveColumnsAsString := sensitiveColumns.String()
+	mapSensitiveColumns, err := convertResourceModelToMap(&sensitiveColumnsAsString)
+	if err != nil {
+		return nil, err
+	}
+	if mapColumns == nil {
+		mapColumns = map[string]any{}
+	}
+	maps.Copy(mapColumns, mapSensitiveColumns)
+	return mapColumns, nil
+}
+
+// dynamicObjectAttributes returns the attributes of a dynamic value that holds an object or a map.
+func dynamicObjectAttributes(value types.Dynamic) (map[string]attr.Value, bool) {
+	switch underlyingValue := value.UnderlyingValue().(type) {
+	case basetypes.ObjectValue:
+		return underlyingValue.Attributes(), true
+	case basetypes.MapValue:
+		return underlyingValue.Elements(), true
+	default:
+		return nil, false
+	}
+}
+
 func caseBool(ctx context.Context, columnValue any, attrValue map[string]attr.Value, attrType map[string]attr.Type, key string) {
 	value, ok := columnValue.(bool)
 	if !ok {
@@ -415,7 +518,7 @@ func caseMapStringOfAny(ctx context.Context, columnValue any, attrValue map[stri
 }
 
 func caseArrayOfAny(ctx context.Context, attrValue map[string]attr.Value, attrType map[string]attr.Type,
-	apiClient *client, objectType map[string]attr.Type, key, environmentId, tableLogicalName, recordid string) error {
+	apiClient *client, objectType map[string]attr.Type, key, environmentId, tableLogicalName, recordid string, stateItems []any) error {
 	var listValues []attr.Value
 	tupleElementType := types.ObjectType{
 		AttrTypes: objectType,
@@ -426,6 +529,15 @@ func caseArrayOfAny(ctx context.Context, attrValue map[string]attr.Value, attrTy
 		return errors.New("error getting relation data: " + err.Error())
 	}
 
+	// Relations that are configured by alternate key keep their configured value as long as they still point to the same records.
+	if stateRelation, ok, err := alternateKeyRelationToState(ctx, apiClient, environmentId, tableLogicalName, key, relationMap, stateItems); err != nil {
+		return err
+	} else if ok {
+		attrValue[key] = stateRelation
+		attrType[key] = stateRelation.Type(ctx)
+		return nil
+	}
+
 	for _, rawItem := range relationMap {
 		item, ok := rawItem.(map[string]any)
 		if !ok {
@@ -463,16 +575,27 @@ func caseArrayOfAny(ctx context.Context, attrValue map[string]attr.Value, attrTy
 }
 
 func (r *DataRecordResource) convertColumnsToState(ctx context.Context, apiClient *client, environmentId, tableLogicalName string, recordid, recordColumns *string, columns map[string]any) (*basetypes.DynamicValue, error) {
-	var objectType = map[string]attr.Type{
-		"table_logical_name": types.StringType,
-		"data_record_id":     types.StringType,
-	}
-
 	mapColumns, err := convertResourceModelToMap(recordColumns)
 	if err != nil {
 		return nil, errors.New("error converting columns to map: " + err.Error())
 	}
 
+	columnField, err := convertColumnMapToState(ctx, apiClient, environmentId, tableLogicalName, *recordid, mapColumns, columns, map[string]string{})
+	if err != nil {
+		return nil, err
+	}
+	result := types.DynamicValue(columnField)
+	return &result, nil
+}
+
+// convertColumnMapToState maps the columns of a record returned by the API onto the columns known in state.
+// lookupTables caches the tables of lookup columns by column name, so it can be shared between records of one table.
+func convertColumnMapToState(ctx context.Context, apiClient *client, environmentId, tableLogicalName, recordid string, mapColumns map[string]any, columns map[string]any, lookupTables map[string]string) (types.Object, error) {
+	var objectType = map[string]attr.Type{
+		"table_logical_name": types.StringType,
+		"data_record_id":     types.StringType,
+	}
+
 	attributeTypes := make(map[string]attr.Type)
 	attributes := make(map[string]attr.Value)
 
@@ -487,22 +610,39 @@ func (r *DataRecordResource) convertColumnsToState(ctx context.Context, apiClien
 		case string:
 			caseString(ctx, columns[key], attributes, attributeTypes, key)
 		case map[string]any:
-			entityLogicalName, err := apiClient.GetEntityRelationDefinitionInfo(ctx, environmentId, tableLogicalName, key)
-			if err != nil {
-				return nil, errors.New("error getting entity relation definition info: " + err.Error())
+			if isAlternateKeyReference(value) {
+				// Lookups that are configured by alternate key keep their configured value as long as they still point to the same record.
+				stateLookup, ok, err := alternateKeyLookupToState(ctx, apiClient, environmentId, value, columns[fmt.Sprintf("_%s_value", key)])
+				if err != nil {
+					return types.Object{}, err
+				}
+				if ok {
+					attributes[key] = stateLookup
+					attributeTypes[key] = stateLookup.Type(ctx)
+					continue
+				}
+			}
+			entityLogicalName, ok := lookupTables[key]
+			if !ok {
+				var err error
+				entityLogicalName, err = apiClient.GetEntityRelationDefinitionInfo(ctx, environmentId, tableLogicalName, key)
+				if err != nil {
+					return types.Object{}, errors.New("error getting entity relation definition info: " + err.Error())
+				}
+				lookupTables[key] = entityLogicalName
 			}
 			caseMapStringOfAny(ctx, columns[fmt.Sprintf("_%s_value", key)], attributes, attributeTypes, key, entityLogicalName, objectType)
 		case []any:
-			err := caseArrayOfAny(ctx, attributes, attributeTypes, apiClient, objectType, key, environmentId, tableLogicalName, *recordid)
+			stateItems, _ := value.([]any)
+			err := caseArrayOfAny(ctx, attributes, attributeTypes, apiClient, objectType, key, environmentId, tableLogicalName, recordid, stateItems)
 			if err != nil {
-				return nil, err
+				return types.Object{}, err
 			}
 		}
 	}
 	columnField, diags := types.ObjectValue(attributeTypes, attributes)
 	if diags.HasError() {
-		return nil, fmt.Errorf("failed to create object value: %v", diags)
+		return types.Object{}, fmt.Errorf("failed to create object value: %v", diags)
 	}
-	result := types.DynamicValue(columnField)
-	return &result, nil
+	return columnField, nil
 }
@@ -0,0 +1,516 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.ResourceWithModifyPlan = &DataRecordImportResource{}
+var _ resource.ResourceWithValidateConfig = &DataRecordImportResource{}
+
+func NewDataRecordImportResource() resource.Resource {
+	return &DataRecordImportResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "data_record_import",
+		},
+	}
+}
+
+func (r *DataRecordImportResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *DataRecordImportResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Power Platform Data Record Import Resource upserts the rows of a CSV or JSON file into a Dataverse table, for example to seed reference data such as countries or product codes. Rows are written with Dataverse `$batch` requests and identified by the value of their key column. Only a hash of the file and the ids of the rows are kept in state, changes to the file are detected through the hash. Rows that are removed from the file are deleted, rows that fail are reported per row as warnings and imported again on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				Read:   true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique id in the format `<environment_id>/<table_logical_name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dynamics 365 environment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the data record table",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "Path of the CSV or JSON file. CSV files start with a header row with the names of the fields, values are converted to the type of their column and empty values clear the column. JSON files hold an array of objects.",
+				Required:            true,
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the file, either `csv` or `json`. Derived from the extension of `file` when not set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(importFormatCsv, importFormatJson),
+				},
+			},
+			"column_mapping": schema.MapAttribute{
+				MarkdownDescription: "Logical names of the columns by name of the field in the file. When set, only the mapped fields are imported. When not set, every field is imported into the column with the same logical name.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"key_column": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the column that identifies a row. Rows are upserted by the alternate key of this column, which must exist on the table, or by their id when it is the primary id column of the table.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 hash of the content of the file that was imported. Empty when rows of the file failed to import.",
+				Computed:            true,
+			},
+			"row_ids": schema.MapAttribute{
+				MarkdownDescription: "Ids of the records in Dataverse, by value of the key column",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DataRecordImportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var config DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ColumnMapping.IsNull() || config.ColumnMapping.IsUnknown() || config.KeyColumn.IsUnknown() {
+		return
+	}
+
+	columnMapping := map[string]types.String{}
+	resp.Diagnostics.Append(config.ColumnMapping.ElementsAs(ctx, &columnMapping, false)...)
+	for _, column := range columnMapping {
+		if column.IsUnknown() || column.Equal(config.KeyColumn) {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("key_column"), "Key column is not mapped", fmt.Sprintf("`column_mapping` must map a field of the file to the key column '%s'.", config.KeyColumn.ValueString()))
+}
+
+func (r *DataRecordImportResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.DataRecordClient = newDataRecordClient(providerClient.Api)
+}
+
+// ModifyPlan compares the hash of the file with the one in state, so that changes to its content are detected. The rows
+// are imported again when the file, its format or mapping changed, or when rows of the file are missing in Dataverse.
+func (r *DataRecordImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.File.IsUnknown() || plan.Format.IsUnknown() || plan.ColumnMapping.IsUnknown() || plan.KeyColumn.IsUnknown() {
+		return
+	}
+
+	rows, contentHash, _, err := readImportFileRows(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("file"), "Invalid import file", err.Error())
+		return
+	}
+
+	// The hash is only known after the rows were imported, because it is cleared when rows fail to import.
+	plan.ContentHash = types.StringUnknown()
+	if !req.State.Raw.IsNull() {
+		var state DataRecordImportResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		stateRowIds := map[string]string{}
+		resp.Diagnostics.Append(state.RowIds.ElementsAs(ctx, &stateRowIds, false)...)
+		unchanged := types.StringValue(contentHash).Equal(state.ContentHash) &&
+			plan.EnvironmentId.Equal(state.EnvironmentId) &&
+			plan.TableLogicalName.Equal(state.TableLogicalName) &&
+			plan.KeyColumn.Equal(state.KeyColumn) &&
+			plan.Format.Equal(state.Format) &&
+			plan.ColumnMapping.Equal(state.ColumnMapping) &&
+			len(rows) == len(stateRowIds)
+		for _, row := range rows {
+			if _, ok := stateRowIds[row.Key]; !ok {
+				unchanged = false
+			}
+		}
+		if unchanged {
+			plan.ContentHash = state.ContentHash
+			plan.RowIds = state.RowIds
+		} else {
+			plan.RowIds = types.MapUnknown(types.StringType)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *DataRecordImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowIds, failures, diags := r.importRows(ctx, "creating", &plan, map[string]string{})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(rowIds) == 0 && failures.HasError() {
+		resp.Diagnostics.Append(failures...)
+		return
+	}
+	// An error would taint the resource, and its replacement would delete every imported row and import it again
+	// with a new id. The failed rows are reported as warnings instead and are imported again by the next apply.
+	resp.Diagnostics.Append(failedRecordWarnings(failures)...)
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString()))
+	setImportedRows(&plan, rowIds, !failures.HasError())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DataRecordImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowIds := map[string]string{}
+	resp.Diagnostics.Append(state.RowIds.ElementsAs(ctx, &rowIds, false)...)
+	if resp.Diagnostics.HasError() || len(rowIds) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(rowIds))
+	for _, key := range sortedKeys(rowIds) {
+		ids = append(ids, rowIds[key])
+	}
+	remoteRecords, err := r.DataRecordClient.GetDataRecordsByIds(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), ids)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	existingIds := map[string]attr.Value{}
+	for key, rowId := range rowIds {
+		if _, ok := remoteRecords[strings.ToLower(rowId)]; !ok {
+			tflog.Debug(ctx, fmt.Sprintf("Row '%s' with id %s no longer exists", key, rowId))
+			continue
+		}
+		existingIds[key] = types.StringValue(rowId)
+	}
+	state.RowIds = types.MapValueMust(types.StringType, existingIds)
+
+	tflog.Debug(ctx, fmt.Sprintf("READ: %s with %d rows of table %s", r.FullTypeName(), len(existingIds), state.TableLogicalName.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DataRecordImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan, state DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRowIds := map[string]string{}
+	resp.Diagnostics.Append(state.RowIds.ElementsAs(ctx, &stateRowIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowIds, failures, diags := r.importRows(ctx, "updating", &plan, stateRowIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Errors would fail the whole apply, so the failed rows are reported as warnings and imported again by the next apply.
+	resp.Diagnostics.Append(failedRecordWarnings(failures)...)
+
+	plan.Id = state.Id
+	setImportedRows(&plan, rowIds, !failures.HasError())
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DataRecordImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state DataRecordImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRowIds := map[string]string{}
+	resp.Diagnostics.Append(state.RowIds.ElementsAs(ctx, &stateRowIds, false)...)
+	if resp.Diagnostics.HasError() || len(stateRowIds) == 0 {
+		return
+	}
+
+	environmentHost, err := r.DataRecordClient.GetEnvironmentHostById(ctx, state.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+		return
+	}
+	definitions := newEntityDefinitionCache(&r.DataRecordClient, state.EnvironmentId.ValueString())
+
+	keys := sortedKeys(stateRowIds)
+	changeSets := make([]batchChangeSet, 0, len(keys))
+	for _, key := range keys {
+		changeSet, err := r.DataRecordClient.BuildDeleteChangeSet(ctx, definitions, environmentHost, state.TableLogicalName.ValueString(), stateRowIds[key])
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+			return
+		}
+		changeSets = append(changeSets, *changeSet)
+	}
+
+	remainingIds := map[string]string{}
+	for i, result := range r.DataRecordClient.ExecuteBatch(ctx, environmentHost, changeSets) {
+		if result.Err == nil || isNotFoundResult(result) {
+			continue
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting row '%s' of %s", keys[i], r.FullTypeName()), result.Err.Error())
+		remainingIds[keys[i]] = stateRowIds[keys[i]]
+	}
+
+	if len(remainingIds) > 0 {
+		// Keep the rows that could not be deleted, so the next destroy retries them.
+		setImportedRows(&state, remainingIds, true)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
+
+// importRows upserts the rows of the import file and deletes the rows of previousRowIds that are no longer in the file.
+// It sets the hash of the imported file on the model and returns the ids of the rows by key together with an error per row
+// that failed. The diagnostics hold the errors that prevented the import as a whole.
+// A row that fails keeps its previous id, so that it isn't lost from state.
+func (r *DataRecordImportResource) importRows(ctx context.Context, operation string, model *DataRecordImportResourceModel, previousRowIds map[string]string) (map[string]string, diag.Diagnostics, diag.Diagnostics) {
+	var diags, failures diag.Diagnostics
+
+	rows, contentHash, format, err := readImportFileRows(ctx, model)
+	if err != nil {
+		diags.AddAttributeError(path.Root("file"), "Invalid import file", err.Error())
+		return nil, nil, diags
+	}
+	model.ContentHash = types.StringValue(contentHash)
+
+	environmentId, tableLogicalName, keyColumn := model.EnvironmentId.ValueString(), model.TableLogicalName.ValueString(), model.KeyColumn.ValueString()
+	environmentHost, err := r.DataRecordClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Client error when %s %s", operation, r.FullTypeName()), err.Error())
+		return nil, nil, diags
+	}
+	definitions := newEntityDefinitionCache(&r.DataRecordClient, environmentId)
+	tableDefinition, err := definitions.get(ctx, tableLogicalName)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Client error when %s %s", operation, r.FullTypeName()), err.Error())
+		return nil, nil, diags
+	}
+	var attributeTypes map[string]string
+	if format == importFormatCsv {
+		attributeTypes, err = r.DataRecordClient.GetEntityAttributeTypes(ctx, environmentId, tableLogicalName)
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Client error when %s %s", operation, r.FullTypeName()), err.Error())
+			return nil, nil, diags
+		}
+	}
+
+	rowIds := map[string]string{}
+	changes := []recordChange{}
+	changeSets := []batchChangeSet{}
+
+	fileKeys := make([]string, 0, len(rows))
+	for _, row := range rows {
+		fileKeys = append(fileKeys, row.Key)
+	}
+	for _, key := range sortedKeys(previousRowIds) {
+		if slices.Contains(fileKeys, key) {
+			continue
+		}
+		changeSet, err := r.DataRecordClient.BuildDeleteChangeSet(ctx, definitions, environmentHost, tableLogicalName, previousRowIds[key])
+		if err != nil {
+			diags.AddError(fmt.Sprintf("Client error when %s %s", operation, r.FullTypeName()), err.Error())
+			return nil, nil, diags
+		}
+		changes = append(changes, recordChange{Key: key, RecordId: previousRowIds[key], Delete: true})
+		changeSets = append(changeSets, *changeSet)
+	}
+
+	for _, row := range rows {
+		changeSet, err := r.buildRowChangeSet(environmentHost, tableDefinition, keyColumn, format, attributeTypes, row)
+		if err != nil {
+			failures.AddError(fmt.Sprintf("Invalid row '%s' of %s", row.Key, r.FullTypeName()), fmt.Sprintf("Row %d of '%s': %s", row.Number, model.File.ValueString(), err.Error()))
+			if recordId, ok := previousRowIds[row.Key]; ok {
+				rowIds[row.Key] = recordId
+			}
+			continue
+		}
+		changes = append(changes, recordChange{Key: row.Key, RecordId: previousRowIds[row.Key]})
+		changeSets = append(changeSets, *changeSet)
+	}
+
+	for i, result := range r.DataRecordClient.ExecuteBatch(ctx, environmentHost, changeSets) {
+		change := changes[i]
+		switch {
+		case change.Delete && (result.Err == nil || isNotFoundResult(result)):
+			continue
+		case result.Err == nil && len(result.EntityIds) > 0 && result.EntityIds[0] != "":
+			rowIds[change.Key], err = getRecordIdFromEntityIdHeader(result.EntityIds[0])
+			if err == nil {
+				continue
+			}
+			result.Err = err
+		case result.Err == nil:
+			result.Err = errors.New("no entity record id returned from the API")
+		}
+
+		failures.AddError(fmt.Sprintf("Client error when importing row '%s' of %s", change.Key, r.FullTypeName()), result.Err.Error())
+		if change.RecordId != "" {
+			rowIds[change.Key] = change.RecordId
+		} else {
+			delete(rowIds, change.Key)
+		}
+	}
+
+	return rowIds, failures, diags
+}
+
+// buildRowChangeSet builds the change set that upserts a row, after converting the values of CSV files to the types of their columns.
+func (r *DataRecordImportResource) buildRowChangeSet(environmentHost string, tableDefinition *entityDefinitionsDto, keyColumn, format string, attributeTypes map[string]string, row importRow) (*batchChangeSet, error) {
+	columns := row.Columns
+	if format == importFormatCsv {
+		var err error
+		columns, err = convertCsvColumns(columns, attributeTypes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r.DataRecordClient.BuildImportChangeSet(environmentHost, tableDefinition, keyColumn, columns)
+}
+
+// readImportFileRows reads the import file of the model and returns its rows, the hash of its content and its format.
+func readImportFileRows(ctx context.Context, model *DataRecordImportResourceModel) ([]importRow, string, string, error) {
+	content, contentHash, err := readImportFile(model.File.ValueString())
+	if err != nil {
+		return nil, "", "", err
+	}
+	format, err := importFileFormat(model.File.ValueString(), model.Format.ValueString())
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	var columnMapping map[string]string
+	if !model.ColumnMapping.IsNull() {
+		if diags := model.ColumnMapping.ElementsAs(ctx, &columnMapping, false); diags.HasError() {
+			return nil, "", "", fmt.Errorf("failed to read column mapping: %v", diags)
+		}
+	}
+
+	rows, err := readImportRows(content, format, columnMapping, model.KeyColumn.ValueString())
+	if err != nil {
+		return nil, "", "", err
+	}
+	return rows, contentHash, format, nil
+}
+
+// setImportedRows sets the ids of the rows on the model. When rows failed, the hash is cleared so that the file is imported again.
+func setImportedRows(model *DataRecordImportResourceModel, rowIds map[string]string, imported bool) {
+	idValues := map[string]attr.Value{}
+	for key, rowId := range rowIds {
+		idValues[key] = types.StringValue(rowId)
+	}
+	model.RowIds = types.MapValueMust(types.StringType, idValues)
+	if !imported {
+		model.ContentHash = types.StringNull()
+	}
+}
+
+// isNotFoundResult reports whether a change set failed because its record doesn't exist.
+func isNotFoundResult(result batchChangeSetResult) bool {
+	return result.Err != nil && len(result.StatusCodes) > 0 && result.StatusCodes[0] == http.StatusNotFound
+}
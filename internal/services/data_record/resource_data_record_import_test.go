@@ -0,0 +1,305 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDataRecordImportResource_Validate_Create(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "contacts.json")
+	require.NoError(t, os.WriteFile(file, []byte(`[
+		{ "contactid": "8d0b2a4e-5f3c-4c1e-9a7b-000000000001", "firstname": "John", "lastname": "Doe" },
+		{ "contactid": "8d0b2a4e-5f3c-4c1e-9a7b-000000000002", "firstname": "Jane", "lastname": "Doe" }
+	]`), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "test_env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+					  language_code     = "1033"
+					  currency_code     = "USD"
+					  security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				resource "time_sleep" "wait_for_dataverse" {
+					create_duration = "120s"
+
+					depends_on = [powerplatform_environment.test_env]
+				}
+
+				resource "powerplatform_data_record_import" "contacts" {
+					environment_id     = powerplatform_environment.test_env.id
+					table_logical_name = "contact"
+					file               = "` + filepath.ToSlash(file) + `"
+					key_column         = "contactid"
+
+					depends_on = [time_sleep.wait_for_dataverse]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_data_record_import.contacts", "row_ids.%", "2"),
+					resource.TestCheckResourceAttr("powerplatform_data_record_import.contacts", "row_ids.8d0b2a4e-5f3c-4c1e-9a7b-000000000001", "8d0b2a4e-5f3c-4c1e-9a7b-000000000001"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordImportResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_entitydefinition_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29/Attributes?%24select=LogicalName%2CAttributeType`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_attributes_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/contacts\?%24filter=contactid`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_contacts.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$batch`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			file, boundary := "post_batch.txt", "batchresponse_00000000-0000-0000-0000-000000000001"
+			switch {
+			case strings.Contains(string(body), "DELETE ") && strings.Contains(string(body), "PATCH "):
+				if !strings.Contains(string(body), "contacts(emailaddress1=%27jane@contoso.com%27)") || !strings.Contains(string(body), `"firstname":"Janet"`) {
+					return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected update"), nil
+				}
+				file, boundary = "post_batch_update.txt", "batchresponse_00000000-0000-0000-0000-000000000002"
+			case strings.Contains(string(body), "DELETE "):
+				file, boundary = "post_batch_delete.txt", "batchresponse_00000000-0000-0000-0000-000000000003"
+			case !strings.Contains(string(body), "contacts(emailaddress1=%27john@contoso.com%27)") || !strings.Contains(string(body), `"numberofchildren":2`):
+				return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected create"), nil
+			}
+
+			resp := httpmock.NewStringResponse(http.StatusOK, strings.ReplaceAll(httpmock.File("tests/resource/Import_Validate_CRUD/"+file).String(), "\n", "\r\n"))
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary="+boundary)
+			return resp, nil
+		})
+
+	file := filepath.Join(t.TempDir(), "contacts.csv")
+	writeFile := func(content string) {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	}
+	config := `
+	resource "powerplatform_data_record_import" "contacts" {
+		environment_id     = "00000000-0000-0000-0000-000000000001"
+		table_logical_name = "contact"
+		file               = "` + filepath.ToSlash(file) + `"
+		key_column         = "emailaddress1"
+
+		column_mapping = {
+			Email    = "emailaddress1"
+			First    = "firstname"
+			Last     = "lastname"
+			Children = "numberofchildren"
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					writeFile("Email,First,Last,Children\njane@contoso.com,Jane,Doe,\njohn@contoso.com,John,Doe,2\n")
+				},
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_record_import.contacts", tfjsonpath.New("row_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+							"john@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000011"),
+						})),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_data_record_import.contacts", "id", "00000000-0000-0000-0000-000000000001/contact"),
+					resource.TestMatchResourceAttr("powerplatform_data_record_import.contacts", "content_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+			{
+				PreConfig: func() {
+					writeFile("Email,First,Last,Children\njane@contoso.com,Janet,Doe,1\n")
+				},
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_record_import.contacts", tfjsonpath.New("row_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+						})),
+				},
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordImportResource_Validate_Key_Column_Mapping(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_data_record_import" "contacts" {
+					environment_id     = "00000000-0000-0000-0000-000000000001"
+					table_logical_name = "contact"
+					file               = "contacts.csv"
+					key_column         = "emailaddress1"
+
+					column_mapping = {
+						First = "firstname"
+					}
+				}`,
+				ExpectError: regexp.MustCompile("Key column is not mapped"),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordImportResource_Validate_Partial_Failure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_entitydefinition_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29/Attributes?%24select=LogicalName%2CAttributeType`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_attributes_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/contacts\?%24filter=contactid`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Import_Validate_CRUD/get_contacts.json").String()), nil
+		})
+
+	// The first batch imports Jane but rejects John, the next apply imports both rows by their key again.
+	batches := 0
+	deletes := 0
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$batch`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			file, boundary := "Import_Validate_Partial_Failure/post_batch.txt", "batchresponse_00000000-0000-0000-0000-000000000001"
+			switch {
+			case strings.Contains(string(body), "DELETE "):
+				deletes++
+				file, boundary = "Records_Validate_Create/post_batch_delete.txt", "batchresponse_00000000-0000-0000-0000-000000000002"
+			case batches > 0:
+				file = "Import_Validate_CRUD/post_batch.txt"
+			}
+			batches++
+
+			resp := httpmock.NewStringResponse(http.StatusOK, strings.ReplaceAll(httpmock.File("tests/resource/"+file).String(), "\n", "\r\n"))
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary="+boundary)
+			return resp, nil
+		})
+
+	file := filepath.Join(t.TempDir(), "contacts.csv")
+	require.NoError(t, os.WriteFile(file, []byte("emailaddress1,firstname,lastname\njane@contoso.com,Jane,Doe\njohn@contoso.com,John,Doe\n"), 0o600))
+	config := `
+	resource "powerplatform_data_record_import" "contacts" {
+		environment_id     = "00000000-0000-0000-0000-000000000001"
+		table_logical_name = "contact"
+		file               = "` + filepath.ToSlash(file) + `"
+		key_column         = "emailaddress1"
+	}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			// Only the destroy at the end of the test deletes rows, the failed row doesn't replace the resource.
+			if deletes != 1 {
+				return errors.New("rows were deleted before the resource was destroyed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The hash is cleared when a row failed, so the file is imported again.
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_record_import.contacts", tfjsonpath.New("row_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+						})),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("powerplatform_data_record_import.contacts", "content_hash"),
+				),
+			},
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_record_import.contacts", tfjsonpath.New("row_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+							"john@contoso.com": knownvalue.StringExact("00000000-0000-0000-0000-000000000011"),
+						})),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_data_record_import.contacts", "content_hash", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
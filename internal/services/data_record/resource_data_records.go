@@ -0,0 +1,467 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+func NewDataRecordsResource() resource.Resource {
+	return &DataRecordsResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "data_records",
+		},
+	}
+}
+
+func (r *DataRecordsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *DataRecordsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Power Platform Data Records Resource manages many records of one Dataverse table at once. Records are created, updated and deleted with Dataverse `$batch` requests, where every record is written in its own change set together with its relations. A record that fails doesn't stop the others; the failures are reported per record as warnings and only the ids of the records that were written successfully are kept in state, so the failed records are planned again on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				Read:   true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique id in the format `<environment_id>/<table_logical_name>`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dynamics 365 environment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the data record table",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.DynamicAttribute{
+				MarkdownDescription: "Records of the table as an object, where every key identifies a record in the configuration and its value holds the columns of the record, in the same format as `columns` of `powerplatform_data_record`. Changing the key of a record deletes it and creates a new one.",
+				Required:            true,
+			},
+			"record_ids": schema.MapAttribute{
+				MarkdownDescription: "Ids of the records in Dataverse, by key of `records`",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *DataRecordsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.DataRecordClient = newDataRecordClient(providerClient.Api)
+}
+
+func (r *DataRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan DataRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedRecords, err := convertRecordsToMap(plan.Records)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting records to map: %s", err.Error()), err.Error())
+		return
+	}
+
+	changes := make([]recordChange, 0, len(plannedRecords))
+	for _, key := range sortedKeys(plannedRecords) {
+		changes = append(changes, recordChange{Key: key, NewColumns: plannedRecords[key]})
+	}
+
+	results, err := r.applyRecordChanges(ctx, plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), changes)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	recordIds := map[string]string{}
+	failures := diag.Diagnostics{}
+	for _, result := range results {
+		if result.Err != nil {
+			failures.AddError(fmt.Sprintf("Client error when creating record '%s' of %s", result.Key, r.FullTypeName()), result.Err.Error())
+			continue
+		}
+		recordIds[result.Key] = result.RecordId
+	}
+
+	if len(recordIds) == 0 && failures.HasError() {
+		resp.Diagnostics.Append(failures...)
+		return
+	}
+	// An error would taint the resource, and its replacement would delete and create the records that were created
+	// successfully again. The failed records are reported as warnings instead and have no id in state, so the next
+	// refresh leaves them out of `records` and they are created by the next apply.
+	resp.Diagnostics.Append(failedRecordWarnings(failures)...)
+
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString()))
+	plan.RecordIds = recordIdsValue(recordIds)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DataRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state DataRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRecords, err := convertRecordsToMap(state.Records)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting records to map: %s", err.Error()), err.Error())
+		return
+	}
+
+	recordIds := map[string]string{}
+	resp.Diagnostics.Append(state.RecordIds.ElementsAs(ctx, &recordIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids := make([]string, 0, len(recordIds))
+	for _, key := range sortedKeys(recordIds) {
+		ids = append(ids, recordIds[key])
+	}
+
+	remoteRecords, err := r.DataRecordClient.GetDataRecordsByIds(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), ids)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	lookupTables := map[string]string{}
+	recordValues, recordTypes := map[string]attr.Value{}, map[string]attr.Type{}
+	existingIds := map[string]attr.Value{}
+	for _, key := range sortedKeys(recordIds) {
+		remoteRecord, ok := remoteRecords[strings.ToLower(recordIds[key])]
+		if !ok {
+			tflog.Debug(ctx, fmt.Sprintf("Record '%s' with id %s no longer exists", key, recordIds[key]))
+			continue
+		}
+
+		stateColumns, _ := stateRecords[key].(map[string]any)
+		columns, err := convertColumnMapToState(ctx, &r.DataRecordClient, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), recordIds[key], stateColumns, remoteRecord, lookupTables)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Error converting columns of record '%s' to state: %s", key, err.Error()), err.Error())
+			return
+		}
+		recordValues[key] = columns
+		recordTypes[key] = columns.Type(ctx)
+		existingIds[key] = types.StringValue(recordIds[key])
+	}
+
+	records, diags := types.ObjectValue(recordTypes, recordValues)
+	resp.Diagnostics.Append(diags...)
+	state.Records = types.DynamicValue(records)
+	state.RecordIds = types.MapValueMust(types.StringType, existingIds)
+
+	tflog.Debug(ctx, fmt.Sprintf("READ: %s with %d records of table %s", r.FullTypeName(), len(existingIds), state.TableLogicalName.ValueString()))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *DataRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan, state DataRecordsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plannedRecords, err := convertRecordsToMap(plan.Records)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting records to map: %s", err.Error()), err.Error())
+		return
+	}
+	stateRecords, err := convertRecordsToMap(state.Records)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error converting records to map: %s", err.Error()), err.Error())
+		return
+	}
+	stateRecordIds := map[string]string{}
+	resp.Diagnostics.Append(state.RecordIds.ElementsAs(ctx, &stateRecordIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	recordIds := map[string]string{}
+	changes := []recordChange{}
+	for _, key := range sortedKeys(stateRecordIds) {
+		if _, ok := plannedRecords[key]; !ok {
+			changes = append(changes, recordChange{Key: key, RecordId: stateRecordIds[key], Delete: true})
+		}
+	}
+	for _, key := range sortedKeys(plannedRecords) {
+		newColumns, _ := plannedRecords[key].(map[string]any)
+		recordId, exists := stateRecordIds[key]
+		if !exists {
+			changes = append(changes, recordChange{Key: key, NewColumns: newColumns})
+			continue
+		}
+		oldColumns, _ := stateRecords[key].(map[string]any)
+		if reflect.DeepEqual(oldColumns, newColumns) {
+			recordIds[key] = recordId
+			continue
+		}
+		changes = append(changes, recordChange{Key: key, RecordId: recordId, OldColumns: oldColumns, NewColumns: newColumns})
+	}
+
+	results, err := r.applyRecordChanges(ctx, plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), changes)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			// Errors would fail the whole apply, so the failed records are reported as warnings. A record keeps the id
+			// it had before, so the next refresh reads the columns it still has in Dataverse and the change is planned again.
+			resp.Diagnostics.AddWarning(fmt.Sprintf("Client error when updating record '%s' of %s", result.Key, r.FullTypeName()), result.Err.Error())
+			if recordId, ok := stateRecordIds[result.Key]; ok {
+				recordIds[result.Key] = recordId
+			}
+			continue
+		}
+		if result.Delete {
+			continue
+		}
+		recordIds[result.Key] = result.RecordId
+	}
+
+	plan.Id = state.Id
+	plan.RecordIds = recordIdsValue(recordIds)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *DataRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state DataRecordsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stateRecordIds := map[string]string{}
+	resp.Diagnostics.Append(state.RecordIds.ElementsAs(ctx, &stateRecordIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changes := make([]recordChange, 0, len(stateRecordIds))
+	for _, key := range sortedKeys(stateRecordIds) {
+		changes = append(changes, recordChange{Key: key, RecordId: stateRecordIds[key], Delete: true})
+	}
+
+	results, err := r.applyRecordChanges(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), changes)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	stateValues := recordAttributes(state.Records)
+	records, recordIds := map[string]attr.Value{}, map[string]string{}
+	for _, result := range results {
+		if result.Err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting record '%s' of %s", result.Key, r.FullTypeName()), result.Err.Error())
+			records[result.Key] = stateValues[result.Key]
+			recordIds[result.Key] = stateRecordIds[result.Key]
+		}
+	}
+
+	if len(recordIds) > 0 {
+		// Keep the records that could not be deleted, so the next destroy retries them.
+		resp.Diagnostics.Append(setRecords(ctx, &state, records, recordIds)...)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
+
+// recordChange is the create, update or delete of a single record of the `records` attribute.
+type recordChange struct {
+	Key        string
+	RecordId   string
+	Delete     bool
+	OldColumns map[string]any
+	NewColumns any
+}
+
+type recordChangeResult struct {
+	recordChange
+	Err error
+}
+
+// applyRecordChanges writes every change in its own change set and returns one result per change.
+func (r *DataRecordsResource) applyRecordChanges(ctx context.Context, environmentId, tableLogicalName string, changes []recordChange) ([]recordChangeResult, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	environmentHost, err := r.DataRecordClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := newEntityDefinitionCache(&r.DataRecordClient, environmentId)
+	changeSets := make([]batchChangeSet, 0, len(changes))
+	for _, change := range changes {
+		var changeSet *batchChangeSet
+		if change.Delete {
+			changeSet, err = r.DataRecordClient.BuildDeleteChangeSet(ctx, definitions, environmentHost, tableLogicalName, change.RecordId)
+		} else {
+			newColumns, ok := change.NewColumns.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("record '%s' must be an object of columns", change.Key)
+			}
+			changeSet, err = r.DataRecordClient.BuildUpsertChangeSet(ctx, definitions, environmentHost, tableLogicalName, change.RecordId, change.OldColumns, newColumns)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("record '%s': %w", change.Key, err)
+		}
+		changeSets = append(changeSets, *changeSet)
+	}
+
+	batchResults := r.DataRecordClient.ExecuteBatch(ctx, environmentHost, changeSets)
+
+	results := make([]recordChangeResult, 0, len(changes))
+	for i, change := range changes {
+		result := recordChangeResult{recordChange: change, Err: batchResults[i].Err}
+		switch {
+		case change.Delete && result.Err != nil && len(batchResults[i].StatusCodes) > 0 && batchResults[i].StatusCodes[0] == http.StatusNotFound:
+			// The record is already gone.
+			result.Err = nil
+		case !change.Delete && result.Err == nil && len(batchResults[i].EntityIds) > 0 && batchResults[i].EntityIds[0] != "":
+			result.RecordId, result.Err = getRecordIdFromEntityIdHeader(batchResults[i].EntityIds[0])
+		case !change.Delete && result.Err == nil && result.RecordId == "":
+			result.Err = errors.New("no entity record id returned from the API")
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// failedRecordWarnings turns the errors of records that failed to be written into warnings.
+func failedRecordWarnings(failures diag.Diagnostics) diag.Diagnostics {
+	warnings := diag.Diagnostics{}
+	for _, failure := range failures {
+		warnings.AddWarning(failure.Summary(), failure.Detail())
+	}
+	return warnings
+}
+
+// setRecords sets the records, given as the values of their keys in `records`, and their ids on the model.
+func setRecords(ctx context.Context, model *DataRecordsResourceModel, records map[string]attr.Value, recordIds map[string]string) diag.Diagnostics {
+	recordTypes := map[string]attr.Type{}
+	for key, value := range records {
+		recordTypes[key] = value.Type(ctx)
+	}
+
+	recordsObject, diags := types.ObjectValue(recordTypes, records)
+	model.Records = types.DynamicValue(recordsObject)
+	model.RecordIds = recordIdsValue(recordIds)
+	return diags
+}
+
+// recordIdsValue converts the ids of the records by key into the value of the `record_ids` attribute.
+func recordIdsValue(recordIds map[string]string) types.Map {
+	idValues := map[string]attr.Value{}
+	for key, recordId := range recordIds {
+		idValues[key] = types.StringValue(recordId)
+	}
+	return types.MapValueMust(types.StringType, idValues)
+}
+
+// recordAttributes returns the values of the keys of the `records` attribute.
+func recordAttributes(records types.Dynamic) map[string]attr.Value {
+	if object, ok := records.UnderlyingValue().(types.Object); ok {
+		return object.Attributes()
+	}
+	return map[string]attr.Value{}
+}
+
+// convertRecordsToMap converts the `records` attribute into a map of column maps by key.
+func convertRecordsToMap(records types.Dynamic) (map[string]any, error) {
+	recordsAsString := records.String()
+	mapRecords, err := convertResourceModelToMap(&recordsAsString)
+	if err != nil {
+		return nil, err
+	}
+	if mapRecords == nil {
+		mapRecords = map[string]any{}
+	}
+	for key, record := range mapRecords {
+		if _, ok := record.(map[string]any); !ok {
+			return nil, fmt.Errorf("record '%s' must be an object of columns", key)
+		}
+	}
+	return mapRecords, nil
+}
@@ -0,0 +1,294 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package data_record_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestAccDataRecordsResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "test_env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+					  language_code     = "1033"
+					  currency_code     = "USD"
+					  security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				resource "time_sleep" "wait_for_dataverse" {
+					create_duration = "120s"
+
+					depends_on = [powerplatform_environment.test_env]
+				}
+
+				resource "powerplatform_data_records" "contacts" {
+					environment_id     = powerplatform_environment.test_env.id
+					table_logical_name = "contact"
+					records = {
+						john = {
+							firstname = "John"
+							lastname  = "Doe"
+						}
+						jane = {
+							firstname = "Jane"
+							lastname  = "Doe"
+						}
+					}
+
+					depends_on = [time_sleep.wait_for_dataverse]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_data_records.contacts", "record_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("powerplatform_data_records.contacts", "record_ids.john"),
+					resource.TestCheckResourceAttrSet("powerplatform_data_records.contacts", "record_ids.jane"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_environment" "test_env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+					  language_code     = "1033"
+					  currency_code     = "USD"
+					  security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				resource "time_sleep" "wait_for_dataverse" {
+					create_duration = "120s"
+
+					depends_on = [powerplatform_environment.test_env]
+				}
+
+				resource "powerplatform_data_records" "contacts" {
+					environment_id     = powerplatform_environment.test_env.id
+					table_logical_name = "contact"
+					records = {
+						john = {
+							firstname = "Johnny"
+							lastname  = "Doe"
+						}
+						max = {
+							firstname = "Max"
+							lastname  = "Mustermann"
+						}
+					}
+
+					depends_on = [time_sleep.wait_for_dataverse]
+				}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_data_records.contacts", "record_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("powerplatform_data_records.contacts", "record_ids.john"),
+					resource.TestCheckResourceAttrSet("powerplatform_data_records.contacts", "record_ids.max"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordsResource_Validate_Create(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_entitydefinition_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/contacts\?%24filter=contactid`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_contacts.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$batch`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			file, boundary := "post_batch.txt", "batchresponse_00000000-0000-0000-0000-000000000001"
+			if strings.Contains(string(body), "DELETE ") {
+				file, boundary = "post_batch_delete.txt", "batchresponse_00000000-0000-0000-0000-000000000002"
+			}
+
+			resp := httpmock.NewStringResponse(http.StatusOK, strings.ReplaceAll(httpmock.File("tests/resource/Records_Validate_Create/"+file).String(), "\n", "\r\n"))
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary="+boundary)
+			return resp, nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_data_records" "contacts" {
+					environment_id     = "00000000-0000-0000-0000-000000000001"
+					table_logical_name = "contact"
+					records = {
+						jane = {
+							firstname = "Jane"
+							lastname  = "Doe"
+						}
+						john = {
+							firstname = "John"
+							lastname  = "Doe"
+						}
+					}
+				}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_records.contacts", tfjsonpath.New("record_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+							"john": knownvalue.StringExact("00000000-0000-0000-0000-000000000011"),
+						})),
+					statecheck.ExpectKnownValue("powerplatform_data_records.contacts", tfjsonpath.New("records").AtMapKey("john"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"firstname": knownvalue.StringExact("John"),
+							"lastname":  knownvalue.StringExact("Doe"),
+						})),
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_data_records.contacts", "id", "00000000-0000-0000-0000-000000000001/contact"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataRecordsResource_Validate_Create_Partial_Failure(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions%28LogicalName=%27contact%27%29#$select=PrimaryIdAttribute,LogicalCollectionName`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_entitydefinition_contact.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/contacts\?%24filter=contactid`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Records_Validate_Create/get_contacts.json").String()), nil
+		})
+
+	// The first batch creates Jane but rejects John, the next apply only creates John.
+	batches := 0
+	deletes := 0
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$batch`,
+		func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			file, boundary := "Records_Validate_Create_Partial_Failure/post_batch.txt", "batchresponse_00000000-0000-0000-0000-000000000001"
+			switch {
+			case strings.Contains(string(body), "DELETE "):
+				deletes++
+				file, boundary = "Records_Validate_Create/post_batch_delete.txt", "batchresponse_00000000-0000-0000-0000-000000000002"
+			case batches > 0:
+				if strings.Contains(string(body), "Jane") {
+					return httpmock.NewStringResponse(http.StatusBadRequest, "record that was created successfully must not be created again"), nil
+				}
+				file, boundary = "Records_Validate_Create_Partial_Failure/post_batch_retry.txt", "batchresponse_00000000-0000-0000-0000-000000000003"
+			}
+			batches++
+
+			resp := httpmock.NewStringResponse(http.StatusOK, strings.ReplaceAll(httpmock.File("tests/resource/"+file).String(), "\n", "\r\n"))
+			resp.Header.Set("Content-Type", "multipart/mixed; boundary="+boundary)
+			return resp, nil
+		})
+
+	config := `
+	resource "powerplatform_data_records" "contacts" {
+		environment_id     = "00000000-0000-0000-0000-000000000001"
+		table_logical_name = "contact"
+		records = {
+			jane = {
+				firstname = "Jane"
+				lastname  = "Doe"
+			}
+			john = {
+				firstname = "John"
+				lastname  = "Doe"
+			}
+		}
+	}
+	`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			// Only the destroy at the end of the test deletes records, the failed record doesn't replace the resource.
+			if deletes != 1 {
+				return errors.New("records were deleted before the resource was destroyed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The record that failed is left out of state, so it is planned again.
+				Config:             config,
+				ExpectNonEmptyPlan: true,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_records.contacts", tfjsonpath.New("record_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+						})),
+				},
+			},
+			{
+				Config: config,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("powerplatform_data_records.contacts", tfjsonpath.New("record_ids"),
+						knownvalue.MapExact(map[string]knownvalue.Check{
+							"jane": knownvalue.StringExact("00000000-0000-0000-0000-000000000010"),
+							"john": knownvalue.StringExact("00000000-0000-0000-0000-000000000011"),
+						})),
+				},
+			},
+		},
+	})
+}
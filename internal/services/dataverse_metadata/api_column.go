@@ -0,0 +1,389 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+const (
+	columnTypeString            = "String"
+	columnTypeMemo              = "Memo"
+	columnTypeInteger           = "Integer"
+	columnTypeDecimal           = "Decimal"
+	columnTypeMoney             = "Money"
+	columnTypeBoolean           = "Boolean"
+	columnTypeDateTime          = "DateTime"
+	columnTypeChoice            = "Choice"
+	columnTypeMultiSelectChoice = "MultiSelectChoice"
+	columnTypeLookup            = "Lookup"
+	columnTypeFile              = "File"
+	columnTypeImage             = "Image"
+)
+
+// columnTypeInfo describes how a column type is represented in the attribute metadata.
+type columnTypeInfo struct {
+	MetadataType      string
+	AttributeType     string
+	AttributeTypeName string
+}
+
+var columnTypes = map[string]columnTypeInfo{
+	columnTypeString:            {MetadataType: "StringAttributeMetadata", AttributeType: "String", AttributeTypeName: "StringType"},
+	columnTypeMemo:              {MetadataType: "MemoAttributeMetadata", AttributeType: "Memo", AttributeTypeName: "MemoType"},
+	columnTypeInteger:           {MetadataType: "IntegerAttributeMetadata", AttributeType: "Integer", AttributeTypeName: "IntegerType"},
+	columnTypeDecimal:           {MetadataType: "DecimalAttributeMetadata", AttributeType: "Decimal", AttributeTypeName: "DecimalType"},
+	columnTypeMoney:             {MetadataType: "MoneyAttributeMetadata", AttributeType: "Money", AttributeTypeName: "MoneyType"},
+	columnTypeBoolean:           {MetadataType: "BooleanAttributeMetadata", AttributeType: "Boolean", AttributeTypeName: "BooleanType"},
+	columnTypeDateTime:          {MetadataType: "DateTimeAttributeMetadata", AttributeType: "DateTime", AttributeTypeName: "DateTimeType"},
+	columnTypeChoice:            {MetadataType: "PicklistAttributeMetadata", AttributeType: "Picklist", AttributeTypeName: "PicklistType"},
+	columnTypeMultiSelectChoice: {MetadataType: "MultiSelectPicklistAttributeMetadata", AttributeType: "Virtual", AttributeTypeName: "MultiSelectPicklistType"},
+	columnTypeLookup:            {MetadataType: "LookupAttributeMetadata", AttributeType: "Lookup", AttributeTypeName: "LookupType"},
+	columnTypeFile:              {MetadataType: "FileAttributeMetadata", AttributeType: "Virtual", AttributeTypeName: "FileType"},
+	columnTypeImage:             {MetadataType: "ImageAttributeMetadata", AttributeType: "Virtual", AttributeTypeName: "ImageType"},
+}
+
+// columnTypeFromMetadata returns the column type of an attribute from its `AttributeType` and `AttributeTypeName`.
+func columnTypeFromMetadata(attribute *AttributeMetadataDto) (string, error) {
+	typeName := ""
+	if attribute.AttributeTypeName != nil {
+		typeName = attribute.AttributeTypeName.Value
+	}
+	for columnType, info := range columnTypes {
+		if info.AttributeTypeName == typeName || (info.AttributeType == attribute.AttributeType && info.AttributeType != "Virtual") {
+			return columnType, nil
+		}
+	}
+	return "", fmt.Errorf("column '%s' has type '%s' (%s), which is not supported", attribute.LogicalName, attribute.AttributeType, typeName)
+}
+
+// columnKey returns the key that addresses a column in the Attributes of a table, either its metadata id or its logical name.
+func columnKey(columnId, logicalName string) string {
+	if columnId != "" {
+		return columnId
+	}
+	return fmt.Sprintf("LogicalName='%s'", logicalName)
+}
+
+// columnFilter returns the `$filter` that selects a column by its id, or by its logical name when the id is empty.
+func columnFilter(columnId, logicalName string) string {
+	if columnId != "" {
+		return fmt.Sprintf("MetadataId eq %s", columnId)
+	}
+	return fmt.Sprintf("LogicalName eq '%s'", logicalName)
+}
+
+func columnsUrl(environmentHost, tableLogicalName string, query url.Values) string {
+	return helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(LogicalName='%s')/Attributes", constants.DATAVERSE_API_VERSION, tableLogicalName), query)
+}
+
+func columnUrl(environmentHost, tableLogicalName, key, columnType string, query url.Values) string {
+	path := fmt.Sprintf("/api/data/%s/EntityDefinitions(LogicalName='%s')/Attributes(%s)", constants.DATAVERSE_API_VERSION, tableLogicalName, key)
+	if columnType != "" {
+		path += "/Microsoft.Dynamics.CRM." + columnTypes[columnType].MetadataType
+	}
+	return helpers.BuildDataverseApiUrl(environmentHost, path, query)
+}
+
+// CreateColumn adds a column to a table and publishes the table. Lookup columns are created together with their one-to-many relationship.
+func (client *client) CreateColumn(ctx context.Context, environmentId, tableLogicalName, solutionUniqueName, columnType string, column AttributeMetadataDto, relationship *oneToManyRelationshipDto) (*AttributeMetadataDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	info := columnTypes[columnType]
+	column.ODataType = "Microsoft.Dynamics.CRM." + info.MetadataType
+	column.AttributeType = info.AttributeType
+	column.AttributeTypeName = &attributeTypeNameDto{Value: info.AttributeTypeName}
+
+	if columnType == columnTypeLookup {
+		if relationship == nil {
+			return nil, errors.New("lookup columns require a relationship")
+		}
+		if err := client.createLookupRelationship(ctx, environmentHost, solutionUniqueName, column, *relationship); err != nil {
+			return nil, err
+		}
+	} else {
+		if column.GlobalOptionSet != nil {
+			// columns reference a global choice by binding it, the choice itself is not sent.
+			optionSetId, err := client.getGlobalChoiceId(ctx, environmentHost, column.GlobalOptionSet.Name)
+			if err != nil {
+				return nil, err
+			}
+			column.GlobalOptionSet = nil
+			column.GlobalOptionSetBind = fmt.Sprintf("/GlobalOptionSetDefinitions(%s)", optionSetId)
+		}
+
+		apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(LogicalName='%s')/Attributes", constants.DATAVERSE_API_VERSION, tableLogicalName), nil)
+		resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, metadataHeaders(solutionUniqueName), column, []int{http.StatusNoContent, http.StatusCreated, http.StatusForbidden}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create column '%s': %w", column.SchemaName, err)
+		}
+		if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := client.PublishEntities(ctx, environmentHost, tableLogicalName); err != nil {
+		return nil, err
+	}
+	return client.getColumn(ctx, environmentHost, tableLogicalName, columnKey("", strings.ToLower(column.SchemaName)), columnType)
+}
+
+func (client *client) createLookupRelationship(ctx context.Context, environmentHost, solutionUniqueName string, column AttributeMetadataDto, relationship oneToManyRelationshipDto) error {
+	referencedTable, _, err := client.getTable(ctx, environmentHost, tableKey("", relationship.ReferencedEntity))
+	if err != nil {
+		return err
+	}
+
+	relationship.ODataType = "Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata"
+	relationship.ReferencedAttribute = referencedTable.PrimaryIdAttribute
+	relationship.Lookup = &column
+	if relationship.CascadeConfiguration == nil {
+		relationship.CascadeConfiguration = defaultCascadeConfiguration()
+	}
+
+	_, err = client.createRelationship(ctx, environmentHost, solutionUniqueName, relationship.SchemaName, relationship)
+	return err
+}
+
+// defaultCascadeConfiguration is the referential behavior Dataverse uses for new lookup columns:
+// related records are kept and the lookup is cleared when the referenced record is deleted.
+func defaultCascadeConfiguration() *cascadeConfigurationDto {
+	return &cascadeConfigurationDto{
+		Assign:   "NoCascade",
+		Delete:   "RemoveLink",
+		Merge:    "NoCascade",
+		Reparent: "NoCascade",
+		Share:    "NoCascade",
+		Unshare:  "NoCascade",
+	}
+}
+
+// GetEntityAttributesDefinition returns the columns of a table with the properties selected by query.
+func GetEntityAttributesDefinition(ctx context.Context, apiClient *api.Client, environmentHost, tableLogicalName string, query url.Values) ([]AttributeMetadataDto, error) {
+	metadataClient := client{Api: apiClient}
+	columns := attributeMetadataArrayDto{}
+	if err := metadataClient.getMetadata(ctx, columnsUrl(environmentHost, tableLogicalName, query), &columns, fmt.Sprintf("table '%s'", tableLogicalName)); err != nil {
+		return nil, err
+	}
+	return columns.Value, nil
+}
+
+// GetColumn returns a column of a table. When the column type is empty, it is read from the metadata first.
+func (client *client) GetColumn(ctx context.Context, environmentId, tableLogicalName, columnId, logicalName, columnType string) (*AttributeMetadataDto, string, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := columnKey(columnId, logicalName)
+	if columnType == "" {
+		values := url.Values{}
+		values.Add("$select", "MetadataId,LogicalName,AttributeType,AttributeTypeName")
+		values.Add("$filter", columnFilter(columnId, logicalName))
+		columns, err := GetEntityAttributesDefinition(ctx, client.Api, environmentHost, tableLogicalName, values)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(columns) == 0 {
+			return nil, "", customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("column %s(%s) not found", tableLogicalName, key))
+		}
+		columnType, err = columnTypeFromMetadata(&columns[0])
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	column, err := client.getColumn(ctx, environmentHost, tableLogicalName, key, columnType)
+	if err != nil {
+		return nil, "", err
+	}
+	return column, columnType, nil
+}
+
+func (client *client) getColumn(ctx context.Context, environmentHost, tableLogicalName, key, columnType string) (*AttributeMetadataDto, error) {
+	values := url.Values{}
+	if columnType == columnTypeBoolean || columnType == columnTypeChoice || columnType == columnTypeMultiSelectChoice {
+		values.Add("$expand", "OptionSet")
+	}
+
+	column := AttributeMetadataDto{}
+	if err := client.getMetadata(ctx, columnUrl(environmentHost, tableLogicalName, key, columnType, values), &column, fmt.Sprintf("column %s(%s)", tableLogicalName, key)); err != nil {
+		return nil, err
+	}
+	return &column, nil
+}
+
+// UpdateColumn updates the labels and type specific settings of a column and publishes its table.
+// The options of choice and yes/no columns are changed with option value actions, so existing data keeps its values.
+func (client *client) UpdateColumn(ctx context.Context, environmentId, tableLogicalName, columnId, solutionUniqueName, columnType string, languageCode int64, column AttributeMetadataDto) (*AttributeMetadataDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.updateColumn(ctx, environmentHost, tableLogicalName, columnId, solutionUniqueName, columnType, languageCode, column); err != nil {
+		return nil, err
+	}
+	if err := client.PublishEntities(ctx, environmentHost, tableLogicalName); err != nil {
+		return nil, err
+	}
+	return client.getColumn(ctx, environmentHost, tableLogicalName, columnId, columnType)
+}
+
+func (client *client) updateColumn(ctx context.Context, environmentHost, tableLogicalName, columnId, solutionUniqueName, columnType string, languageCode int64, column AttributeMetadataDto) error {
+	current, err := client.getColumn(ctx, environmentHost, tableLogicalName, columnId, columnType)
+	if err != nil {
+		return err
+	}
+
+	definition, err := client.getMetadataDefinition(ctx, columnUrl(environmentHost, tableLogicalName, columnId, columnType, nil))
+	if err != nil {
+		return err
+	}
+	definition["@odata.type"] = "Microsoft.Dynamics.CRM." + columnTypes[columnType].MetadataType
+	definition["DisplayName"] = labelBody(labelText(column.DisplayName, languageCode), languageCode)
+	definition["Description"] = labelBody(labelText(column.Description, languageCode), languageCode)
+	if column.RequiredLevel != nil {
+		setManagedPropertyValue(definition, "RequiredLevel", column.RequiredLevel.Value)
+	}
+	setDefinitionValue(definition, "MaxLength", column.MaxLength)
+	setDefinitionValue(definition, "Format", column.Format)
+	setDefinitionValue(definition, "MinValue", column.MinValue)
+	setDefinitionValue(definition, "MaxValue", column.MaxValue)
+	setDefinitionValue(definition, "Precision", column.Precision)
+	setDefinitionValue(definition, "PrecisionSource", column.PrecisionSource)
+	if column.FormatName != nil {
+		definition["FormatName"] = map[string]any{"Value": column.FormatName.Value}
+	}
+	if column.DateTimeBehavior != nil {
+		definition["DateTimeBehavior"] = map[string]any{"Value": column.DateTimeBehavior.Value}
+	}
+	// options are changed with option value actions below, the option set is not part of the attribute definition.
+	delete(definition, "OptionSet")
+	delete(definition, "GlobalOptionSet")
+
+	if err := client.putMetadataDefinition(ctx, columnUrl(environmentHost, tableLogicalName, columnId, "", nil), solutionUniqueName, definition); err != nil {
+		return fmt.Errorf("failed to update column '%s': %w", current.LogicalName, err)
+	}
+
+	target := optionSetTarget{
+		EntityLogicalName:    tableLogicalName,
+		AttributeLogicalName: current.LogicalName,
+		SolutionUniqueName:   solutionUniqueName,
+	}
+	switch {
+	case columnType == columnTypeBoolean && current.OptionSet != nil && column.OptionSet != nil:
+		for _, options := range [][2]*optionDto{{current.OptionSet.TrueOption, column.OptionSet.TrueOption}, {current.OptionSet.FalseOption, column.OptionSet.FalseOption}} {
+			if options[0] != nil && options[1] != nil && !optionEqual(*options[0], *options[1], languageCode) {
+				if err := client.UpdateOptionValue(ctx, environmentHost, target, *options[1]); err != nil {
+					return err
+				}
+			}
+		}
+	case current.OptionSet != nil && !current.OptionSet.IsGlobal && column.OptionSet != nil:
+		if err := client.applyOptions(ctx, environmentHost, target, current.OptionSet.Options, column.OptionSet.Options, languageCode); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteColumn deletes a column and its data. Lookup columns are deleted by deleting their relationship.
+func (client *client) DeleteColumn(ctx context.Context, environmentId, tableLogicalName, columnId, relationshipSchemaName string) error {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	apiUrl := columnUrl(environmentHost, tableLogicalName, columnId, "", nil)
+	if relationshipSchemaName != "" {
+		apiUrl = helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/RelationshipDefinitions(SchemaName='%s')", constants.DATAVERSE_API_VERSION, relationshipSchemaName), nil)
+	}
+	return client.deleteMetadata(ctx, apiUrl, fmt.Sprintf("column %s(%s)", tableLogicalName, columnId))
+}
+
+// GetLookupRelationshipSchemaName returns the schema name of the one-to-many relationship of a lookup column.
+func (client *client) GetLookupRelationshipSchemaName(ctx context.Context, environmentId, tableLogicalName, columnLogicalName string) (string, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	values.Add("$select", "SchemaName,ReferencingAttribute")
+	values.Add("$filter", fmt.Sprintf("ReferencingAttribute eq '%s'", columnLogicalName))
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(LogicalName='%s')/ManyToOneRelationships", constants.DATAVERSE_API_VERSION, tableLogicalName), values)
+
+	relationships := oneToManyRelationshipArrayDto{}
+	if err := client.getMetadata(ctx, apiUrl, &relationships, fmt.Sprintf("table '%s'", tableLogicalName)); err != nil {
+		return "", err
+	}
+	if len(relationships.Value) == 0 {
+		return "", customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("relationship of lookup column '%s' not found", columnLogicalName))
+	}
+	return relationships.Value[0].SchemaName, nil
+}
+
+// getGlobalChoiceId returns the metadata id of a global choice by its name.
+func (client *client) getGlobalChoiceId(ctx context.Context, environmentHost, name string) (string, error) {
+	values := url.Values{}
+	values.Add("$select", "MetadataId,Name")
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/GlobalOptionSetDefinitions(Name='%s')", constants.DATAVERSE_API_VERSION, name), values)
+
+	optionSet := optionSetDto{}
+	if err := client.getMetadata(ctx, apiUrl, &optionSet, fmt.Sprintf("global choice '%s'", name)); err != nil {
+		return "", err
+	}
+	return optionSet.MetadataId, nil
+}
+
+// getMetadata reads a metadata definition into responseObj. A missing definition is returned as an ErrObjectNotFound error.
+func (client *client) getMetadata(ctx context.Context, apiUrl string, responseObj any, description string) error {
+	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, responseObj)
+	if err != nil {
+		return err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("%s not found", description))
+	}
+	return nil
+}
+
+func (client *client) deleteMetadata(ctx context.Context, apiUrl, description string) error {
+	resp, err := client.Api.Execute(ctx, nil, http.MethodDelete, apiUrl, nil, nil, []int{http.StatusNoContent, http.StatusNotFound, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("%s not found", description))
+	}
+	return nil
+}
+
+// setDefinitionValue sets a value in a raw metadata definition when it is set.
+func setDefinitionValue[T any](definition map[string]any, name string, value *T) {
+	if value != nil {
+		definition[name] = *value
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const testColumnId = "44444444-4444-4444-4444-444444444444"
+
+func TestUnitColumnDtoFromModel_FillsTypeDefaultsOnCreate(t *testing.T) {
+	newModel := func(columnType string) *ColumnResourceModel {
+		return &ColumnResourceModel{
+			SchemaName:        types.StringValue("cr123_Value"),
+			Type:              types.StringValue(columnType),
+			LanguageCode:      types.Int64Value(defaultLanguageCode),
+			DisplayName:       types.StringValue("Value"),
+			RequiredLevel:     types.StringValue("None"),
+			MaxLength:         types.Int64Unknown(),
+			Format:            types.StringUnknown(),
+			MinValue:          types.Float64Unknown(),
+			MaxValue:          types.Float64Unknown(),
+			Precision:         types.Int64Unknown(),
+			DateTimeBehavior:  types.StringUnknown(),
+			TrueLabel:         types.StringUnknown(),
+			FalseLabel:        types.StringUnknown(),
+			GlobalChoiceName:  types.StringNull(),
+			MaxSizeInKb:       types.Int64Unknown(),
+			CanStoreFullImage: types.BoolUnknown(),
+		}
+	}
+
+	column := columnDtoFromModel(newModel(columnTypeString), true)
+	require.Equal(t, int64(100), *column.MaxLength)
+	require.Equal(t, "Text", column.FormatName.Value)
+
+	column = columnDtoFromModel(newModel(columnTypeMemo), true)
+	require.Equal(t, int64(2000), *column.MaxLength)
+	require.Equal(t, "TextArea", *column.Format)
+
+	column = columnDtoFromModel(newModel(columnTypeMoney), true)
+	require.Nil(t, column.Precision)
+	require.Equal(t, int64(2), *column.PrecisionSource)
+
+	model := newModel(columnTypeMoney)
+	model.Precision = types.Int64Value(4)
+	column = columnDtoFromModel(model, true)
+	require.Equal(t, int64(4), *column.Precision)
+	require.Equal(t, int64(0), *column.PrecisionSource)
+
+	// Adding a precision to an existing column switches it from the precision of the currency to its own precision.
+	column = columnDtoFromModel(model, false)
+	require.Equal(t, int64(4), *column.Precision)
+	require.Equal(t, int64(0), *column.PrecisionSource)
+
+	column = columnDtoFromModel(newModel(columnTypeMoney), false)
+	require.Nil(t, column.Precision)
+	require.Nil(t, column.PrecisionSource)
+
+	column = columnDtoFromModel(newModel(columnTypeDateTime), true)
+	require.Equal(t, "DateAndTime", *column.Format)
+	require.Equal(t, "UserLocal", column.DateTimeBehavior.Value)
+
+	column = columnDtoFromModel(newModel(columnTypeBoolean), true)
+	require.Equal(t, "Yes", labelText(column.OptionSet.TrueOption.Label, defaultLanguageCode))
+	require.Equal(t, "No", labelText(column.OptionSet.FalseOption.Label, defaultLanguageCode))
+
+	column = columnDtoFromModel(newModel(columnTypeBoolean), false)
+	require.Nil(t, column.OptionSet)
+
+	model = newModel(columnTypeMultiSelectChoice)
+	model.GlobalChoiceName = types.StringValue("cr123_priority")
+	column = columnDtoFromModel(model, true)
+	require.Nil(t, column.OptionSet)
+	require.Equal(t, "cr123_priority", column.GlobalOptionSet.Name)
+
+	column = columnDtoFromModel(newModel(columnTypeImage), true)
+	require.Equal(t, int64(10240), *column.MaxSizeInKB)
+	require.False(t, *column.CanStoreFullImage)
+}
+
+func TestUnitCreateColumn_BindsGlobalChoice(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	httpmock.RegisterResponder("GET", metadataUrl("GlobalOptionSetDefinitions(Name='cr123_priority')", url.Values{"$select": {"MetadataId,Name"}}),
+		httpmock.NewStringResponder(http.StatusOK, `{"MetadataId":"66666666-6666-6666-6666-666666666666","Name":"cr123_priority"}`))
+
+	var created map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes", nil),
+		func(req *http.Request) (*http.Response, error) {
+			created = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes(LogicalName='cr123_priority')/Microsoft.Dynamics.CRM.PicklistAttributeMetadata", url.Values{"$expand": {"OptionSet"}}),
+		httpmock.NewStringResponder(http.StatusOK, `{"MetadataId":"44444444-4444-4444-4444-444444444444","LogicalName":"cr123_priority","SchemaName":"cr123_Priority","OptionSet":{"Name":"cr123_priority","IsGlobal":true}}`))
+
+	metadataClient := newTestMetadataClient()
+	column, err := metadataClient.CreateColumn(context.Background(), testEnvironmentId, "cr123_project", "", columnTypeChoice,
+		AttributeMetadataDto{
+			SchemaName:      "cr123_Priority",
+			DisplayName:     newLabel("Priority", defaultLanguageCode),
+			GlobalOptionSet: &optionSetDto{Name: "cr123_priority", IsGlobal: true},
+		}, nil)
+	require.NoError(t, err)
+	require.True(t, column.OptionSet.IsGlobal)
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.PicklistAttributeMetadata", created["@odata.type"])
+	require.Equal(t, "Picklist", created["AttributeType"])
+	require.Equal(t, "/GlobalOptionSetDefinitions(66666666-6666-6666-6666-666666666666)", created["GlobalOptionSet@odata.bind"])
+	require.NotContains(t, created, "GlobalOptionSet")
+	require.NotContains(t, created, "OptionSet")
+}
+
+func TestUnitUpdateColumn_AppliesOptionChangesWithActions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	columnResponse := "get_column_created.json"
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes("+testColumnId+")/Microsoft.Dynamics.CRM.PicklistAttributeMetadata", url.Values{"$expand": {"OptionSet"}}),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/"+columnResponse).String()), nil
+		})
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes("+testColumnId+")/Microsoft.Dynamics.CRM.PicklistAttributeMetadata", nil),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/get_column_created.json").String()))
+
+	var definition map[string]any
+	httpmock.RegisterResponder("PUT", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes("+testColumnId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			definition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	actions := []string{}
+	for _, action := range []string{"InsertOptionValue", "UpdateOptionValue", "DeleteOptionValue"} {
+		httpmock.RegisterResponder("POST", metadataUrl(action, nil),
+			func(req *http.Request) (*http.Response, error) {
+				body := readJsonBody(t, req)
+				require.Equal(t, "cr123_project", body["EntityLogicalName"])
+				require.Equal(t, "cr123_stage", body["AttributeLogicalName"])
+				actions = append(actions, action)
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			})
+	}
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		func(req *http.Request) (*http.Response, error) {
+			columnResponse = "get_column_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	metadataClient := newTestMetadataClient()
+	column, err := metadataClient.UpdateColumn(context.Background(), testEnvironmentId, "cr123_project", testColumnId, "", columnTypeChoice, defaultLanguageCode,
+		AttributeMetadataDto{
+			DisplayName:   newLabel("Stage", defaultLanguageCode),
+			RequiredLevel: &requiredLevelDto{Value: "ApplicationRequired"},
+			OptionSet: &optionSetDto{
+				Options: []optionDto{
+					{Value: 100000001, Label: newLabel("In Progress", defaultLanguageCode)},
+					{Value: 100000002, Label: newLabel("Completed", defaultLanguageCode)},
+				},
+			},
+		})
+	require.NoError(t, err)
+	require.Len(t, column.OptionSet.Options, 2)
+
+	require.Equal(t, []string{"DeleteOptionValue", "UpdateOptionValue", "InsertOptionValue"}, actions)
+	require.NotContains(t, definition, "OptionSet")
+	require.Equal(t, "Microsoft.Dynamics.CRM.PicklistAttributeMetadata", definition["@odata.type"])
+}
+
+func TestUnitGetColumn_DetectsColumnType(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes", url.Values{"$select": {"MetadataId,LogicalName,AttributeType,AttributeTypeName"}, "$filter": {"LogicalName eq 'cr123_stage'"}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/get_column_type.json").String()))
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes(LogicalName='cr123_stage')/Microsoft.Dynamics.CRM.PicklistAttributeMetadata", url.Values{"$expand": {"OptionSet"}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/get_column_created.json").String()))
+
+	metadataClient := newTestMetadataClient()
+	column, columnType, err := metadataClient.GetColumn(context.Background(), testEnvironmentId, "cr123_project", "", "cr123_stage", "")
+	require.NoError(t, err)
+	require.Equal(t, columnTypeChoice, columnType)
+	require.Equal(t, testColumnId, column.MetadataId)
+
+	columnType, err = columnTypeFromMetadata(&AttributeMetadataDto{AttributeType: "Virtual", AttributeTypeName: &attributeTypeNameDto{Value: "ImageType"}})
+	require.NoError(t, err)
+	require.Equal(t, columnTypeImage, columnType)
+
+	_, err = columnTypeFromMetadata(&AttributeMetadataDto{LogicalName: "cr123_customer", AttributeType: "Customer", AttributeTypeName: &attributeTypeNameDto{Value: "CustomerType"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,245 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/environment"
+)
+
+const defaultLanguageCode = 1033
+
+type client struct {
+	Api               *api.Client
+	environmentClient environment.Client
+}
+
+func newDataverseMetadataClient(apiClient *api.Client) client {
+	return client{
+		Api:               apiClient,
+		environmentClient: environment.NewEnvironmentClient(apiClient),
+	}
+}
+
+// metadataHeaders returns the headers of metadata write requests. Labels are merged, so translations
+// that are not managed by Terraform are kept, and new components are added to the solution when one is given.
+func metadataHeaders(solutionUniqueName string) http.Header {
+	headers := http.Header{}
+	headers.Set("MSCRM.MergeLabels", "true")
+	if solutionUniqueName != "" {
+		headers.Set("MSCRM.SolutionUniqueName", solutionUniqueName)
+	}
+	return headers
+}
+
+// PublishEntities publishes the customizations of the given tables, so that changes to their metadata become visible to apps.
+func (client *client) PublishEntities(ctx context.Context, environmentHost string, entityLogicalNames ...string) error {
+	return client.publishComponents(ctx, environmentHost, "entities", "entity", entityLogicalNames)
+}
+
+// PublishOptionSets publishes the customizations of the given global choices.
+func (client *client) PublishOptionSets(ctx context.Context, environmentHost string, optionSetNames ...string) error {
+	return client.publishComponents(ctx, environmentHost, "optionsets", "optionset", optionSetNames)
+}
+
+func (client *client) publishComponents(ctx context.Context, environmentHost, collectionElement, element string, names []string) error {
+	var parameterXml strings.Builder
+	parameterXml.WriteString("<importexportxml><" + collectionElement + ">")
+	for _, name := range names {
+		parameterXml.WriteString("<" + element + ">")
+		if err := xml.EscapeText(&parameterXml, []byte(name)); err != nil {
+			return err
+		}
+		parameterXml.WriteString("</" + element + ">")
+	}
+	parameterXml.WriteString("</" + collectionElement + "></importexportxml>")
+
+	return client.publishXml(ctx, environmentHost, parameterXml.String())
+}
+
+func (client *client) publishXml(ctx context.Context, environmentHost, parameterXml string) error {
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/PublishXml", constants.DATAVERSE_API_VERSION), nil)
+	body := map[string]string{
+		"ParameterXml": parameterXml,
+	}
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, nil, body, []int{http.StatusOK, http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to publish customizations: %w", err)
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+// newLabel returns a label with a single localized label, or nil when the text is empty.
+func newLabel(text string, languageCode int64) *labelDto {
+	if text == "" {
+		return nil
+	}
+	return &labelDto{
+		ODataType: "Microsoft.Dynamics.CRM.Label",
+		LocalizedLabels: []localizedLabelDto{
+			{
+				ODataType:    "Microsoft.Dynamics.CRM.LocalizedLabel",
+				Label:        text,
+				LanguageCode: languageCode,
+			},
+		},
+	}
+}
+
+// newTranslatedLabel returns a label with a localized label for the given language and for each of the translations,
+// or nil when the text is empty. Nil translations are not managed, so translations maintained outside of Terraform are kept.
+func newTranslatedLabel(text string, languageCode int64, translations map[int64]string) *labelDto {
+	label := newLabel(text, languageCode)
+	if label == nil {
+		return nil
+	}
+	label.translationsManaged = translations != nil
+	for _, translationLanguageCode := range slices.Sorted(maps.Keys(translations)) {
+		if translationLanguageCode == languageCode {
+			continue
+		}
+		label.LocalizedLabels = append(label.LocalizedLabels, localizedLabelDto{
+			ODataType:    "Microsoft.Dynamics.CRM.LocalizedLabel",
+			Label:        translations[translationLanguageCode],
+			LanguageCode: translationLanguageCode,
+		})
+	}
+	return label
+}
+
+// labelText returns the text of a label in the given language. When the label has no
+// translation for the language, the label of the user's language is returned.
+func labelText(label *labelDto, languageCode int64) string {
+	if text, ok := localizedLabelText(label, languageCode); ok {
+		return text
+	}
+	if label != nil && label.UserLocalizedLabel != nil {
+		return label.UserLocalizedLabel.Label
+	}
+	return ""
+}
+
+// localizedLabelText returns the text of a label in the given language, without falling back to the user's language.
+func localizedLabelText(label *labelDto, languageCode int64) (string, bool) {
+	if label == nil {
+		return "", false
+	}
+	for _, localizedLabel := range label.LocalizedLabels {
+		if localizedLabel.LanguageCode == languageCode {
+			return localizedLabel.Label, true
+		}
+	}
+	return "", false
+}
+
+// labelTranslations returns the texts of a label in all languages other than the given one. Empty texts are cleared translations and are skipped.
+func labelTranslations(label *labelDto, languageCode int64) map[int64]string {
+	translations := map[int64]string{}
+	if label == nil {
+		return translations
+	}
+	for _, localizedLabel := range label.LocalizedLabels {
+		if localizedLabel.LanguageCode != languageCode && localizedLabel.Label != "" {
+			translations[localizedLabel.LanguageCode] = localizedLabel.Label
+		}
+	}
+	return translations
+}
+
+// localizedLabelTexts returns the texts of a label in all of its languages, including empty texts of cleared translations.
+func localizedLabelTexts(label *labelDto) map[int64]string {
+	texts := map[int64]string{}
+	if label == nil {
+		return texts
+	}
+	for _, localizedLabel := range label.LocalizedLabels {
+		texts[localizedLabel.LanguageCode] = localizedLabel.Label
+	}
+	return texts
+}
+
+// reconcileTranslations returns the desired label with the translations of the current label that it doesn't have.
+// Labels are merged, so when the translations are managed the missing languages are added with an empty text to clear them,
+// and otherwise they are added with their current text to keep them.
+func reconcileTranslations(current, desired *labelDto, languageCode int64) *labelDto {
+	if current == nil || desired == nil {
+		return desired
+	}
+	reconciled := *desired
+	reconciled.LocalizedLabels = slices.Clone(desired.LocalizedLabels)
+	currentTranslations := labelTranslations(current, languageCode)
+	for _, translationLanguageCode := range slices.Sorted(maps.Keys(currentTranslations)) {
+		if _, ok := localizedLabelText(desired, translationLanguageCode); ok {
+			continue
+		}
+		text := currentTranslations[translationLanguageCode]
+		if desired.translationsManaged {
+			text = ""
+		}
+		reconciled.LocalizedLabels = append(reconciled.LocalizedLabels, localizedLabelDto{
+			ODataType:    "Microsoft.Dynamics.CRM.LocalizedLabel",
+			Label:        text,
+			LanguageCode: translationLanguageCode,
+		})
+	}
+	return &reconciled
+}
+
+// labelsEqual reports whether the current label has the same text as the desired label in the given language and the same translations.
+func labelsEqual(current, desired *labelDto, languageCode int64) bool {
+	return labelText(current, languageCode) == labelText(desired, languageCode) &&
+		maps.Equal(labelTranslations(current, languageCode), labelTranslations(desired, languageCode))
+}
+
+// labelBody returns a label as written in the raw metadata that is sent back with PUT requests.
+// Labels are merged, so an empty text is sent to clear the label in the given language.
+func labelBody(text string, languageCode int64) map[string]any {
+	return translatedLabelBody(text, languageCode, nil)
+}
+
+// translatedLabelBody returns a label with translations as written in the raw metadata that is sent back with PUT requests.
+func translatedLabelBody(text string, languageCode int64, translations map[int64]string) map[string]any {
+	localizedLabels := []any{
+		map[string]any{
+			"@odata.type":  "Microsoft.Dynamics.CRM.LocalizedLabel",
+			"Label":        text,
+			"LanguageCode": languageCode,
+		},
+	}
+	for _, translationLanguageCode := range slices.Sorted(maps.Keys(translations)) {
+		if translationLanguageCode == languageCode {
+			continue
+		}
+		localizedLabels = append(localizedLabels, map[string]any{
+			"@odata.type":  "Microsoft.Dynamics.CRM.LocalizedLabel",
+			"Label":        translations[translationLanguageCode],
+			"LanguageCode": translationLanguageCode,
+		})
+	}
+	return map[string]any{
+		"@odata.type":     "Microsoft.Dynamics.CRM.Label",
+		"LocalizedLabels": localizedLabels,
+	}
+}
+
+// getEntityIdFromResponse returns the metadata id from the `OData-EntityId` header of a create request,
+// for example `https://org.crm.dynamics.com/api/data/v9.2/EntityDefinitions(00000000-0000-0000-0000-000000000001)`.
+func getEntityIdFromResponse(resp *api.Response) (string, error) {
+	entityId := resp.GetHeader(constants.HEADER_ODATA_ENTITY_ID)
+	start, end := strings.LastIndex(entityId, "("), strings.LastIndex(entityId, ")")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("unexpected OData-EntityId header '%s'", entityId)
+	}
+	return entityId[start+1 : end], nil
+}
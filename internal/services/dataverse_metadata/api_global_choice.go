@@ -0,0 +1,123 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+// globalChoiceKey returns the key that addresses a global choice, either its metadata id or its name.
+func globalChoiceKey(globalChoiceId, name string) string {
+	if globalChoiceId != "" {
+		return globalChoiceId
+	}
+	return fmt.Sprintf("Name='%s'", name)
+}
+
+func globalChoiceUrl(environmentHost, key string) string {
+	return helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/GlobalOptionSetDefinitions(%s)", constants.DATAVERSE_API_VERSION, key), nil)
+}
+
+// CreateGlobalChoice creates a global choice with its options and publishes it.
+func (client *client) CreateGlobalChoice(ctx context.Context, environmentId, solutionUniqueName string, optionSet optionSetDto) (*optionSetDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	optionSet.ODataType = "Microsoft.Dynamics.CRM.OptionSetMetadata"
+	optionSet.IsGlobal = true
+	optionSet.OptionSetType = "Picklist"
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/GlobalOptionSetDefinitions", constants.DATAVERSE_API_VERSION), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, metadataHeaders(solutionUniqueName), optionSet, []int{http.StatusNoContent, http.StatusCreated, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create global choice '%s': %w", optionSet.Name, err)
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	globalChoiceId, err := getEntityIdFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return client.publishGlobalChoice(ctx, environmentHost, globalChoiceId, optionSet.Name)
+}
+
+// GetGlobalChoice returns a global choice with its options, either by its metadata id or by its name.
+func (client *client) GetGlobalChoice(ctx context.Context, environmentId, globalChoiceId, name string) (*optionSetDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	return client.getGlobalChoice(ctx, environmentHost, globalChoiceKey(globalChoiceId, name))
+}
+
+func (client *client) getGlobalChoice(ctx context.Context, environmentHost, key string) (*optionSetDto, error) {
+	optionSet := optionSetDto{}
+	if err := client.getMetadata(ctx, globalChoiceUrl(environmentHost, key), &optionSet, fmt.Sprintf("global choice %s", key)); err != nil {
+		return nil, err
+	}
+	return &optionSet, nil
+}
+
+// UpdateGlobalChoice updates the labels of a global choice and applies the option changes with option value actions,
+// so that records using the choice keep their values. The global choice is published afterwards.
+func (client *client) UpdateGlobalChoice(ctx context.Context, environmentId, globalChoiceId, solutionUniqueName string, languageCode int64, optionSet optionSetDto) (*optionSetDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := client.getGlobalChoice(ctx, environmentHost, globalChoiceId)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := client.getMetadataDefinition(ctx, globalChoiceUrl(environmentHost, globalChoiceId))
+	if err != nil {
+		return nil, err
+	}
+	definition["@odata.type"] = "Microsoft.Dynamics.CRM.OptionSetMetadata"
+	displayName := reconcileTranslations(current.DisplayName, optionSet.DisplayName, languageCode)
+	definition["DisplayName"] = translatedLabelBody(labelText(displayName, languageCode), languageCode, localizedLabelTexts(displayName))
+	definition["Description"] = labelBody(labelText(optionSet.Description, languageCode), languageCode)
+	// options are changed with option value actions below, they can't be changed by updating the definition.
+	delete(definition, "Options")
+
+	if err := client.putMetadataDefinition(ctx, globalChoiceUrl(environmentHost, globalChoiceId), solutionUniqueName, definition); err != nil {
+		return nil, fmt.Errorf("failed to update global choice '%s': %w", current.Name, err)
+	}
+
+	target := optionSetTarget{
+		OptionSetName:      current.Name,
+		SolutionUniqueName: solutionUniqueName,
+	}
+	if err := client.applyOptions(ctx, environmentHost, target, current.Options, optionSet.Options, languageCode); err != nil {
+		return nil, err
+	}
+	return client.publishGlobalChoice(ctx, environmentHost, globalChoiceId, current.Name)
+}
+
+// DeleteGlobalChoice deletes a global choice. Global choices that are used by columns can't be deleted.
+func (client *client) DeleteGlobalChoice(ctx context.Context, environmentId, globalChoiceId string) error {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+	return client.deleteMetadata(ctx, globalChoiceUrl(environmentHost, globalChoiceId), fmt.Sprintf("global choice %s", globalChoiceId))
+}
+
+func (client *client) publishGlobalChoice(ctx context.Context, environmentHost, globalChoiceId, name string) (*optionSetDto, error) {
+	if err := client.PublishOptionSets(ctx, environmentHost, name); err != nil {
+		return nil, err
+	}
+	return client.getGlobalChoice(ctx, environmentHost, globalChoiceId)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const testGlobalChoiceId = "66666666-6666-6666-6666-666666666666"
+
+func TestUnitUpdateGlobalChoice_AppliesOptionActionsInOrder(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	globalChoiceResponse := "get_global_choice_created.json"
+	httpmock.RegisterResponder("GET", metadataUrl("GlobalOptionSetDefinitions("+testGlobalChoiceId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Global_Choice_Validate_CRUD/"+globalChoiceResponse).String()), nil
+		})
+
+	var definition map[string]any
+	httpmock.RegisterResponder("PUT", metadataUrl("GlobalOptionSetDefinitions("+testGlobalChoiceId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "true", req.Header.Get("MSCRM.MergeLabels"))
+			definition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	actions := []string{}
+	bodies := map[string]map[string]any{}
+	for _, action := range []string{"InsertOptionValue", "UpdateOptionValue", "DeleteOptionValue", "OrderOption"} {
+		httpmock.RegisterResponder("POST", metadataUrl(action, nil),
+			func(req *http.Request) (*http.Response, error) {
+				body := readJsonBody(t, req)
+				require.Equal(t, "cr123_priority", body["OptionSetName"])
+				require.NotContains(t, body, "EntityLogicalName")
+				actions = append(actions, action)
+				bodies[action] = body
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			})
+	}
+
+	var published map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		func(req *http.Request) (*http.Response, error) {
+			published = readJsonBody(t, req)
+			globalChoiceResponse = "get_global_choice_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	metadataClient := newTestMetadataClient()
+	optionSet, err := metadataClient.UpdateGlobalChoice(context.Background(), testEnvironmentId, testGlobalChoiceId, "", defaultLanguageCode,
+		optionSetDto{
+			DisplayName: newTranslatedLabel("Priority", defaultLanguageCode, map[int64]string{1031: "Priorität"}),
+			Description: newLabel("Priority of work items", defaultLanguageCode),
+			Options: []optionDto{
+				{Value: 100000002, Label: newTranslatedLabel("Critical", defaultLanguageCode, map[int64]string{1031: "Kritisch"}), Description: newLabel("Needs immediate attention", defaultLanguageCode), Color: "#ff0000"},
+				{Value: 100000001, Label: newLabel("Medium", defaultLanguageCode)},
+				{Value: 100000003, Label: newLabel("Deferred", defaultLanguageCode)},
+			},
+		})
+	require.NoError(t, err)
+	require.Len(t, optionSet.Options, 3)
+
+	require.Equal(t, []string{"DeleteOptionValue", "UpdateOptionValue", "InsertOptionValue", "OrderOption"}, actions)
+	require.InDelta(t, 100000000, bodies["DeleteOptionValue"]["Value"], 0)
+	require.InDelta(t, 100000002, bodies["UpdateOptionValue"]["Value"], 0)
+	require.Equal(t, true, bodies["UpdateOptionValue"]["MergeLabels"])
+	label, ok := bodies["UpdateOptionValue"]["Label"].(map[string]any)
+	require.True(t, ok)
+	require.Len(t, label["LocalizedLabels"], 2)
+	require.InDelta(t, 100000003, bodies["InsertOptionValue"]["Value"], 0)
+	require.Equal(t, []any{float64(100000002), float64(100000001), float64(100000003)}, bodies["OrderOption"]["Values"])
+
+	require.NotContains(t, definition, "Options")
+	require.NotContains(t, definition, "@odata.context")
+	displayName, ok := definition["DisplayName"].(map[string]any)
+	require.True(t, ok)
+	require.Len(t, displayName["LocalizedLabels"], 2)
+
+	require.Equal(t, "<importexportxml><optionsets><optionset>cr123_priority</optionset></optionsets></importexportxml>", published["ParameterXml"])
+}
+
+func TestUnitDiffOptions_ComparesTranslations(t *testing.T) {
+	current := []optionDto{
+		{Value: 1, Label: newTranslatedLabel("High", defaultLanguageCode, map[int64]string{1031: "Hoch"})},
+		{Value: 2, Label: newLabel("Low", defaultLanguageCode), Color: "#00ff00"},
+	}
+	desired := []optionDto{
+		{Value: 1, Label: newTranslatedLabel("High", defaultLanguageCode, map[int64]string{1031: "Wichtig"})},
+		{Value: 2, Label: newLabel("Low", defaultLanguageCode), Color: "#00ff00"},
+	}
+
+	inserted, updated, deleted := diffOptions(current, desired, defaultLanguageCode)
+	require.Empty(t, inserted)
+	require.Empty(t, deleted)
+	require.Len(t, updated, 1)
+	require.Equal(t, int64(1), updated[0].Value)
+
+	// translations that are not managed are kept when labels are merged, so they are not a change.
+	desired[0].Label = newTranslatedLabel("High", defaultLanguageCode, nil)
+	_, updated, _ = diffOptions(current, desired, defaultLanguageCode)
+	require.Empty(t, updated)
+
+	// managed translations that are removed are cleared with an empty text.
+	desired[0].Label = newTranslatedLabel("High", defaultLanguageCode, map[int64]string{})
+	_, updated, _ = diffOptions(current, desired, defaultLanguageCode)
+	require.Len(t, updated, 1)
+	text, ok := localizedLabelText(updated[0].Label, 1031)
+	require.True(t, ok)
+	require.Empty(t, text)
+
+	require.Equal(t, []int64{2, 3}, optionOrderAfterDiff(current, []optionDto{{Value: 3}}, []int64{1}))
+}
+
+func TestUnitUpdateGlobalChoice_ClearsRemovedTranslations(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	httpmock.RegisterResponder("GET", metadataUrl("GlobalOptionSetDefinitions("+testGlobalChoiceId+")", nil),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Global_Choice_Validate_CRUD/get_global_choice_updated.json").String()))
+
+	var definition map[string]any
+	httpmock.RegisterResponder("PUT", metadataUrl("GlobalOptionSetDefinitions("+testGlobalChoiceId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			definition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	updates := []map[string]any{}
+	httpmock.RegisterResponder("POST", metadataUrl("UpdateOptionValue", nil),
+		func(req *http.Request) (*http.Response, error) {
+			updates = append(updates, readJsonBody(t, req))
+			return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+		})
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	metadataClient := newTestMetadataClient()
+	_, err := metadataClient.UpdateGlobalChoice(context.Background(), testEnvironmentId, testGlobalChoiceId, "", defaultLanguageCode,
+		optionSetDto{
+			DisplayName: newTranslatedLabel("Priority", defaultLanguageCode, map[int64]string{}),
+			Description: newLabel("Priority of work items", defaultLanguageCode),
+			Options: []optionDto{
+				{Value: 100000002, Label: newTranslatedLabel("Critical", defaultLanguageCode, map[int64]string{}), Description: newLabel("Needs immediate attention", defaultLanguageCode), Color: "#ff0000"},
+				{Value: 100000001, Label: newLabel("Medium", defaultLanguageCode)},
+				{Value: 100000003, Label: newLabel("Deferred", defaultLanguageCode)},
+			},
+		})
+	require.NoError(t, err)
+
+	displayName, ok := definition["DisplayName"].(map[string]any)
+	require.True(t, ok)
+	require.Contains(t, displayName["LocalizedLabels"], map[string]any{"@odata.type": "Microsoft.Dynamics.CRM.LocalizedLabel", "Label": "", "LanguageCode": float64(1031)})
+
+	require.Len(t, updates, 1)
+	require.InDelta(t, 100000002, updates[0]["Value"], 0)
+	label, ok := updates[0]["Label"].(map[string]any)
+	require.True(t, ok)
+	require.Contains(t, label["LocalizedLabels"], map[string]any{"@odata.type": "Microsoft.Dynamics.CRM.LocalizedLabel", "Label": "", "LanguageCode": float64(1031)})
+}
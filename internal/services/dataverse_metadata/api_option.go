@@ -0,0 +1,172 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+// optionSetTarget addresses the options of either a global choice, by OptionSetName,
+// or of a local choice or yes/no column, by EntityLogicalName and AttributeLogicalName.
+type optionSetTarget struct {
+	OptionSetName        string
+	EntityLogicalName    string
+	AttributeLogicalName string
+	SolutionUniqueName   string
+}
+
+func (target optionSetTarget) parameters() map[string]any {
+	parameters := map[string]any{}
+	if target.OptionSetName != "" {
+		parameters["OptionSetName"] = target.OptionSetName
+	}
+	if target.EntityLogicalName != "" {
+		parameters["EntityLogicalName"] = target.EntityLogicalName
+	}
+	if target.AttributeLogicalName != "" {
+		parameters["AttributeLogicalName"] = target.AttributeLogicalName
+	}
+	if target.SolutionUniqueName != "" {
+		parameters["SolutionUniqueName"] = target.SolutionUniqueName
+	}
+	return parameters
+}
+
+// InsertOptionValue adds an option with the given value to an option set.
+func (client *client) InsertOptionValue(ctx context.Context, environmentHost string, target optionSetTarget, option optionDto) error {
+	parameters := target.parameters()
+	parameters["Value"] = option.Value
+	parameters["Label"] = option.Label
+	if option.Description != nil {
+		parameters["Description"] = option.Description
+	}
+	if option.Color != "" {
+		parameters["Color"] = option.Color
+	}
+	return client.executeOptionAction(ctx, environmentHost, "InsertOptionValue", parameters)
+}
+
+// UpdateOptionValue updates the label of an option. Labels are merged, so translations that are not part of the option are kept
+// and translations with an empty text are cleared.
+func (client *client) UpdateOptionValue(ctx context.Context, environmentHost string, target optionSetTarget, option optionDto) error {
+	parameters := target.parameters()
+	parameters["Value"] = option.Value
+	parameters["Label"] = option.Label
+	parameters["MergeLabels"] = true
+	if option.Description != nil {
+		parameters["Description"] = option.Description
+	}
+	if option.Color != "" {
+		parameters["Color"] = option.Color
+	}
+	return client.executeOptionAction(ctx, environmentHost, "UpdateOptionValue", parameters)
+}
+
+// DeleteOptionValue removes an option from an option set.
+func (client *client) DeleteOptionValue(ctx context.Context, environmentHost string, target optionSetTarget, value int64) error {
+	parameters := target.parameters()
+	delete(parameters, "SolutionUniqueName")
+	parameters["Value"] = value
+	return client.executeOptionAction(ctx, environmentHost, "DeleteOptionValue", parameters)
+}
+
+// OrderOption sets the order of the options of an option set.
+func (client *client) OrderOption(ctx context.Context, environmentHost string, target optionSetTarget, values []int64) error {
+	parameters := target.parameters()
+	parameters["Values"] = values
+	return client.executeOptionAction(ctx, environmentHost, "OrderOption", parameters)
+}
+
+// applyOptions inserts, updates, deletes and orders the options of an option set, so that it contains the desired options in the desired order.
+func (client *client) applyOptions(ctx context.Context, environmentHost string, target optionSetTarget, current, desired []optionDto, languageCode int64) error {
+	inserted, updated, deleted := diffOptions(current, desired, languageCode)
+	for _, value := range deleted {
+		if err := client.DeleteOptionValue(ctx, environmentHost, target, value); err != nil {
+			return err
+		}
+	}
+	for _, option := range updated {
+		if err := client.UpdateOptionValue(ctx, environmentHost, target, option); err != nil {
+			return err
+		}
+	}
+	for _, option := range inserted {
+		if err := client.InsertOptionValue(ctx, environmentHost, target, option); err != nil {
+			return err
+		}
+	}
+
+	desiredOrder := make([]int64, 0, len(desired))
+	for _, option := range desired {
+		desiredOrder = append(desiredOrder, option.Value)
+	}
+	if !slices.Equal(optionOrderAfterDiff(current, inserted, deleted), desiredOrder) {
+		return client.OrderOption(ctx, environmentHost, target, desiredOrder)
+	}
+	return nil
+}
+
+func (client *client) executeOptionAction(ctx context.Context, environmentHost, action string, parameters map[string]any) error {
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/%s", constants.DATAVERSE_API_VERSION, action), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, nil, parameters, []int{http.StatusOK, http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s for option %v: %w", action, parameters["Value"], err)
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+// diffOptions returns the options that have to be inserted, updated or deleted to change the options of an option set from current to desired.
+// The labels of existing options are reconciled with their current translations, see reconcileTranslations.
+func diffOptions(current, desired []optionDto, languageCode int64) (inserted, updated []optionDto, deleted []int64) {
+	currentByValue := map[int64]optionDto{}
+	for _, option := range current {
+		currentByValue[option.Value] = option
+	}
+	desiredValues := map[int64]bool{}
+	for _, option := range desired {
+		desiredValues[option.Value] = true
+		existing, exists := currentByValue[option.Value]
+		if exists {
+			option.Label = reconcileTranslations(existing.Label, option.Label, languageCode)
+		}
+		switch {
+		case !exists:
+			inserted = append(inserted, option)
+		case !optionEqual(existing, option, languageCode):
+			updated = append(updated, option)
+		}
+	}
+	for _, option := range current {
+		if !desiredValues[option.Value] {
+			deleted = append(deleted, option.Value)
+		}
+	}
+	return inserted, updated, deleted
+}
+
+func optionEqual(current, desired optionDto, languageCode int64) bool {
+	return labelsEqual(current.Label, desired.Label, languageCode) &&
+		labelsEqual(current.Description, desired.Description, languageCode) &&
+		current.Color == desired.Color
+}
+
+// optionOrderAfterDiff returns the order of the option values after applying a diff, when inserted options are added to the end.
+func optionOrderAfterDiff(current []optionDto, inserted []optionDto, deleted []int64) []int64 {
+	values := []int64{}
+	for _, option := range current {
+		if !slices.Contains(deleted, option.Value) {
+			values = append(values, option.Value)
+		}
+	}
+	for _, option := range inserted {
+		values = append(values, option.Value)
+	}
+	return values
+}
@@ -0,0 +1,210 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+const (
+	relationshipTypeOneToMany  = "OneToManyRelationship"
+	relationshipTypeManyToMany = "ManyToManyRelationship"
+)
+
+// relationshipKey returns the key that addresses a relationship, either its metadata id or its schema name.
+func relationshipKey(relationshipId, schemaName string) string {
+	if relationshipId != "" {
+		return relationshipId
+	}
+	return fmt.Sprintf("SchemaName='%s'", schemaName)
+}
+
+func relationshipUrl(environmentHost, key, metadataType string, query url.Values) string {
+	path := fmt.Sprintf("/api/data/%s/RelationshipDefinitions(%s)", constants.DATAVERSE_API_VERSION, key)
+	if metadataType != "" {
+		path += "/Microsoft.Dynamics.CRM." + metadataType
+	}
+	return helpers.BuildDataverseApiUrl(environmentHost, path, query)
+}
+
+// CreateOneToManyRelationship creates a one-to-many relationship together with its lookup column on the referencing table
+// and publishes both tables.
+func (client *client) CreateOneToManyRelationship(ctx context.Context, environmentId, solutionUniqueName string, relationship oneToManyRelationshipDto) (*oneToManyRelationshipDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+	if relationship.Lookup == nil {
+		return nil, fmt.Errorf("relationship '%s' requires a lookup column", relationship.SchemaName)
+	}
+
+	lookup := *relationship.Lookup
+	info := columnTypes[columnTypeLookup]
+	lookup.ODataType = "Microsoft.Dynamics.CRM." + info.MetadataType
+	lookup.AttributeType = info.AttributeType
+	lookup.AttributeTypeName = &attributeTypeNameDto{Value: info.AttributeTypeName}
+	if err := client.createLookupRelationship(ctx, environmentHost, solutionUniqueName, lookup, relationship); err != nil {
+		return nil, err
+	}
+
+	if err := client.PublishEntities(ctx, environmentHost, relationship.ReferencedEntity, relationship.ReferencingEntity); err != nil {
+		return nil, err
+	}
+	return client.getOneToManyRelationship(ctx, environmentHost, relationshipKey("", relationship.SchemaName))
+}
+
+// CreateManyToManyRelationship creates a many-to-many relationship with its intersect table and publishes both tables.
+func (client *client) CreateManyToManyRelationship(ctx context.Context, environmentId, solutionUniqueName string, relationship manyToManyRelationshipDto) (*manyToManyRelationshipDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	relationship.ODataType = "Microsoft.Dynamics.CRM.ManyToManyRelationshipMetadata"
+	relationshipId, err := client.createRelationship(ctx, environmentHost, solutionUniqueName, relationship.SchemaName, relationship)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.PublishEntities(ctx, environmentHost, relationship.Entity1LogicalName, relationship.Entity2LogicalName); err != nil {
+		return nil, err
+	}
+	return client.getManyToManyRelationship(ctx, environmentHost, relationshipId)
+}
+
+func (client *client) createRelationship(ctx context.Context, environmentHost, solutionUniqueName, schemaName string, relationship any) (string, error) {
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/RelationshipDefinitions", constants.DATAVERSE_API_VERSION), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, metadataHeaders(solutionUniqueName), relationship, []int{http.StatusNoContent, http.StatusCreated, http.StatusForbidden}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create relationship '%s': %w", schemaName, err)
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return "", err
+	}
+	return getEntityIdFromResponse(resp)
+}
+
+// GetRelationship returns a relationship, either by its metadata id or by its schema name. Depending on the type
+// of the relationship, either the one-to-many relationship with its lookup column or the many-to-many relationship is returned.
+func (client *client) GetRelationship(ctx context.Context, environmentId, relationshipId, schemaName string) (*oneToManyRelationshipDto, *manyToManyRelationshipDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := relationshipKey(relationshipId, schemaName)
+	values := url.Values{}
+	values.Add("$select", "SchemaName,RelationshipType")
+	relationshipType := relationshipTypeDto{}
+	if err := client.getMetadata(ctx, relationshipUrl(environmentHost, key, "", values), &relationshipType, fmt.Sprintf("relationship %s", key)); err != nil {
+		return nil, nil, err
+	}
+
+	switch relationshipType.RelationshipType {
+	case relationshipTypeOneToMany:
+		oneToMany, err := client.getOneToManyRelationship(ctx, environmentHost, key)
+		return oneToMany, nil, err
+	case relationshipTypeManyToMany:
+		manyToMany, err := client.getManyToManyRelationship(ctx, environmentHost, key)
+		return nil, manyToMany, err
+	default:
+		return nil, nil, fmt.Errorf("relationship '%s' has type '%s', which is not supported", relationshipType.SchemaName, relationshipType.RelationshipType)
+	}
+}
+
+func (client *client) getOneToManyRelationship(ctx context.Context, environmentHost, key string) (*oneToManyRelationshipDto, error) {
+	relationship := oneToManyRelationshipDto{}
+	if err := client.getMetadata(ctx, relationshipUrl(environmentHost, key, "OneToManyRelationshipMetadata", nil), &relationship, fmt.Sprintf("relationship %s", key)); err != nil {
+		return nil, err
+	}
+
+	lookup, err := client.getColumn(ctx, environmentHost, relationship.ReferencingEntity, columnKey("", relationship.ReferencingAttribute), columnTypeLookup)
+	if err != nil {
+		return nil, err
+	}
+	relationship.Lookup = lookup
+	return &relationship, nil
+}
+
+func (client *client) getManyToManyRelationship(ctx context.Context, environmentHost, key string) (*manyToManyRelationshipDto, error) {
+	relationship := manyToManyRelationshipDto{}
+	if err := client.getMetadata(ctx, relationshipUrl(environmentHost, key, "ManyToManyRelationshipMetadata", nil), &relationship, fmt.Sprintf("relationship %s", key)); err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// UpdateOneToManyRelationship updates the cascade configuration of a one-to-many relationship and the labels
+// of its lookup column, and publishes both tables.
+func (client *client) UpdateOneToManyRelationship(ctx context.Context, environmentId, relationshipId, solutionUniqueName string, languageCode int64, relationship oneToManyRelationshipDto) (*oneToManyRelationshipDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := client.getOneToManyRelationship(ctx, environmentHost, relationshipId)
+	if err != nil {
+		return nil, err
+	}
+
+	if relationship.CascadeConfiguration != nil && *relationship.CascadeConfiguration != cascadeConfigurationWithoutRollupView(current.CascadeConfiguration) {
+		definition, err := client.getMetadataDefinition(ctx, relationshipUrl(environmentHost, relationshipId, "OneToManyRelationshipMetadata", nil))
+		if err != nil {
+			return nil, err
+		}
+		definition["@odata.type"] = "Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata"
+		cascadeConfiguration, ok := definition["CascadeConfiguration"].(map[string]any)
+		if !ok {
+			cascadeConfiguration = map[string]any{}
+		}
+		cascadeConfiguration["Assign"] = relationship.CascadeConfiguration.Assign
+		cascadeConfiguration["Delete"] = relationship.CascadeConfiguration.Delete
+		cascadeConfiguration["Merge"] = relationship.CascadeConfiguration.Merge
+		cascadeConfiguration["Reparent"] = relationship.CascadeConfiguration.Reparent
+		cascadeConfiguration["Share"] = relationship.CascadeConfiguration.Share
+		cascadeConfiguration["Unshare"] = relationship.CascadeConfiguration.Unshare
+		definition["CascadeConfiguration"] = cascadeConfiguration
+
+		if err := client.putMetadataDefinition(ctx, relationshipUrl(environmentHost, relationshipId, "", nil), solutionUniqueName, definition); err != nil {
+			return nil, fmt.Errorf("failed to update relationship '%s': %w", current.SchemaName, err)
+		}
+	}
+
+	if relationship.Lookup != nil {
+		if err := client.updateColumn(ctx, environmentHost, current.ReferencingEntity, columnKey("", current.ReferencingAttribute), solutionUniqueName, columnTypeLookup, languageCode, *relationship.Lookup); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := client.PublishEntities(ctx, environmentHost, current.ReferencedEntity, current.ReferencingEntity); err != nil {
+		return nil, err
+	}
+	return client.getOneToManyRelationship(ctx, environmentHost, relationshipId)
+}
+
+// DeleteRelationship deletes a relationship. Deleting a one-to-many relationship deletes its lookup column,
+// deleting a many-to-many relationship deletes its intersect table.
+func (client *client) DeleteRelationship(ctx context.Context, environmentId, relationshipId string) error {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+	return client.deleteMetadata(ctx, relationshipUrl(environmentHost, relationshipId, "", nil), fmt.Sprintf("relationship %s", relationshipId))
+}
+
+// cascadeConfigurationWithoutRollupView returns the cascade configuration that can be configured, so that it can be compared with the desired one.
+func cascadeConfigurationWithoutRollupView(cascadeConfiguration *cascadeConfigurationDto) cascadeConfigurationDto {
+	if cascadeConfiguration == nil {
+		return cascadeConfigurationDto{}
+	}
+	result := *cascadeConfiguration
+	result.RollupView = ""
+	return result
+}
@@ -0,0 +1,190 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/require"
+)
+
+const testRelationshipId = "77777777-7777-7777-7777-777777777777"
+
+func registerRelationshipReadMocks(key string, relationshipFile, lookupColumnFile *string) {
+	httpmock.RegisterResponder("GET", metadataUrl("RelationshipDefinitions("+key+")/Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata", nil),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/"+*relationshipFile).String()), nil
+		})
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes(LogicalName='cr123_accountid')/Microsoft.Dynamics.CRM.LookupAttributeMetadata", nil),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/"+*lookupColumnFile).String()), nil
+		})
+}
+
+func TestUnitCreateOneToManyRelationship_CreatesLookupColumn(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(LogicalName='account')", url.Values{"$select": {tableSelect}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/get_account_table.json").String()))
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions(70000000-0000-0000-0000-000000000001)/Attributes(LogicalName='name')/Microsoft.Dynamics.CRM.StringAttributeMetadata", url.Values{"$select": {primaryNameColumnSelect}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/get_account_primary_name_column.json").String()))
+
+	var created map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("RelationshipDefinitions", nil),
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "ContosoCore", req.Header.Get("MSCRM.SolutionUniqueName"))
+			created = readJsonBody(t, req)
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("https://%s/api/data/v9.2/RelationshipDefinitions(%s)", testEnvironmentHost, testRelationshipId))
+			return resp, nil
+		})
+
+	var published map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		func(req *http.Request) (*http.Response, error) {
+			published = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	relationshipFile, lookupColumnFile := "get_relationship_created.json", "get_lookup_column_created.json"
+	registerRelationshipReadMocks("SchemaName='cr123_account_cr123_project'", &relationshipFile, &lookupColumnFile)
+
+	metadataClient := newTestMetadataClient()
+	relationship, err := metadataClient.CreateOneToManyRelationship(context.Background(), testEnvironmentId, "ContosoCore", oneToManyRelationshipDto{
+		SchemaName:        "cr123_account_cr123_project",
+		ReferencedEntity:  "account",
+		ReferencingEntity: "cr123_project",
+		Lookup: &AttributeMetadataDto{
+			SchemaName:    "cr123_AccountId",
+			DisplayName:   newLabel("Account", defaultLanguageCode),
+			RequiredLevel: &requiredLevelDto{Value: "None"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, testRelationshipId, relationship.MetadataId)
+	require.Equal(t, "cr123_accountid", relationship.Lookup.LogicalName)
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata", created["@odata.type"])
+	require.Equal(t, "accountid", created["ReferencedAttribute"])
+	require.Equal(t, map[string]any{"Assign": "NoCascade", "Delete": "RemoveLink", "Merge": "NoCascade", "Reparent": "NoCascade", "Share": "NoCascade", "Unshare": "NoCascade"}, created["CascadeConfiguration"])
+	lookup, ok := created["Lookup"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "Microsoft.Dynamics.CRM.LookupAttributeMetadata", lookup["@odata.type"])
+	require.Equal(t, "Lookup", lookup["AttributeType"])
+
+	require.Equal(t, "<importexportxml><entities><entity>account</entity><entity>cr123_project</entity></entities></importexportxml>", published["ParameterXml"])
+}
+
+func TestUnitCreateManyToManyRelationship(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	var created map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("RelationshipDefinitions", nil),
+		func(req *http.Request) (*http.Response, error) {
+			created = readJsonBody(t, req)
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("https://%s/api/data/v9.2/RelationshipDefinitions(%s)", testEnvironmentHost, testRelationshipId))
+			return resp, nil
+		})
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+	httpmock.RegisterResponder("GET", metadataUrl("RelationshipDefinitions("+testRelationshipId+")/Microsoft.Dynamics.CRM.ManyToManyRelationshipMetadata", nil),
+		httpmock.NewStringResponder(http.StatusOK, `{
+  "MetadataId":"77777777-7777-7777-7777-777777777777",
+  "SchemaName":"cr123_project_contact",
+  "Entity1LogicalName":"cr123_project",
+  "Entity2LogicalName":"contact",
+  "IntersectEntityName":"cr123_project_contact"
+}`))
+
+	metadataClient := newTestMetadataClient()
+	relationship, err := metadataClient.CreateManyToManyRelationship(context.Background(), testEnvironmentId, "", manyToManyRelationshipDto{
+		SchemaName:          "cr123_project_contact",
+		Entity1LogicalName:  "cr123_project",
+		Entity2LogicalName:  "contact",
+		IntersectEntityName: "cr123_project_contact",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "cr123_project_contact", relationship.IntersectEntityName)
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.ManyToManyRelationshipMetadata", created["@odata.type"])
+	require.Equal(t, "cr123_project_contact", created["IntersectEntityName"])
+}
+
+func TestUnitUpdateOneToManyRelationship_UpdatesCascadeConfigurationAndLookup(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	relationshipFile, lookupColumnFile := "get_relationship_created.json", "get_lookup_column_created.json"
+	registerRelationshipReadMocks(testRelationshipId, &relationshipFile, &lookupColumnFile)
+
+	var relationshipDefinition, lookupDefinition map[string]any
+	httpmock.RegisterResponder("PUT", metadataUrl("RelationshipDefinitions("+testRelationshipId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			relationshipDefinition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder("PUT", metadataUrl("EntityDefinitions(LogicalName='cr123_project')/Attributes(LogicalName='cr123_accountid')", nil),
+		func(req *http.Request) (*http.Response, error) {
+			lookupDefinition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		func(req *http.Request) (*http.Response, error) {
+			relationshipFile, lookupColumnFile = "get_relationship_updated.json", "get_lookup_column_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	metadataClient := newTestMetadataClient()
+	relationship, err := metadataClient.UpdateOneToManyRelationship(context.Background(), testEnvironmentId, testRelationshipId, "", defaultLanguageCode, oneToManyRelationshipDto{
+		CascadeConfiguration: &cascadeConfigurationDto{Assign: "NoCascade", Delete: "Restrict", Merge: "NoCascade", Reparent: "NoCascade", Share: "Cascade", Unshare: "Cascade"},
+		Lookup: &AttributeMetadataDto{
+			DisplayName:   newLabel("Customer", defaultLanguageCode),
+			RequiredLevel: &requiredLevelDto{Value: "ApplicationRequired"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Restrict", relationship.CascadeConfiguration.Delete)
+	require.Equal(t, "Customer", labelText(relationship.Lookup.DisplayName, defaultLanguageCode))
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata", relationshipDefinition["@odata.type"])
+	require.Equal(t, true, relationshipDefinition["IsCustomRelationship"])
+	require.Equal(t, map[string]any{"Assign": "NoCascade", "Delete": "Restrict", "Merge": "NoCascade", "Reparent": "NoCascade", "Share": "Cascade", "Unshare": "Cascade", "RollupView": "NoCascade"}, relationshipDefinition["CascadeConfiguration"])
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.LookupAttributeMetadata", lookupDefinition["@odata.type"])
+	require.Equal(t, map[string]any{"Value": "ApplicationRequired", "CanBeChanged": true}, lookupDefinition["RequiredLevel"])
+}
+
+func TestUnitUpdateOneToManyRelationship_SkipsUnchangedCascadeConfiguration(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+
+	relationshipFile, lookupColumnFile := "get_relationship_created.json", "get_lookup_column_created.json"
+	registerRelationshipReadMocks(testRelationshipId, &relationshipFile, &lookupColumnFile)
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	metadataClient := newTestMetadataClient()
+	_, err := metadataClient.UpdateOneToManyRelationship(context.Background(), testEnvironmentId, testRelationshipId, "", defaultLanguageCode, oneToManyRelationshipDto{
+		CascadeConfiguration: defaultCascadeConfiguration(),
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+const tableSelect = "MetadataId,LogicalName,SchemaName,EntitySetName,DisplayName,DisplayCollectionName,Description,OwnershipType,HasActivities,HasNotes,IsActivity,IsCustomEntity,IsAuditEnabled,ChangeTrackingEnabled,PrimaryIdAttribute,PrimaryNameAttribute"
+
+const primaryNameColumnSelect = "MetadataId,LogicalName,SchemaName,AttributeType,MaxLength,IsPrimaryName,DisplayName,Description,RequiredLevel"
+
+// tableKey returns the key that addresses a table in EntityDefinitions, either its metadata id or its logical name.
+func tableKey(tableId, logicalName string) string {
+	if tableId != "" {
+		return tableId
+	}
+	return fmt.Sprintf("LogicalName='%s'", logicalName)
+}
+
+// CreateTable creates a custom table together with its primary name column and publishes it.
+func (client *client) CreateTable(ctx context.Context, environmentId, solutionUniqueName string, table entityMetadataDto, primaryNameColumn AttributeMetadataDto) (*entityMetadataDto, *AttributeMetadataDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	table.ODataType = "Microsoft.Dynamics.CRM.EntityMetadata"
+	primaryNameColumn.ODataType = "Microsoft.Dynamics.CRM.StringAttributeMetadata"
+	primaryNameColumn.AttributeType = "String"
+	primaryNameColumn.FormatName = &formatNameDto{Value: "Text"}
+	primaryNameColumn.IsPrimaryName = true
+	table.Attributes = []AttributeMetadataDto{primaryNameColumn}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions", constants.DATAVERSE_API_VERSION), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, metadataHeaders(solutionUniqueName), table, []int{http.StatusNoContent, http.StatusCreated, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create table '%s': %w", table.SchemaName, err)
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, nil, err
+	}
+
+	tableId, err := getEntityIdFromResponse(resp)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client.publishTable(ctx, environmentHost, tableId)
+}
+
+// GetTable returns a table and its primary name column. The table is addressed by its metadata id or, when the id is empty, by its logical name.
+func (client *client) GetTable(ctx context.Context, environmentId, tableId, logicalName string) (*entityMetadataDto, *AttributeMetadataDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client.getTable(ctx, environmentHost, tableKey(tableId, logicalName))
+}
+
+func (client *client) getTable(ctx context.Context, environmentHost, key string) (*entityMetadataDto, *AttributeMetadataDto, error) {
+	values := url.Values{}
+	values.Add("$select", tableSelect)
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(%s)", constants.DATAVERSE_API_VERSION, key), values)
+
+	table := entityMetadataDto{}
+	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &table)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return nil, nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("table '%s' not found", key))
+	}
+
+	values = url.Values{}
+	values.Add("$select", primaryNameColumnSelect)
+	apiUrl = helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(%s)/Attributes(LogicalName='%s')/Microsoft.Dynamics.CRM.StringAttributeMetadata", constants.DATAVERSE_API_VERSION, table.MetadataId, table.PrimaryNameAttribute), values)
+
+	primaryNameColumn := AttributeMetadataDto{}
+	resp, err = client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &primaryNameColumn)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return nil, nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("primary name column '%s' of table '%s' not found", table.PrimaryNameAttribute, table.LogicalName))
+	}
+
+	return &table, &primaryNameColumn, nil
+}
+
+// UpdateTable updates the labels and flags of a table and its primary name column, and publishes the table.
+// Dataverse only accepts complete metadata definitions, so the current definitions are read and sent back with the changes applied.
+func (client *client) UpdateTable(ctx context.Context, environmentId, tableId, solutionUniqueName string, languageCode int64, table entityMetadataDto, primaryNameColumn AttributeMetadataDto) (*entityMetadataDto, *AttributeMetadataDto, error) {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tableUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(%s)", constants.DATAVERSE_API_VERSION, tableId), nil)
+	definition, err := client.getMetadataDefinition(ctx, tableUrl)
+	if err != nil {
+		return nil, nil, err
+	}
+	definition["@odata.type"] = "Microsoft.Dynamics.CRM.EntityMetadata"
+	definition["DisplayName"] = labelBody(labelText(table.DisplayName, languageCode), languageCode)
+	definition["DisplayCollectionName"] = labelBody(labelText(table.DisplayCollectionName, languageCode), languageCode)
+	definition["Description"] = labelBody(labelText(table.Description, languageCode), languageCode)
+	definition["HasActivities"] = table.HasActivities
+	definition["HasNotes"] = table.HasNotes
+	if table.ChangeTrackingEnabled != nil {
+		definition["ChangeTrackingEnabled"] = *table.ChangeTrackingEnabled
+	}
+	if table.IsAuditEnabled != nil {
+		setManagedPropertyValue(definition, "IsAuditEnabled", table.IsAuditEnabled.Value)
+	}
+	if err := client.putMetadataDefinition(ctx, tableUrl, solutionUniqueName, definition); err != nil {
+		return nil, nil, fmt.Errorf("failed to update table '%s': %w", tableId, err)
+	}
+
+	primaryNameAttribute, ok := definition["PrimaryNameAttribute"].(string)
+	if !ok {
+		return nil, nil, fmt.Errorf("PrimaryNameAttribute of table '%s' is missing or not a string", tableId)
+	}
+
+	columnUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(%s)/Attributes(LogicalName='%s')", constants.DATAVERSE_API_VERSION, tableId, primaryNameAttribute), nil)
+	columnDefinition, err := client.getMetadataDefinition(ctx, columnUrl+"/Microsoft.Dynamics.CRM.StringAttributeMetadata")
+	if err != nil {
+		return nil, nil, err
+	}
+	columnDefinition["@odata.type"] = "Microsoft.Dynamics.CRM.StringAttributeMetadata"
+	columnDefinition["DisplayName"] = labelBody(labelText(primaryNameColumn.DisplayName, languageCode), languageCode)
+	columnDefinition["Description"] = labelBody(labelText(primaryNameColumn.Description, languageCode), languageCode)
+	columnDefinition["MaxLength"] = primaryNameColumn.MaxLength
+	if primaryNameColumn.RequiredLevel != nil {
+		setManagedPropertyValue(columnDefinition, "RequiredLevel", primaryNameColumn.RequiredLevel.Value)
+	}
+	if err := client.putMetadataDefinition(ctx, columnUrl, solutionUniqueName, columnDefinition); err != nil {
+		return nil, nil, fmt.Errorf("failed to update primary name column of table '%s': %w", tableId, err)
+	}
+
+	return client.publishTable(ctx, environmentHost, tableId)
+}
+
+// DeleteTable deletes a custom table with all of its columns and data.
+func (client *client) DeleteTable(ctx context.Context, environmentId, tableId string) error {
+	environmentHost, err := client.environmentClient.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/EntityDefinitions(%s)", constants.DATAVERSE_API_VERSION, tableId), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodDelete, apiUrl, nil, nil, []int{http.StatusNoContent, http.StatusNotFound, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("table '%s' not found", tableId))
+	}
+	return nil
+}
+
+func (client *client) publishTable(ctx context.Context, environmentHost, tableId string) (*entityMetadataDto, *AttributeMetadataDto, error) {
+	table, primaryNameColumn, err := client.getTable(ctx, environmentHost, tableId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := client.PublishEntities(ctx, environmentHost, table.LogicalName); err != nil {
+		return nil, nil, err
+	}
+	return table, primaryNameColumn, nil
+}
+
+// getMetadataDefinition reads a metadata definition as it is, so that it can be sent back with a PUT request.
+func (client *client) getMetadataDefinition(ctx context.Context, apiUrl string) (map[string]any, error) {
+	definition := map[string]any{}
+	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &definition)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("metadata '%s' not found", apiUrl))
+	}
+
+	for key := range definition {
+		if strings.HasPrefix(key, "@odata.") && key != "@odata.type" {
+			delete(definition, key)
+		}
+	}
+	return definition, nil
+}
+
+func (client *client) putMetadataDefinition(ctx context.Context, apiUrl, solutionUniqueName string, definition map[string]any) error {
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPut, apiUrl, metadataHeaders(solutionUniqueName), definition, []int{http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+// setManagedPropertyValue sets the value of a managed property, like `IsAuditEnabled` or `RequiredLevel`, in a raw metadata definition.
+func setManagedPropertyValue(definition map[string]any, name string, value any) {
+	property, ok := definition[name].(map[string]any)
+	if !ok {
+		property = map[string]any{}
+	}
+	property["Value"] = value
+	definition[name] = property
+}
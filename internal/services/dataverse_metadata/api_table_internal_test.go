@@ -0,0 +1,216 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/config"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testEnvironmentId   = "00000000-0000-0000-0000-000000000001"
+	testEnvironmentHost = "00000000-0000-0000-0000-000000000001.crm4.dynamics.com"
+	testTableId         = "22222222-2222-2222-2222-222222222222"
+)
+
+func newTestMetadataClient() client {
+	cfg := &config.ProviderConfig{
+		TestMode: true,
+		Urls:     config.ProviderConfigUrls{BapiUrl: "api.bap.microsoft.com"},
+	}
+	return newDataverseMetadataClient(api.NewApiClientBase(cfg, api.NewAuthBase(cfg)))
+}
+
+// RegisterMetadataEnvironmentMocks mocks the environment the metadata requests are sent to.
+// It is exported so that the resource tests in the external test package use the same mocks.
+func RegisterMetadataEnvironmentMocks() {
+	httpmock.RegisterNoResponder(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("no responder found for %s %s", req.Method, req.URL)
+	})
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/"+testEnvironmentId+"?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy%2Cproperties%2FcopilotPolicies&api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/get_environment.json").String()))
+}
+
+func metadataUrl(path string, query url.Values) string {
+	return helpers.BuildDataverseApiUrl(testEnvironmentHost, "/api/data/v9.2/"+path, query)
+}
+
+func registerTableReadMocks(tableFile, primaryNameColumnFile string) {
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions("+testTableId+")", url.Values{"$select": {tableSelect}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+tableFile).String()))
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions("+testTableId+")/Attributes(LogicalName='cr123_name')/Microsoft.Dynamics.CRM.StringAttributeMetadata", url.Values{"$select": {primaryNameColumnSelect}}),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+primaryNameColumnFile).String()))
+}
+
+func readJsonBody(t *testing.T, req *http.Request) map[string]any {
+	t.Helper()
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	result := map[string]any{}
+	require.NoError(t, json.Unmarshal(body, &result))
+	return result
+}
+
+func TestUnitCreateTable_CreatesPrimaryNameColumnAndPublishes(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+	registerTableReadMocks("get_table_created.json", "get_primary_name_column_created.json")
+
+	var created map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("EntityDefinitions", nil),
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "true", req.Header.Get("MSCRM.MergeLabels"))
+			require.Equal(t, "ContosoCore", req.Header.Get("MSCRM.SolutionUniqueName"))
+			created = readJsonBody(t, req)
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions(%s)", testEnvironmentHost, testTableId))
+			return resp, nil
+		})
+
+	var published map[string]any
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		func(req *http.Request) (*http.Response, error) {
+			published = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	metadataClient := newTestMetadataClient()
+	changeTrackingEnabled, maxLength := false, int64(100)
+	table, primaryNameColumn, err := metadataClient.CreateTable(context.Background(), testEnvironmentId, "ContosoCore",
+		entityMetadataDto{
+			SchemaName:            "cr123_Project",
+			DisplayName:           newLabel("Project", defaultLanguageCode),
+			DisplayCollectionName: newLabel("Projects", defaultLanguageCode),
+			OwnershipType:         "UserOwned",
+			HasNotes:              true,
+			IsAuditEnabled:        &booleanManagedPropertyDto{Value: false},
+			ChangeTrackingEnabled: &changeTrackingEnabled,
+		},
+		AttributeMetadataDto{
+			SchemaName:    "cr123_Name",
+			DisplayName:   newLabel("Name", defaultLanguageCode),
+			MaxLength:     &maxLength,
+			RequiredLevel: &requiredLevelDto{Value: "ApplicationRequired"},
+		})
+	require.NoError(t, err)
+	require.Equal(t, testTableId, table.MetadataId)
+	require.Equal(t, "cr123_projects", table.EntitySetName)
+	require.Equal(t, "cr123_name", primaryNameColumn.LogicalName)
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.EntityMetadata", created["@odata.type"])
+	require.Equal(t, "UserOwned", created["OwnershipType"])
+	require.NotContains(t, created, "Description")
+	attributes, ok := created["Attributes"].([]any)
+	require.True(t, ok)
+	require.Len(t, attributes, 1)
+	attribute, ok := attributes[0].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "Microsoft.Dynamics.CRM.StringAttributeMetadata", attribute["@odata.type"])
+	require.Equal(t, true, attribute["IsPrimaryName"])
+	require.Equal(t, "cr123_Name", attribute["SchemaName"])
+
+	require.Equal(t, "<importexportxml><entities><entity>cr123_project</entity></entities></importexportxml>", published["ParameterXml"])
+}
+
+func TestUnitUpdateTable_SendsCompleteDefinitionsWithChanges(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	RegisterMetadataEnvironmentMocks()
+	registerTableReadMocks("get_table_updated.json", "get_primary_name_column_updated.json")
+
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions("+testTableId+")", nil),
+		httpmock.NewStringResponder(http.StatusOK, `{
+  "@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#EntityDefinitions/$entity",
+  "MetadataId":"22222222-2222-2222-2222-222222222222",
+  "SchemaName":"cr123_Project",
+  "PrimaryNameAttribute":"cr123_name",
+  "IsValidForQueue":{"Value":false,"CanBeChanged":true},
+  "IsAuditEnabled":{"Value":false,"CanBeChanged":true,"ManagedPropertyLogicalName":"canmodifyauditsettings"}
+}`))
+	httpmock.RegisterResponder("GET", metadataUrl("EntityDefinitions("+testTableId+")/Attributes(LogicalName='cr123_name')/Microsoft.Dynamics.CRM.StringAttributeMetadata", nil),
+		httpmock.NewStringResponder(http.StatusOK, `{
+  "@odata.context":"https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/$metadata#EntityDefinitions(22222222-2222-2222-2222-222222222222)/Attributes/Microsoft.Dynamics.CRM.StringAttributeMetadata/$entity",
+  "MetadataId":"33333333-3333-3333-3333-333333333333",
+  "SchemaName":"cr123_Name",
+  "MaxLength":100,
+  "RequiredLevel":{"Value":"None","CanBeChanged":true}
+}`))
+
+	var tableDefinition, columnDefinition map[string]any
+	httpmock.RegisterResponder("PUT", metadataUrl("EntityDefinitions("+testTableId+")", nil),
+		func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "true", req.Header.Get("MSCRM.MergeLabels"))
+			tableDefinition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder("PUT", metadataUrl("EntityDefinitions("+testTableId+")/Attributes(LogicalName='cr123_name')", nil),
+		func(req *http.Request) (*http.Response, error) {
+			columnDefinition = readJsonBody(t, req)
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+	httpmock.RegisterResponder("POST", metadataUrl("PublishXml", nil),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	metadataClient := newTestMetadataClient()
+	changeTrackingEnabled, maxLength := true, int64(200)
+	table, primaryNameColumn, err := metadataClient.UpdateTable(context.Background(), testEnvironmentId, testTableId, "", defaultLanguageCode,
+		entityMetadataDto{
+			DisplayName:           newLabel("Project", defaultLanguageCode),
+			DisplayCollectionName: newLabel("Customer Projects", defaultLanguageCode),
+			Description:           newLabel("Projects run for customers", defaultLanguageCode),
+			HasNotes:              true,
+			IsAuditEnabled:        &booleanManagedPropertyDto{Value: true},
+			ChangeTrackingEnabled: &changeTrackingEnabled,
+		},
+		AttributeMetadataDto{
+			DisplayName:   newLabel("Project Name", defaultLanguageCode),
+			MaxLength:     &maxLength,
+			RequiredLevel: &requiredLevelDto{Value: "ApplicationRequired"},
+		})
+	require.NoError(t, err)
+	require.Equal(t, "Customer Projects", labelText(table.DisplayCollectionName, defaultLanguageCode))
+	require.Equal(t, int64(200), *primaryNameColumn.MaxLength)
+
+	require.NotContains(t, tableDefinition, "@odata.context")
+	require.Equal(t, "Microsoft.Dynamics.CRM.EntityMetadata", tableDefinition["@odata.type"])
+	require.Equal(t, map[string]any{"Value": false, "CanBeChanged": true}, tableDefinition["IsValidForQueue"])
+	require.Equal(t, map[string]any{"Value": true, "CanBeChanged": true, "ManagedPropertyLogicalName": "canmodifyauditsettings"}, tableDefinition["IsAuditEnabled"])
+	require.Equal(t, true, tableDefinition["ChangeTrackingEnabled"])
+
+	require.Equal(t, "Microsoft.Dynamics.CRM.StringAttributeMetadata", columnDefinition["@odata.type"])
+	require.InDelta(t, 200, columnDefinition["MaxLength"], 0)
+	require.Equal(t, map[string]any{"Value": "ApplicationRequired", "CanBeChanged": true}, columnDefinition["RequiredLevel"])
+	description, ok := columnDefinition["Description"].(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, []any{map[string]any{"@odata.type": "Microsoft.Dynamics.CRM.LocalizedLabel", "Label": "", "LanguageCode": float64(defaultLanguageCode)}}, description["LocalizedLabels"])
+}
+
+func TestUnitGetEntityIdFromResponse(t *testing.T) {
+	resp := &api.Response{HttpResponse: &http.Response{Header: http.Header{}}}
+	resp.HttpResponse.Header.Set("OData-EntityId", "https://org.crm.dynamics.com/api/data/v9.2/EntityDefinitions("+testTableId+")")
+
+	tableId, err := getEntityIdFromResponse(resp)
+	require.NoError(t, err)
+	require.Equal(t, testTableId, tableId)
+
+	resp.HttpResponse.Header.Del("OData-EntityId")
+	_, err = getEntityIdFromResponse(resp)
+	require.Error(t, err)
+}
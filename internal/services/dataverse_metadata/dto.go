@@ -0,0 +1,157 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+type labelDto struct {
+	ODataType          string              `json:"@odata.type,omitempty"`
+	LocalizedLabels    []localizedLabelDto `json:"LocalizedLabels"`
+	UserLocalizedLabel *localizedLabelDto  `json:"UserLocalizedLabel,omitempty"`
+	// translationsManaged reports whether the translations of the label are managed by Terraform.
+	// It is not part of the metadata and only set on labels built from the configuration.
+	translationsManaged bool
+}
+
+type localizedLabelDto struct {
+	ODataType    string `json:"@odata.type,omitempty"`
+	Label        string `json:"Label"`
+	LanguageCode int64  `json:"LanguageCode"`
+}
+
+type booleanManagedPropertyDto struct {
+	Value        bool `json:"Value"`
+	CanBeChanged bool `json:"CanBeChanged"`
+}
+
+type requiredLevelDto struct {
+	Value        string `json:"Value"`
+	CanBeChanged bool   `json:"CanBeChanged"`
+}
+
+type entityMetadataDto struct {
+	ODataType             string                     `json:"@odata.type,omitempty"`
+	MetadataId            string                     `json:"MetadataId,omitempty"`
+	LogicalName           string                     `json:"LogicalName,omitempty"`
+	SchemaName            string                     `json:"SchemaName"`
+	EntitySetName         string                     `json:"EntitySetName,omitempty"`
+	DisplayName           *labelDto                  `json:"DisplayName,omitempty"`
+	DisplayCollectionName *labelDto                  `json:"DisplayCollectionName,omitempty"`
+	Description           *labelDto                  `json:"Description,omitempty"`
+	OwnershipType         string                     `json:"OwnershipType"`
+	HasActivities         bool                       `json:"HasActivities"`
+	HasNotes              bool                       `json:"HasNotes"`
+	IsActivity            bool                       `json:"IsActivity"`
+	IsCustomEntity        bool                       `json:"IsCustomEntity,omitempty"`
+	IsAuditEnabled        *booleanManagedPropertyDto `json:"IsAuditEnabled,omitempty"`
+	ChangeTrackingEnabled *bool                      `json:"ChangeTrackingEnabled,omitempty"`
+	PrimaryIdAttribute    string                     `json:"PrimaryIdAttribute,omitempty"`
+	PrimaryNameAttribute  string                     `json:"PrimaryNameAttribute,omitempty"`
+	Attributes            []AttributeMetadataDto     `json:"Attributes,omitempty"`
+}
+
+// AttributeMetadataDto is the metadata of a column. It is exported for the data record resources, which read
+// the columns of a table with GetEntityAttributesDefinition.
+type AttributeMetadataDto struct {
+	ODataType           string                `json:"@odata.type,omitempty"`
+	MetadataId          string                `json:"MetadataId,omitempty"`
+	LogicalName         string                `json:"LogicalName,omitempty"`
+	SchemaName          string                `json:"SchemaName"`
+	AttributeType       string                `json:"AttributeType,omitempty"`
+	AttributeTypeName   *attributeTypeNameDto `json:"AttributeTypeName,omitempty"`
+	IsPrimaryName       bool                  `json:"IsPrimaryName,omitempty"`
+	DisplayName         *labelDto             `json:"DisplayName,omitempty"`
+	Description         *labelDto             `json:"Description,omitempty"`
+	RequiredLevel       *requiredLevelDto     `json:"RequiredLevel,omitempty"`
+	MaxLength           *int64                `json:"MaxLength,omitempty"`
+	FormatName          *formatNameDto        `json:"FormatName,omitempty"`
+	Format              *string               `json:"Format,omitempty"`
+	MinValue            *float64              `json:"MinValue,omitempty"`
+	MaxValue            *float64              `json:"MaxValue,omitempty"`
+	Precision           *int64                `json:"Precision,omitempty"`
+	PrecisionSource     *int64                `json:"PrecisionSource,omitempty"`
+	DateTimeBehavior    *dateTimeBehaviorDto  `json:"DateTimeBehavior,omitempty"`
+	MaxSizeInKB         *int64                `json:"MaxSizeInKB,omitempty"`
+	CanStoreFullImage   *bool                 `json:"CanStoreFullImage,omitempty"`
+	Targets             []string              `json:"Targets,omitempty"`
+	OptionSet           *optionSetDto         `json:"OptionSet,omitempty"`
+	GlobalOptionSet     *optionSetDto         `json:"GlobalOptionSet,omitempty"`
+	GlobalOptionSetBind string                `json:"GlobalOptionSet@odata.bind,omitempty"`
+}
+
+type attributeTypeNameDto struct {
+	Value string `json:"Value"`
+}
+
+type formatNameDto struct {
+	Value string `json:"Value"`
+}
+
+type dateTimeBehaviorDto struct {
+	Value string `json:"Value"`
+}
+
+type optionSetDto struct {
+	ODataType     string      `json:"@odata.type,omitempty"`
+	MetadataId    string      `json:"MetadataId,omitempty"`
+	Name          string      `json:"Name,omitempty"`
+	IsGlobal      bool        `json:"IsGlobal"`
+	OptionSetType string      `json:"OptionSetType,omitempty"`
+	DisplayName   *labelDto   `json:"DisplayName,omitempty"`
+	Description   *labelDto   `json:"Description,omitempty"`
+	Options       []optionDto `json:"Options,omitempty"`
+	TrueOption    *optionDto  `json:"TrueOption,omitempty"`
+	FalseOption   *optionDto  `json:"FalseOption,omitempty"`
+}
+
+type optionDto struct {
+	Value       int64     `json:"Value"`
+	Label       *labelDto `json:"Label,omitempty"`
+	Description *labelDto `json:"Description,omitempty"`
+	Color       string    `json:"Color,omitempty"`
+}
+
+type oneToManyRelationshipDto struct {
+	ODataType            string                   `json:"@odata.type,omitempty"`
+	MetadataId           string                   `json:"MetadataId,omitempty"`
+	SchemaName           string                   `json:"SchemaName"`
+	ReferencedEntity     string                   `json:"ReferencedEntity"`
+	ReferencedAttribute  string                   `json:"ReferencedAttribute,omitempty"`
+	ReferencingEntity    string                   `json:"ReferencingEntity"`
+	ReferencingAttribute string                   `json:"ReferencingAttribute,omitempty"`
+	CascadeConfiguration *cascadeConfigurationDto `json:"CascadeConfiguration,omitempty"`
+	Lookup               *AttributeMetadataDto    `json:"Lookup,omitempty"`
+}
+
+type attributeMetadataArrayDto struct {
+	Value []AttributeMetadataDto `json:"value"`
+}
+
+type oneToManyRelationshipArrayDto struct {
+	Value []oneToManyRelationshipDto `json:"value"`
+}
+
+type manyToManyRelationshipDto struct {
+	ODataType           string `json:"@odata.type,omitempty"`
+	MetadataId          string `json:"MetadataId,omitempty"`
+	SchemaName          string `json:"SchemaName"`
+	Entity1LogicalName  string `json:"Entity1LogicalName"`
+	Entity2LogicalName  string `json:"Entity2LogicalName"`
+	IntersectEntityName string `json:"IntersectEntityName"`
+}
+
+// relationshipTypeDto is the part of a relationship definition that is common to all relationship types.
+type relationshipTypeDto struct {
+	MetadataId       string `json:"MetadataId"`
+	SchemaName       string `json:"SchemaName"`
+	RelationshipType string `json:"RelationshipType"`
+}
+
+type cascadeConfigurationDto struct {
+	Assign     string `json:"Assign"`
+	Delete     string `json:"Delete"`
+	Merge      string `json:"Merge"`
+	Reparent   string `json:"Reparent"`
+	Share      string `json:"Share"`
+	Unshare    string `json:"Unshare"`
+	RollupView string `json:"RollupView,omitempty"`
+}
@@ -0,0 +1,146 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+type TableResource struct {
+	helpers.TypeInfo
+	MetadataClient client
+}
+
+type ColumnResource struct {
+	helpers.TypeInfo
+	MetadataClient client
+}
+
+type GlobalChoiceResource struct {
+	helpers.TypeInfo
+	MetadataClient client
+}
+
+type RelationshipResource struct {
+	helpers.TypeInfo
+	MetadataClient client
+}
+
+type TableResourceModel struct {
+	Timeouts              timeouts.Value          `tfsdk:"timeouts"`
+	Id                    types.String            `tfsdk:"id"`
+	EnvironmentId         types.String            `tfsdk:"environment_id"`
+	SolutionUniqueName    types.String            `tfsdk:"solution_unique_name"`
+	SchemaName            types.String            `tfsdk:"schema_name"`
+	LogicalName           types.String            `tfsdk:"logical_name"`
+	EntitySetName         types.String            `tfsdk:"entity_set_name"`
+	LanguageCode          types.Int64             `tfsdk:"language_code"`
+	DisplayName           types.String            `tfsdk:"display_name"`
+	DisplayCollectionName types.String            `tfsdk:"display_collection_name"`
+	Description           types.String            `tfsdk:"description"`
+	OwnershipType         types.String            `tfsdk:"ownership_type"`
+	HasActivities         types.Bool              `tfsdk:"has_activities"`
+	HasNotes              types.Bool              `tfsdk:"has_notes"`
+	IsAuditEnabled        types.Bool              `tfsdk:"is_audit_enabled"`
+	ChangeTrackingEnabled types.Bool              `tfsdk:"change_tracking_enabled"`
+	PrimaryNameColumn     *PrimaryNameColumnModel `tfsdk:"primary_name_column"`
+}
+
+type PrimaryNameColumnModel struct {
+	SchemaName    types.String `tfsdk:"schema_name"`
+	LogicalName   types.String `tfsdk:"logical_name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Description   types.String `tfsdk:"description"`
+	MaxLength     types.Int64  `tfsdk:"max_length"`
+	RequiredLevel types.String `tfsdk:"required_level"`
+}
+
+type ColumnResourceModel struct {
+	Timeouts               timeouts.Value      `tfsdk:"timeouts"`
+	Id                     types.String        `tfsdk:"id"`
+	EnvironmentId          types.String        `tfsdk:"environment_id"`
+	TableLogicalName       types.String        `tfsdk:"table_logical_name"`
+	SolutionUniqueName     types.String        `tfsdk:"solution_unique_name"`
+	SchemaName             types.String        `tfsdk:"schema_name"`
+	LogicalName            types.String        `tfsdk:"logical_name"`
+	Type                   types.String        `tfsdk:"type"`
+	LanguageCode           types.Int64         `tfsdk:"language_code"`
+	DisplayName            types.String        `tfsdk:"display_name"`
+	Description            types.String        `tfsdk:"description"`
+	RequiredLevel          types.String        `tfsdk:"required_level"`
+	MaxLength              types.Int64         `tfsdk:"max_length"`
+	Format                 types.String        `tfsdk:"format"`
+	MinValue               types.Float64       `tfsdk:"min_value"`
+	MaxValue               types.Float64       `tfsdk:"max_value"`
+	Precision              types.Int64         `tfsdk:"precision"`
+	DateTimeBehavior       types.String        `tfsdk:"date_time_behavior"`
+	TrueLabel              types.String        `tfsdk:"true_label"`
+	FalseLabel             types.String        `tfsdk:"false_label"`
+	Options                []ColumnOptionModel `tfsdk:"options"`
+	GlobalChoiceName       types.String        `tfsdk:"global_choice_name"`
+	LookupTargetTable      types.String        `tfsdk:"lookup_target_table"`
+	RelationshipSchemaName types.String        `tfsdk:"relationship_schema_name"`
+	MaxSizeInKb            types.Int64         `tfsdk:"max_size_in_kb"`
+	CanStoreFullImage      types.Bool          `tfsdk:"can_store_full_image"`
+}
+
+type ColumnOptionModel struct {
+	Value types.Int64  `tfsdk:"value"`
+	Label types.String `tfsdk:"label"`
+}
+
+type GlobalChoiceResourceModel struct {
+	Timeouts              timeouts.Value            `tfsdk:"timeouts"`
+	Id                    types.String              `tfsdk:"id"`
+	EnvironmentId         types.String              `tfsdk:"environment_id"`
+	SolutionUniqueName    types.String              `tfsdk:"solution_unique_name"`
+	Name                  types.String              `tfsdk:"name"`
+	LanguageCode          types.Int64               `tfsdk:"language_code"`
+	DisplayName           types.String              `tfsdk:"display_name"`
+	LocalizedDisplayNames map[string]string         `tfsdk:"localized_display_names"`
+	Description           types.String              `tfsdk:"description"`
+	Options               []GlobalChoiceOptionModel `tfsdk:"options"`
+}
+
+type GlobalChoiceOptionModel struct {
+	Value           types.Int64       `tfsdk:"value"`
+	Label           types.String      `tfsdk:"label"`
+	LocalizedLabels map[string]string `tfsdk:"localized_labels"`
+	Description     types.String      `tfsdk:"description"`
+	Color           types.String      `tfsdk:"color"`
+}
+
+type RelationshipResourceModel struct {
+	Timeouts                timeouts.Value                 `tfsdk:"timeouts"`
+	Id                      types.String                   `tfsdk:"id"`
+	EnvironmentId           types.String                   `tfsdk:"environment_id"`
+	SolutionUniqueName      types.String                   `tfsdk:"solution_unique_name"`
+	SchemaName              types.String                   `tfsdk:"schema_name"`
+	Type                    types.String                   `tfsdk:"type"`
+	TableLogicalName        types.String                   `tfsdk:"table_logical_name"`
+	RelatedTableLogicalName types.String                   `tfsdk:"related_table_logical_name"`
+	LanguageCode            types.Int64                    `tfsdk:"language_code"`
+	LookupColumn            *RelationshipLookupColumnModel `tfsdk:"lookup_column"`
+	CascadeConfiguration    types.Object                   `tfsdk:"cascade_configuration"`
+	IntersectEntityName     types.String                   `tfsdk:"intersect_entity_name"`
+}
+
+type RelationshipLookupColumnModel struct {
+	SchemaName    types.String `tfsdk:"schema_name"`
+	LogicalName   types.String `tfsdk:"logical_name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Description   types.String `tfsdk:"description"`
+	RequiredLevel types.String `tfsdk:"required_level"`
+}
+
+type RelationshipCascadeConfigurationModel struct {
+	Assign   types.String `tfsdk:"assign"`
+	Delete   types.String `tfsdk:"delete"`
+	Merge    types.String `tfsdk:"merge"`
+	Reparent types.String `tfsdk:"reparent"`
+	Share    types.String `tfsdk:"share"`
+	Unshare  types.String `tfsdk:"unshare"`
+}
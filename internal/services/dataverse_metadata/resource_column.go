@@ -0,0 +1,728 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &ColumnResource{}
+var _ resource.ResourceWithConfigure = &ColumnResource{}
+var _ resource.ResourceWithImportState = &ColumnResource{}
+var _ resource.ResourceWithValidateConfig = &ColumnResource{}
+
+// columnTypeAttributes lists the column types each type specific attribute can be used with.
+var columnTypeAttributes = map[string][]string{
+	"max_length":               {columnTypeString, columnTypeMemo},
+	"format":                   {columnTypeString, columnTypeMemo, columnTypeInteger, columnTypeDateTime},
+	"min_value":                {columnTypeInteger, columnTypeDecimal, columnTypeMoney},
+	"max_value":                {columnTypeInteger, columnTypeDecimal, columnTypeMoney},
+	"precision":                {columnTypeDecimal, columnTypeMoney},
+	"date_time_behavior":       {columnTypeDateTime},
+	"true_label":               {columnTypeBoolean},
+	"false_label":              {columnTypeBoolean},
+	"options":                  {columnTypeChoice, columnTypeMultiSelectChoice},
+	"global_choice_name":       {columnTypeChoice, columnTypeMultiSelectChoice},
+	"lookup_target_table":      {columnTypeLookup},
+	"relationship_schema_name": {columnTypeLookup},
+	"max_size_in_kb":           {columnTypeFile, columnTypeImage},
+	"can_store_full_image":     {columnTypeImage},
+}
+
+// maxMoneyPrecision is the highest precision Dataverse supports for Money columns.
+const maxMoneyPrecision = 4
+
+func NewColumnResource() resource.Resource {
+	return &ColumnResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "dataverse_column",
+		},
+	}
+}
+
+func (r *ColumnResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *ColumnResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a column of a Dataverse table. Changes to the column are published after they have been applied. See [Create and update column definitions using the Web API](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/create-update-column-definitions-using-web-api) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Metadata id of the column.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse-enabled environment containing the table.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"table_logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the table the column belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"solution_unique_name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the unmanaged solution the column is added to when it is created. When omitted, the column is only added to the default solution.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_name": schema.StringAttribute{
+				MarkdownDescription: "Schema name of the column, starting with the customization prefix of the publisher, for example `cr123_StartDate`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(schemaNameRegex, "schema_name must start with a customization prefix followed by '_'"),
+				},
+			},
+			"logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the column, the lowercase schema name.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the column. Valid values are `String`, `Memo`, `Integer`, `Decimal`, `Money`, `Boolean`, `DateTime`, `Choice`, `MultiSelectChoice`, `Lookup`, `File` and `Image`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(columnTypeString, columnTypeMemo, columnTypeInteger, columnTypeDecimal, columnTypeMoney, columnTypeBoolean, columnTypeDateTime, columnTypeChoice, columnTypeMultiSelectChoice, columnTypeLookup, columnTypeFile, columnTypeImage),
+				},
+			},
+			"language_code": schema.Int64Attribute{
+				MarkdownDescription: "Language code of the display names, descriptions and option labels. Defaults to `1033` (English).",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultLanguageCode),
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the column.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the column.",
+				Optional:            true,
+			},
+			"required_level": schema.StringAttribute{
+				MarkdownDescription: "Requirement level of the column. Valid values are `None`, `Recommended` and `ApplicationRequired`. Defaults to `None`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("None"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("None", "Recommended", "ApplicationRequired"),
+				},
+			},
+			"max_length": schema.Int64Attribute{
+				MarkdownDescription: "Maximum length of the values of `String` and `Memo` columns. Defaults to `100` for `String` and `2000` for `Memo` columns.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1048576),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "Format of the column. `String` columns support `Text`, `TextArea`, `Email`, `Url`, `Phone`, `TickerSymbol` and `Json`, `Memo` columns `TextArea`, `Email`, `Json` and `RichText`, `Integer` columns `None`, `Duration`, `TimeZone`, `Language` and `Locale`, and `DateTime` columns `DateOnly` and `DateAndTime`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"min_value": schema.Float64Attribute{
+				MarkdownDescription: "Minimum value of `Integer`, `Decimal` and `Money` columns.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"max_value": schema.Float64Attribute{
+				MarkdownDescription: "Maximum value of `Integer`, `Decimal` and `Money` columns.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"precision": schema.Int64Attribute{
+				MarkdownDescription: "Number of decimal places of `Decimal` and `Money` columns, at most 10 for `Decimal` and 4 for `Money` columns. `Money` columns use the precision of their currency when it is omitted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(0, 10),
+				},
+			},
+			"date_time_behavior": schema.StringAttribute{
+				MarkdownDescription: "Behavior of `DateTime` columns. Valid values are `UserLocal`, `DateOnly` and `TimeZoneIndependent`. Defaults to `UserLocal`. Once changed from `UserLocal`, the behavior can't be changed anymore.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("UserLocal", "DateOnly", "TimeZoneIndependent"),
+				},
+			},
+			"true_label": schema.StringAttribute{
+				MarkdownDescription: "Label of the `true` option of `Boolean` columns. Defaults to `Yes`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"false_label": schema.StringAttribute{
+				MarkdownDescription: "Label of the `false` option of `Boolean` columns. Defaults to `No`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"options": schema.ListNestedAttribute{
+				MarkdownDescription: "Options of `Choice` and `MultiSelectChoice` columns with a local option set. Conflicts with `global_choice_name`.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot("global_choice_name")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.Int64Attribute{
+							MarkdownDescription: "Value of the option, usually starting with the option value prefix of the publisher.",
+							Required:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Label of the option.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"global_choice_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the global choice used by `Choice` and `MultiSelectChoice` columns. Conflicts with `options`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"lookup_target_table": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the table referenced by `Lookup` columns.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"relationship_schema_name": schema.StringAttribute{
+				MarkdownDescription: "Schema name of the one-to-many relationship created for `Lookup` columns. Defaults to `<prefix>_<lookup_target_table>_<table_logical_name>_<logical_name>`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"max_size_in_kb": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size of the files of `File` and `Image` columns in kilobytes. Defaults to `32768` for `File` and `10240` for `Image` columns. It can't be changed once the column has been created.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+					int64planmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"can_store_full_image": schema.BoolAttribute{
+				MarkdownDescription: "Whether `Image` columns store the full image in addition to the thumbnail. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+					boolplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ColumnResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var columnType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &columnType)...)
+	if resp.Diagnostics.HasError() || columnType.IsNull() || columnType.IsUnknown() {
+		return
+	}
+
+	configured := map[string]bool{}
+	for name, supportedTypes := range columnTypeAttributes {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value == nil || value.IsNull() {
+			continue
+		}
+		configured[name] = true
+
+		if !slices.Contains(supportedTypes, columnType.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid attribute for column type",
+				fmt.Sprintf("`%s` can only be set for columns of type %s, not for `%s` columns.", name, strings.Join(supportedTypes, ", "), columnType.ValueString()),
+			)
+		}
+	}
+
+	switch columnType.ValueString() {
+	case columnTypeChoice, columnTypeMultiSelectChoice:
+		if !configured["options"] && !configured["global_choice_name"] {
+			resp.Diagnostics.AddAttributeError(path.Root("options"), "Missing choice options", "Choice columns require either `options` or `global_choice_name`.")
+		}
+	case columnTypeLookup:
+		if !configured["lookup_target_table"] {
+			resp.Diagnostics.AddAttributeError(path.Root("lookup_target_table"), "Missing lookup target table", "Lookup columns require `lookup_target_table`.")
+		}
+	case columnTypeMoney:
+		var precision types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("precision"), &precision)...)
+		if !precision.IsNull() && !precision.IsUnknown() && precision.ValueInt64() > maxMoneyPrecision {
+			resp.Diagnostics.AddAttributeError(path.Root("precision"), "Invalid precision", fmt.Sprintf("`Money` columns support a precision of at most %d.", maxMoneyPrecision))
+		}
+	}
+}
+
+func (r *ColumnResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.MetadataClient = newDataverseMetadataClient(providerClient.Api)
+}
+
+func (r *ColumnResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan ColumnResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var relationship *oneToManyRelationshipDto
+	if plan.Type.ValueString() == columnTypeLookup {
+		if plan.RelationshipSchemaName.IsNull() || plan.RelationshipSchemaName.IsUnknown() {
+			plan.RelationshipSchemaName = types.StringValue(defaultRelationshipSchemaName(plan.SchemaName.ValueString(), plan.LookupTargetTable.ValueString(), plan.TableLogicalName.ValueString()))
+		}
+		relationship = &oneToManyRelationshipDto{
+			SchemaName:        plan.RelationshipSchemaName.ValueString(),
+			ReferencedEntity:  plan.LookupTargetTable.ValueString(),
+			ReferencingEntity: plan.TableLogicalName.ValueString(),
+		}
+	}
+
+	column, err := r.MetadataClient.CreateColumn(ctx, plan.EnvironmentId.ValueString(), plan.TableLogicalName.ValueString(), plan.SolutionUniqueName.ValueString(), plan.Type.ValueString(), columnDtoFromModel(&plan, true), relationship)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setColumnModelFromDto(&plan, plan.Type.ValueString(), column)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ColumnResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state ColumnResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	column, columnType, err := r.MetadataClient.GetColumn(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), state.Id.ValueString(), state.LogicalName.ValueString(), state.Type.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	if columnType == columnTypeLookup && state.RelationshipSchemaName.IsNull() {
+		relationshipSchemaName, err := r.MetadataClient.GetLookupRelationshipSchemaName(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), column.LogicalName)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+			return
+		}
+		state.RelationshipSchemaName = types.StringValue(relationshipSchemaName)
+	}
+
+	resp.Diagnostics.Append(setColumnModelFromDto(&state, columnType, column)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ColumnResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan ColumnResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state ColumnResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	column, err := r.MetadataClient.UpdateColumn(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), state.Id.ValueString(), plan.SolutionUniqueName.ValueString(), plan.Type.ValueString(), plan.LanguageCode.ValueInt64(), columnDtoFromModel(&plan, false))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setColumnModelFromDto(&plan, plan.Type.ValueString(), column)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ColumnResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state ColumnResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationshipSchemaName := ""
+	if state.Type.ValueString() == columnTypeLookup {
+		relationshipSchemaName = state.RelationshipSchemaName.ValueString()
+	}
+
+	err := r.MetadataClient.DeleteColumn(ctx, state.EnvironmentId.ValueString(), state.TableLogicalName.ValueString(), state.Id.ValueString(), relationshipSchemaName)
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+	}
+}
+
+func (r *ColumnResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || !guidRegex.MatchString(parts[0]) || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id/table_logical_name/column_logical_name', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_logical_name"), strings.ToLower(parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("language_code"), int64(defaultLanguageCode))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("logical_name"), strings.ToLower(parts[2]))...)
+}
+
+// defaultRelationshipSchemaName returns the relationship name the maker portal uses for a new lookup column,
+// for example `cr123_account_cr123_project_cr123_accountid`.
+func defaultRelationshipSchemaName(schemaName, targetTable, tableLogicalName string) string {
+	prefix, _, _ := strings.Cut(schemaName, "_")
+	return fmt.Sprintf("%s_%s_%s_%s", prefix, targetTable, tableLogicalName, strings.ToLower(schemaName))
+}
+
+// columnDtoFromModel returns the definition of a column. When creating is set, the defaults Dataverse
+// expects for the type specific settings are filled in for the settings that are not configured.
+func columnDtoFromModel(model *ColumnResourceModel, creating bool) AttributeMetadataDto {
+	languageCode := model.LanguageCode.ValueInt64()
+	column := AttributeMetadataDto{
+		SchemaName:    model.SchemaName.ValueString(),
+		DisplayName:   newLabel(model.DisplayName.ValueString(), languageCode),
+		Description:   newLabel(model.Description.ValueString(), languageCode),
+		RequiredLevel: &requiredLevelDto{Value: model.RequiredLevel.ValueString()},
+	}
+
+	int64Value := func(value types.Int64, defaultValue int64) *int64 {
+		if !value.IsNull() && !value.IsUnknown() {
+			return value.ValueInt64Pointer()
+		}
+		if creating {
+			return &defaultValue
+		}
+		return nil
+	}
+	float64Value := func(value types.Float64, defaultValue float64) *float64 {
+		if !value.IsNull() && !value.IsUnknown() {
+			return value.ValueFloat64Pointer()
+		}
+		if creating {
+			return &defaultValue
+		}
+		return nil
+	}
+	stringValue := func(value types.String, defaultValue string) string {
+		if !value.IsNull() && !value.IsUnknown() {
+			return value.ValueString()
+		}
+		if creating {
+			return defaultValue
+		}
+		return ""
+	}
+
+	switch model.Type.ValueString() {
+	case columnTypeString:
+		column.MaxLength = int64Value(model.MaxLength, 100)
+		if format := stringValue(model.Format, "Text"); format != "" {
+			column.FormatName = &formatNameDto{Value: format}
+		}
+	case columnTypeMemo:
+		column.MaxLength = int64Value(model.MaxLength, 2000)
+		if format := stringValue(model.Format, "TextArea"); format != "" {
+			column.Format = &format
+		}
+	case columnTypeInteger:
+		if format := stringValue(model.Format, "None"); format != "" {
+			column.Format = &format
+		}
+		column.MinValue = float64Value(model.MinValue, -2147483648)
+		column.MaxValue = float64Value(model.MaxValue, 2147483647)
+	case columnTypeDecimal:
+		column.MinValue = float64Value(model.MinValue, -100000000000)
+		column.MaxValue = float64Value(model.MaxValue, 100000000000)
+		column.Precision = int64Value(model.Precision, 2)
+	case columnTypeMoney:
+		column.MinValue = float64Value(model.MinValue, -922337203685477)
+		column.MaxValue = float64Value(model.MaxValue, 922337203685477)
+		// 0 uses the precision of the column, 2 the precision of the currency of each record.
+		// The precision source is also set when a precision is added to an existing column, otherwise Dataverse ignores the precision.
+		if !model.Precision.IsNull() && !model.Precision.IsUnknown() {
+			column.Precision = model.Precision.ValueInt64Pointer()
+			column.PrecisionSource = new(int64)
+		} else if creating {
+			precisionSource := int64(2)
+			column.PrecisionSource = &precisionSource
+		}
+	case columnTypeDateTime:
+		if format := stringValue(model.Format, "DateAndTime"); format != "" {
+			column.Format = &format
+		}
+		if behavior := stringValue(model.DateTimeBehavior, "UserLocal"); behavior != "" {
+			column.DateTimeBehavior = &dateTimeBehaviorDto{Value: behavior}
+		}
+	case columnTypeBoolean:
+		column.OptionSet = &optionSetDto{
+			ODataType:     "Microsoft.Dynamics.CRM.BooleanOptionSetMetadata",
+			OptionSetType: "Boolean",
+			TrueOption:    &optionDto{Value: 1, Label: newLabel(stringValue(model.TrueLabel, "Yes"), languageCode)},
+			FalseOption:   &optionDto{Value: 0, Label: newLabel(stringValue(model.FalseLabel, "No"), languageCode)},
+		}
+		if column.OptionSet.TrueOption.Label == nil || column.OptionSet.FalseOption.Label == nil {
+			column.OptionSet = nil
+		}
+	case columnTypeChoice, columnTypeMultiSelectChoice:
+		if !model.GlobalChoiceName.IsNull() {
+			column.GlobalOptionSet = &optionSetDto{Name: model.GlobalChoiceName.ValueString(), IsGlobal: true}
+			break
+		}
+		column.OptionSet = &optionSetDto{
+			ODataType:     "Microsoft.Dynamics.CRM.OptionSetMetadata",
+			OptionSetType: "Picklist",
+			Options:       make([]optionDto, 0, len(model.Options)),
+		}
+		for _, option := range model.Options {
+			column.OptionSet.Options = append(column.OptionSet.Options, optionDto{
+				Value: option.Value.ValueInt64(),
+				Label: newLabel(option.Label.ValueString(), languageCode),
+			})
+		}
+	case columnTypeFile:
+		column.MaxSizeInKB = int64Value(model.MaxSizeInKb, 32768)
+	case columnTypeImage:
+		column.MaxSizeInKB = int64Value(model.MaxSizeInKb, 10240)
+		if !model.CanStoreFullImage.IsNull() && !model.CanStoreFullImage.IsUnknown() {
+			column.CanStoreFullImage = model.CanStoreFullImage.ValueBoolPointer()
+		} else if creating {
+			canStoreFullImage := false
+			column.CanStoreFullImage = &canStoreFullImage
+		}
+	}
+
+	return column
+}
+
+// setColumnModelFromDto sets the model from the column metadata. Settings that don't apply to the column type are null,
+// so that a changed column type or changed settings show up as drift.
+func setColumnModelFromDto(model *ColumnResourceModel, columnType string, column *AttributeMetadataDto) (diags diag.Diagnostics) {
+	if model.LanguageCode.IsNull() || model.LanguageCode.IsUnknown() {
+		model.LanguageCode = types.Int64Value(defaultLanguageCode)
+	}
+	languageCode := model.LanguageCode.ValueInt64()
+
+	model.Id = types.StringValue(column.MetadataId)
+	model.SchemaName = types.StringValue(column.SchemaName)
+	model.LogicalName = types.StringValue(column.LogicalName)
+	model.Type = types.StringValue(columnType)
+	model.DisplayName = types.StringValue(labelText(column.DisplayName, languageCode))
+	model.Description = nullableStringValue(labelText(column.Description, languageCode))
+	model.RequiredLevel = types.StringValue("None")
+	if column.RequiredLevel != nil {
+		model.RequiredLevel = types.StringValue(column.RequiredLevel.Value)
+	}
+
+	model.MaxLength = types.Int64Null()
+	model.Format = types.StringNull()
+	model.MinValue = types.Float64Null()
+	model.MaxValue = types.Float64Null()
+	model.Precision = types.Int64Null()
+	model.DateTimeBehavior = types.StringNull()
+	model.TrueLabel = types.StringNull()
+	model.FalseLabel = types.StringNull()
+	model.MaxSizeInKb = types.Int64Null()
+	model.CanStoreFullImage = types.BoolNull()
+	if columnType != columnTypeLookup {
+		model.LookupTargetTable = types.StringNull()
+		model.RelationshipSchemaName = types.StringNull()
+	}
+	if columnType != columnTypeChoice && columnType != columnTypeMultiSelectChoice {
+		model.Options = nil
+		model.GlobalChoiceName = types.StringNull()
+	}
+
+	switch columnType {
+	case columnTypeString:
+		model.MaxLength = types.Int64PointerValue(column.MaxLength)
+		if column.FormatName != nil {
+			model.Format = types.StringValue(column.FormatName.Value)
+		}
+	case columnTypeMemo:
+		model.MaxLength = types.Int64PointerValue(column.MaxLength)
+		model.Format = types.StringPointerValue(column.Format)
+	case columnTypeInteger:
+		model.Format = types.StringPointerValue(column.Format)
+		model.MinValue = types.Float64PointerValue(column.MinValue)
+		model.MaxValue = types.Float64PointerValue(column.MaxValue)
+	case columnTypeDecimal, columnTypeMoney:
+		model.MinValue = types.Float64PointerValue(column.MinValue)
+		model.MaxValue = types.Float64PointerValue(column.MaxValue)
+		model.Precision = types.Int64PointerValue(column.Precision)
+	case columnTypeDateTime:
+		model.Format = types.StringPointerValue(column.Format)
+		if column.DateTimeBehavior != nil {
+			model.DateTimeBehavior = types.StringValue(column.DateTimeBehavior.Value)
+		}
+	case columnTypeBoolean:
+		if column.OptionSet != nil && column.OptionSet.TrueOption != nil && column.OptionSet.FalseOption != nil {
+			model.TrueLabel = types.StringValue(labelText(column.OptionSet.TrueOption.Label, languageCode))
+			model.FalseLabel = types.StringValue(labelText(column.OptionSet.FalseOption.Label, languageCode))
+		}
+	case columnTypeChoice, columnTypeMultiSelectChoice:
+		if column.OptionSet == nil {
+			diags.AddError("Missing option set", fmt.Sprintf("The option set of column '%s' is missing.", column.LogicalName))
+			break
+		}
+		if column.OptionSet.IsGlobal {
+			model.GlobalChoiceName = types.StringValue(column.OptionSet.Name)
+			model.Options = nil
+			break
+		}
+		model.GlobalChoiceName = types.StringNull()
+		model.Options = make([]ColumnOptionModel, 0, len(column.OptionSet.Options))
+		for _, option := range column.OptionSet.Options {
+			model.Options = append(model.Options, ColumnOptionModel{
+				Value: types.Int64Value(option.Value),
+				Label: types.StringValue(labelText(option.Label, languageCode)),
+			})
+		}
+	case columnTypeLookup:
+		if len(column.Targets) > 0 {
+			model.LookupTargetTable = types.StringValue(column.Targets[0])
+		}
+		if model.RelationshipSchemaName.IsUnknown() {
+			model.RelationshipSchemaName = types.StringNull()
+		}
+	case columnTypeFile:
+		model.MaxSizeInKb = types.Int64PointerValue(column.MaxSizeInKB)
+	case columnTypeImage:
+		model.MaxSizeInKb = types.Int64PointerValue(column.MaxSizeInKB)
+		model.CanStoreFullImage = types.BoolPointerValue(column.CanStoreFullImage)
+	}
+	return diags
+}
@@ -0,0 +1,299 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
+)
+
+const testColumnId = "44444444-4444-4444-4444-444444444444"
+
+func TestUnitColumnResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+	dataverse_metadata.RegisterMetadataEnvironmentMocks()
+
+	columnResponse := "get_column_created.json"
+	columnsUrl := fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28LogicalName=%%27cr123_project%%27%%29/Attributes", testEnvironmentHost)
+
+	httpmock.RegisterResponder("POST", columnsUrl,
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"SchemaName":"cr123_Stage"`) || !strings.Contains(string(body), `"AttributeType":"Picklist"`) || !strings.Contains(string(body), `"Value":100000001`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing choice column"}}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(fmt.Sprintf(`^%s%%28(LogicalName=%%27cr123_stage%%27|%s)%%29/Microsoft\.Dynamics\.CRM\.PicklistAttributeMetadata(\?%%24expand=OptionSet)?$`, regexp.QuoteMeta(columnsUrl), testColumnId)),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/"+columnResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("%s?%%24filter=LogicalName+eq+%%27cr123_stage%%27&%%24select=MetadataId%%2CLogicalName%%2CAttributeType%%2CAttributeTypeName", columnsUrl),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Column_Validate_CRUD/get_column_type.json").String()))
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%%28%s%%29", columnsUrl, testColumnId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Value":"ApplicationRequired"`) || !strings.Contains(string(body), `"Label":"Stage of the project"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing updated column"}}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	for _, action := range []string{"InsertOptionValue", "UpdateOptionValue", "DeleteOptionValue"} {
+		httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/%s", testEnvironmentHost, action),
+			func(req *http.Request) (*http.Response, error) {
+				columnResponse = "get_column_updated.json"
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			})
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/PublishXml", testEnvironmentHost),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s%%28%s%%29", columnsUrl, testColumnId),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "stage" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Stage"
+					type               = "Choice"
+					display_name       = "Stage"
+
+					options = [
+						{ value = 100000000, label = "Planned" },
+						{ value = 100000001, label = "Active" },
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "id", testColumnId),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "logical_name", "cr123_stage"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "required_level", "None"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.#", "2"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.1.label", "Active"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_column.stage", "max_length"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_column.stage", "global_choice_name"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "stage" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Stage"
+					type               = "Choice"
+					display_name       = "Stage"
+					description        = "Stage of the project"
+					required_level     = "ApplicationRequired"
+
+					options = [
+						{ value = 100000001, label = "In Progress" },
+						{ value = 100000002, label = "Completed" },
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "description", "Stage of the project"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "required_level", "ApplicationRequired"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.#", "2"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.0.label", "In Progress"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.1.value", "100000002"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_dataverse_column.stage",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testEnvironmentId + "/cr123_project/cr123_stage",
+			},
+		},
+	})
+}
+
+func TestUnitColumnResource_Validate_Type_Specific_Attributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "amount" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Amount"
+					type               = "Integer"
+					display_name       = "Amount"
+					max_length         = 100
+				}`,
+				ExpectError: regexp.MustCompile("`max_length` can only be set for columns of type String, Memo"),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "stage" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Stage"
+					type               = "Choice"
+					display_name       = "Stage"
+				}`,
+				ExpectError: regexp.MustCompile("Missing choice options"),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "account" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Account"
+					type               = "Lookup"
+					display_name       = "Account"
+				}`,
+				ExpectError: regexp.MustCompile("Missing lookup target table"),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_column" "budget" {
+					environment_id     = "` + testEnvironmentId + `"
+					table_logical_name = "cr123_project"
+					schema_name        = "cr123_Budget"
+					type               = "Money"
+					display_name       = "Budget"
+					precision          = 6
+				}`,
+				ExpectError: regexp.MustCompile("`Money` columns support a precision of at most 4"),
+			},
+		},
+	})
+}
+
+func TestAccColumnResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: columnAcceptanceResourceConfig(mocks.TestName(), "Active", 200),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_dataverse_column.stage", "id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "logical_name", "tfp_stage"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.code", "max_length", "200"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.account", "lookup_target_table", "account"),
+				),
+			},
+			{
+				Config: columnAcceptanceResourceConfig(mocks.TestName(), "In Progress", 400),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.stage", "options.1.label", "In Progress"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_column.code", "max_length", "400"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_dataverse_column.stage",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"solution_unique_name"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					environmentState := state.RootModule().Resources["powerplatform_environment.environment"]
+					return environmentState.Primary.ID + "/tfp_project/tfp_stage", nil
+				},
+			},
+		},
+	})
+}
+
+func columnAcceptanceResourceConfig(environmentDisplayName, activeLabel string, maxLength int) string {
+	return fmt.Sprintf(`
+resource "powerplatform_environment" "environment" {
+  display_name     = "%s"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "time_sleep" "wait_120_seconds" {
+  depends_on      = [powerplatform_environment.environment]
+  create_duration = "120s"
+}
+
+resource "powerplatform_publisher" "publisher" {
+  depends_on           = [time_sleep.wait_120_seconds]
+  environment_id       = powerplatform_environment.environment.id
+  uniquename           = "terraformpublisher"
+  friendly_name        = "Terraform Publisher"
+  customization_prefix = "tfp"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.publisher.environment_id
+  schema_name             = "tfp_Project"
+  display_name            = "Project"
+  display_collection_name = "Projects"
+
+  primary_name_column = {
+    schema_name  = "tfp_Name"
+    display_name = "Name"
+  }
+}
+
+resource "powerplatform_dataverse_column" "stage" {
+  environment_id     = powerplatform_dataverse_table.project.environment_id
+  table_logical_name = powerplatform_dataverse_table.project.logical_name
+  schema_name        = "tfp_Stage"
+  type               = "Choice"
+  display_name       = "Stage"
+
+  options = [
+    { value = 100000000, label = "Planned" },
+    { value = 100000001, label = "%s" },
+  ]
+}
+
+resource "powerplatform_dataverse_column" "code" {
+  environment_id     = powerplatform_dataverse_table.project.environment_id
+  table_logical_name = powerplatform_dataverse_table.project.logical_name
+  schema_name        = "tfp_Code"
+  type               = "String"
+  display_name       = "Code"
+  max_length         = %d
+}
+
+resource "powerplatform_dataverse_column" "account" {
+  environment_id      = powerplatform_dataverse_table.project.environment_id
+  table_logical_name  = powerplatform_dataverse_table.project.logical_name
+  schema_name         = "tfp_AccountId"
+  type                = "Lookup"
+  display_name        = "Account"
+  lookup_target_table = "account"
+}
+`, environmentDisplayName, activeLabel, maxLength)
+}
@@ -0,0 +1,371 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &GlobalChoiceResource{}
+var _ resource.ResourceWithConfigure = &GlobalChoiceResource{}
+var _ resource.ResourceWithImportState = &GlobalChoiceResource{}
+
+var languageCodeRegex = regexp.MustCompile(`^[0-9]+$`)
+
+var colorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+func NewGlobalChoiceResource() resource.Resource {
+	return &GlobalChoiceResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "dataverse_global_choice",
+		},
+	}
+}
+
+func (r *GlobalChoiceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *GlobalChoiceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	localizedLabelsValidators := []validator.Map{
+		mapvalidator.KeysAre(stringvalidator.RegexMatches(languageCodeRegex, "keys must be language codes, for example `1031`")),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a global choice (option set) that can be shared by choice columns of multiple tables. Options are inserted, updated, deleted and ordered individually, so that records using the choice keep their values. See [Create and update choices (option sets)](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/create-update-optionsets) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Metadata id of the global choice.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse-enabled environment.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"solution_unique_name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the unmanaged solution the global choice is added to when it is created. When omitted, the global choice is only added to the default solution.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the global choice, starting with the customization prefix of the publisher, for example `cr123_priority`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(schemaNameRegex, "name must start with a customization prefix followed by '_'"),
+				},
+			},
+			"language_code": schema.Int64Attribute{
+				MarkdownDescription: "Language code of `display_name`, `description` and the option labels and descriptions. Defaults to `1033` (English).",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultLanguageCode),
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the global choice.",
+				Required:            true,
+			},
+			"localized_display_names": schema.MapAttribute{
+				MarkdownDescription: "Translations of the display name, keyed by language code, for example `{ \"1031\" = \"Priorität\" }`. The languages must be enabled in the environment. When set, translations added outside of Terraform are reported as drift.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          localizedLabelsValidators,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the global choice.",
+				Optional:            true,
+			},
+			"options": schema.ListNestedAttribute{
+				MarkdownDescription: "Options of the global choice, in the order they are shown in apps.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"value": schema.Int64Attribute{
+							MarkdownDescription: "Value of the option, usually starting with the option value prefix of the publisher. Changing the value deletes the option and inserts a new one.",
+							Required:            true,
+						},
+						"label": schema.StringAttribute{
+							MarkdownDescription: "Label of the option.",
+							Required:            true,
+						},
+						"localized_labels": schema.MapAttribute{
+							MarkdownDescription: "Translations of the label, keyed by language code. When set, translations added outside of Terraform are reported as drift.",
+							Optional:            true,
+							ElementType:         types.StringType,
+							Validators:          localizedLabelsValidators,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the option.",
+							Optional:            true,
+						},
+						"color": schema.StringAttribute{
+							MarkdownDescription: "Color of the option as a hexadecimal value, for example `#0000ff`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(colorRegex, "color must be a hexadecimal color, for example `#0000ff`"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *GlobalChoiceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.MetadataClient = newDataverseMetadataClient(providerClient.Api)
+}
+
+func (r *GlobalChoiceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan GlobalChoiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optionSet, err := r.MetadataClient.CreateGlobalChoice(ctx, plan.EnvironmentId.ValueString(), plan.SolutionUniqueName.ValueString(), globalChoiceDtoFromModel(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setGlobalChoiceModelFromDto(&plan, optionSet)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GlobalChoiceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state GlobalChoiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optionSet, err := r.MetadataClient.GetGlobalChoice(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setGlobalChoiceModelFromDto(&state, optionSet)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GlobalChoiceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan GlobalChoiceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state GlobalChoiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	optionSet, err := r.MetadataClient.UpdateGlobalChoice(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), plan.SolutionUniqueName.ValueString(), plan.LanguageCode.ValueInt64(), globalChoiceDtoFromModel(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setGlobalChoiceModelFromDto(&plan, optionSet)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GlobalChoiceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state GlobalChoiceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.MetadataClient.DeleteGlobalChoice(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+	}
+}
+
+func (r *GlobalChoiceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, globalChoice, found := strings.Cut(req.ID, "/")
+	if !found || !guidRegex.MatchString(environmentId) || globalChoice == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id/global_choice_name' or 'environment_id/global_choice_id', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("language_code"), int64(defaultLanguageCode))...)
+	if guidRegex.MatchString(globalChoice) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), globalChoice)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), globalChoice)...)
+	}
+}
+
+func globalChoiceDtoFromModel(model *GlobalChoiceResourceModel) optionSetDto {
+	languageCode := model.LanguageCode.ValueInt64()
+	optionSet := optionSetDto{
+		Name:        model.Name.ValueString(),
+		DisplayName: newTranslatedLabel(model.DisplayName.ValueString(), languageCode, translationsFromModel(model.LocalizedDisplayNames)),
+		Description: newLabel(model.Description.ValueString(), languageCode),
+		Options:     make([]optionDto, 0, len(model.Options)),
+	}
+	for _, option := range model.Options {
+		optionSet.Options = append(optionSet.Options, optionDto{
+			Value:       option.Value.ValueInt64(),
+			Label:       newTranslatedLabel(option.Label.ValueString(), languageCode, translationsFromModel(option.LocalizedLabels)),
+			Description: newLabel(option.Description.ValueString(), languageCode),
+			Color:       option.Color.ValueString(),
+		})
+	}
+	return optionSet
+}
+
+// setGlobalChoiceModelFromDto sets the model from the global choice metadata. Translations are only read
+// when they are managed, so that translations maintained outside of Terraform don't show up as drift.
+func setGlobalChoiceModelFromDto(model *GlobalChoiceResourceModel, optionSet *optionSetDto) {
+	if model.LanguageCode.IsNull() || model.LanguageCode.IsUnknown() {
+		model.LanguageCode = types.Int64Value(defaultLanguageCode)
+	}
+	languageCode := model.LanguageCode.ValueInt64()
+
+	model.Id = types.StringValue(optionSet.MetadataId)
+	model.Name = types.StringValue(optionSet.Name)
+	model.DisplayName = types.StringValue(labelText(optionSet.DisplayName, languageCode))
+	model.Description = nullableStringValue(labelText(optionSet.Description, languageCode))
+	if model.LocalizedDisplayNames != nil {
+		model.LocalizedDisplayNames = translationsToModel(labelTranslations(optionSet.DisplayName, languageCode))
+	}
+
+	managedTranslations := map[int64]bool{}
+	for _, option := range model.Options {
+		managedTranslations[option.Value.ValueInt64()] = option.LocalizedLabels != nil
+	}
+
+	options := make([]GlobalChoiceOptionModel, 0, len(optionSet.Options))
+	for _, option := range optionSet.Options {
+		optionModel := GlobalChoiceOptionModel{
+			Value:       types.Int64Value(option.Value),
+			Label:       types.StringValue(labelText(option.Label, languageCode)),
+			Description: nullableStringValue(labelText(option.Description, languageCode)),
+			Color:       nullableStringValue(option.Color),
+		}
+		if managedTranslations[option.Value] {
+			optionModel.LocalizedLabels = translationsToModel(labelTranslations(option.Label, languageCode))
+		}
+		options = append(options, optionModel)
+	}
+	model.Options = options
+}
+
+// translationsFromModel converts translations keyed by language code strings, as they are configured, to translations keyed by language code.
+// Translations that are not configured stay nil, so they are not managed.
+func translationsFromModel(translations map[string]string) map[int64]string {
+	if translations == nil {
+		return nil
+	}
+	result := map[int64]string{}
+	for languageCode, text := range translations {
+		code, err := strconv.ParseInt(languageCode, 10, 64)
+		if err != nil {
+			// keys are validated to be language codes by the schema.
+			continue
+		}
+		result[code] = text
+	}
+	return result
+}
+
+func translationsToModel(translations map[int64]string) map[string]string {
+	result := map[string]string{}
+	for languageCode, text := range translations {
+		result[strconv.FormatInt(languageCode, 10)] = text
+	}
+	return result
+}
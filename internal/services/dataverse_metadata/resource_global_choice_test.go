@@ -0,0 +1,211 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
+)
+
+const testGlobalChoiceId = "66666666-6666-6666-6666-666666666666"
+
+func TestUnitGlobalChoiceResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+	dataverse_metadata.RegisterMetadataEnvironmentMocks()
+
+	globalChoiceResponse := "get_global_choice_created.json"
+	globalChoicesUrl := fmt.Sprintf("https://%s/api/data/v9.2/GlobalOptionSetDefinitions", testEnvironmentHost)
+
+	httpmock.RegisterResponder("POST", globalChoicesUrl,
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Name":"cr123_priority"`) || !strings.Contains(string(body), `"IsGlobal":true`) || !strings.Contains(string(body), `"Label":"Priorität"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing global choice"}}`), nil
+			}
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("%s(%s)", globalChoicesUrl, testGlobalChoiceId))
+			return resp, nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(fmt.Sprintf(`^%s%%28(%s|Name=%%27cr123_priority%%27)%%29$`, regexp.QuoteMeta(globalChoicesUrl), testGlobalChoiceId)),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Global_Choice_Validate_CRUD/"+globalChoiceResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s%%28%s%%29", globalChoicesUrl, testGlobalChoiceId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Label":"Priority of work items"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing updated description"}}`), nil
+			}
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	for _, action := range []string{"InsertOptionValue", "UpdateOptionValue", "DeleteOptionValue", "OrderOption"} {
+		httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/%s", testEnvironmentHost, action),
+			func(req *http.Request) (*http.Response, error) {
+				globalChoiceResponse = "get_global_choice_updated.json"
+				return httpmock.NewStringResponse(http.StatusOK, "{}"), nil
+			})
+	}
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/PublishXml", testEnvironmentHost),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s%%28%s%%29", globalChoicesUrl, testGlobalChoiceId),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_global_choice" "priority" {
+					environment_id          = "` + testEnvironmentId + `"
+					name                    = "cr123_priority"
+					display_name            = "Priority"
+					localized_display_names = { "1031" = "Priorität" }
+
+					options = [
+						{ value = 100000000, label = "Low", color = "#00ff00" },
+						{ value = 100000001, label = "Medium" },
+						{ value = 100000002, label = "High", color = "#ff0000", localized_labels = { "1031" = "Hoch" } },
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "id", testGlobalChoiceId),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "localized_display_names.1031", "Priorität"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.#", "3"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.0.color", "#00ff00"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_global_choice.priority", "options.1.color"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_global_choice.priority", "options.1.localized_labels"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.2.localized_labels.1031", "Hoch"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_global_choice" "priority" {
+					environment_id          = "` + testEnvironmentId + `"
+					name                    = "cr123_priority"
+					display_name            = "Priority"
+					localized_display_names = { "1031" = "Priorität" }
+					description             = "Priority of work items"
+
+					options = [
+						{ value = 100000002, label = "Critical", color = "#ff0000", description = "Needs immediate attention", localized_labels = { "1031" = "Kritisch" } },
+						{ value = 100000001, label = "Medium" },
+						{ value = 100000003, label = "Deferred" },
+					]
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "description", "Priority of work items"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.#", "3"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.0.value", "100000002"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.0.description", "Needs immediate attention"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.0.localized_labels.1031", "Kritisch"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.2.label", "Deferred"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_dataverse_global_choice.priority",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testEnvironmentId + "/cr123_priority",
+				ImportStateVerifyIgnore: []string{"localized_display_names", "options"},
+			},
+		},
+	})
+}
+
+func TestAccGlobalChoiceResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: globalChoiceAcceptanceResourceConfig(mocks.TestName(), "High"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_dataverse_global_choice.priority", "id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "name", "tfp_priority"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.#", "2"),
+				),
+			},
+			{
+				Config: globalChoiceAcceptanceResourceConfig(mocks.TestName(), "Critical"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_global_choice.priority", "options.1.label", "Critical"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_dataverse_global_choice.priority",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"solution_unique_name"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					environmentState := state.RootModule().Resources["powerplatform_environment.environment"]
+					return environmentState.Primary.ID + "/tfp_priority", nil
+				},
+			},
+		},
+	})
+}
+
+func globalChoiceAcceptanceResourceConfig(environmentDisplayName, highLabel string) string {
+	return fmt.Sprintf(`
+resource "powerplatform_environment" "environment" {
+  display_name     = "%s"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "time_sleep" "wait_120_seconds" {
+  depends_on      = [powerplatform_environment.environment]
+  create_duration = "120s"
+}
+
+resource "powerplatform_publisher" "publisher" {
+  depends_on           = [time_sleep.wait_120_seconds]
+  environment_id       = powerplatform_environment.environment.id
+  uniquename           = "terraformpublisher"
+  friendly_name        = "Terraform Publisher"
+  customization_prefix = "tfp"
+}
+
+resource "powerplatform_dataverse_global_choice" "priority" {
+  environment_id = powerplatform_publisher.publisher.environment_id
+  name           = "tfp_priority"
+  display_name   = "Priority"
+
+  options = [
+    { value = 100000000, label = "Low" },
+    { value = 100000001, label = "%s", color = "#ff0000" },
+  ]
+}
+`, environmentDisplayName, highLabel)
+}
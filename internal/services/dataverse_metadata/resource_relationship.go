@@ -0,0 +1,542 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &RelationshipResource{}
+var _ resource.ResourceWithConfigure = &RelationshipResource{}
+var _ resource.ResourceWithImportState = &RelationshipResource{}
+var _ resource.ResourceWithValidateConfig = &RelationshipResource{}
+
+const (
+	relationshipOneToMany  = "OneToMany"
+	relationshipManyToOne  = "ManyToOne"
+	relationshipManyToMany = "ManyToMany"
+)
+
+var cascadeConfigurationAttributeTypes = map[string]attr.Type{
+	"assign":   types.StringType,
+	"delete":   types.StringType,
+	"merge":    types.StringType,
+	"reparent": types.StringType,
+	"share":    types.StringType,
+	"unshare":  types.StringType,
+}
+
+func NewRelationshipResource() resource.Resource {
+	return &RelationshipResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "dataverse_relationship",
+		},
+	}
+}
+
+func (r *RelationshipResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *RelationshipResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	cascadeAttribute := func(description, defaultValue string, values ...string) schema.StringAttribute {
+		return schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("%s Valid values are `%s`. Defaults to `%s`.", description, strings.Join(values, "`, `"), defaultValue),
+			Optional:            true,
+			Computed:            true,
+			Default:             stringdefault.StaticString(defaultValue),
+			Validators: []validator.String{
+				stringvalidator.OneOf(values...),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a relationship between two Dataverse tables. One-to-many and many-to-one relationships create a lookup column on the table of the many side, many-to-many relationships create an intersect table. The tables are published after changes have been applied. See [Create and update table relationships using the Web API](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/create-update-entity-relationships-using-web-api) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Metadata id of the relationship.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse-enabled environment containing the tables.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"solution_unique_name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the unmanaged solution the relationship is added to when it is created. When omitted, the relationship is only added to the default solution.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_name": schema.StringAttribute{
+				MarkdownDescription: "Schema name of the relationship, starting with the customization prefix of the publisher, for example `cr123_account_cr123_project`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(schemaNameRegex, "schema_name must start with a customization prefix followed by '_'"),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of the relationship from the perspective of `table_logical_name`. Valid values are `OneToMany`, `ManyToOne` and `ManyToMany`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(relationshipOneToMany, relationshipManyToOne, relationshipManyToMany),
+				},
+			},
+			"table_logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the table the relationship is defined from. It is the referenced table of `OneToMany`, the referencing table of `ManyToOne` and the first table of `ManyToMany` relationships.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"related_table_logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the related table. It is the referencing table of `OneToMany`, the referenced table of `ManyToOne` and the second table of `ManyToMany` relationships.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"language_code": schema.Int64Attribute{
+				MarkdownDescription: "Language code of the labels of the lookup column. Defaults to `1033` (English).",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultLanguageCode),
+			},
+			"lookup_column": schema.SingleNestedAttribute{
+				MarkdownDescription: "Lookup column created on the referencing table of `OneToMany` and `ManyToOne` relationships.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"schema_name": schema.StringAttribute{
+						MarkdownDescription: "Schema name of the lookup column, for example `cr123_AccountId`.",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(schemaNameRegex, "schema_name must start with a customization prefix followed by '_'"),
+						},
+					},
+					"logical_name": schema.StringAttribute{
+						MarkdownDescription: "Logical name of the lookup column.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"display_name": schema.StringAttribute{
+						MarkdownDescription: "Display name of the lookup column.",
+						Required:            true,
+					},
+					"description": schema.StringAttribute{
+						MarkdownDescription: "Description of the lookup column.",
+						Optional:            true,
+					},
+					"required_level": schema.StringAttribute{
+						MarkdownDescription: "Requirement level of the lookup column. Valid values are `None`, `Recommended` and `ApplicationRequired`. Defaults to `None`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("None"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("None", "Recommended", "ApplicationRequired"),
+						},
+					},
+				},
+			},
+			"cascade_configuration": schema.SingleNestedAttribute{
+				MarkdownDescription: "Behavior of `OneToMany` and `ManyToOne` relationships when an action is taken on a record of the referenced table. See [Configure table relationship behavior](https://learn.microsoft.com/power-apps/maker/data-platform/create-edit-1n-relationships#add-advanced-relationship-behavior) for more information.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"assign":   cascadeAttribute("Behavior when the referenced record is assigned to another owner.", "NoCascade", "Cascade", "Active", "UserOwned", "NoCascade"),
+					"delete":   cascadeAttribute("Behavior when the referenced record is deleted.", "RemoveLink", "Cascade", "RemoveLink", "Restrict"),
+					"merge":    cascadeAttribute("Behavior when the referenced record is merged.", "NoCascade", "Cascade", "NoCascade"),
+					"reparent": cascadeAttribute("Behavior when the parent of the referenced record changes.", "NoCascade", "Cascade", "Active", "UserOwned", "NoCascade"),
+					"share":    cascadeAttribute("Behavior when the referenced record is shared.", "NoCascade", "Cascade", "Active", "UserOwned", "NoCascade"),
+					"unshare":  cascadeAttribute("Behavior when sharing of the referenced record is removed.", "NoCascade", "Cascade", "Active", "UserOwned", "NoCascade"),
+				},
+			},
+			"intersect_entity_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the intersect table of `ManyToMany` relationships, for example `cr123_project_contact`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *RelationshipResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var relationshipType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &relationshipType)...)
+	if resp.Diagnostics.HasError() || relationshipType.IsNull() || relationshipType.IsUnknown() {
+		return
+	}
+
+	configured := func(name string) bool {
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		return value != nil && !value.IsNull()
+	}
+
+	if relationshipType.ValueString() == relationshipManyToMany {
+		if !configured("intersect_entity_name") {
+			resp.Diagnostics.AddAttributeError(path.Root("intersect_entity_name"), "Missing intersect entity name", "ManyToMany relationships require `intersect_entity_name`.")
+		}
+		for _, name := range []string{"lookup_column", "cascade_configuration"} {
+			if configured(name) {
+				resp.Diagnostics.AddAttributeError(path.Root(name), "Invalid attribute for relationship type", fmt.Sprintf("`%s` can only be set for OneToMany and ManyToOne relationships.", name))
+			}
+		}
+		return
+	}
+
+	if !configured("lookup_column") {
+		resp.Diagnostics.AddAttributeError(path.Root("lookup_column"), "Missing lookup column", "OneToMany and ManyToOne relationships require `lookup_column`.")
+	}
+	if configured("intersect_entity_name") {
+		resp.Diagnostics.AddAttributeError(path.Root("intersect_entity_name"), "Invalid attribute for relationship type", "`intersect_entity_name` can only be set for ManyToMany relationships.")
+	}
+}
+
+func (r *RelationshipResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.MetadataClient = newDataverseMetadataClient(providerClient.Api)
+}
+
+func (r *RelationshipResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan RelationshipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Type.ValueString() == relationshipManyToMany {
+		relationship, err := r.MetadataClient.CreateManyToManyRelationship(ctx, plan.EnvironmentId.ValueString(), plan.SolutionUniqueName.ValueString(), manyToManyRelationshipDto{
+			SchemaName:          plan.SchemaName.ValueString(),
+			Entity1LogicalName:  plan.TableLogicalName.ValueString(),
+			Entity2LogicalName:  plan.RelatedTableLogicalName.ValueString(),
+			IntersectEntityName: plan.IntersectEntityName.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+			return
+		}
+		setManyToManyRelationshipModelFromDto(&plan, relationship)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	relationship, diags := oneToManyRelationshipDtoFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.MetadataClient.CreateOneToManyRelationship(ctx, plan.EnvironmentId.ValueString(), plan.SolutionUniqueName.ValueString(), relationship)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setOneToManyRelationshipModelFromDto(&plan, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RelationshipResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state RelationshipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oneToMany, manyToMany, err := r.MetadataClient.GetRelationship(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), state.SchemaName.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	if manyToMany != nil {
+		setManyToManyRelationshipModelFromDto(&state, manyToMany)
+	} else {
+		resp.Diagnostics.Append(setOneToManyRelationshipModelFromDto(&state, oneToMany)...)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *RelationshipResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan RelationshipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state RelationshipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Type.ValueString() == relationshipManyToMany {
+		// all settings of many-to-many relationships require a replacement, only Terraform specific settings can change.
+		oneToMany, manyToMany, err := r.MetadataClient.GetRelationship(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), "")
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+			return
+		}
+		if manyToMany == nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), fmt.Sprintf("relationship '%s' is not a many-to-many relationship", oneToMany.SchemaName))
+			return
+		}
+		setManyToManyRelationshipModelFromDto(&plan, manyToMany)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	relationship, diags := oneToManyRelationshipDtoFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.MetadataClient.UpdateOneToManyRelationship(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), plan.SolutionUniqueName.ValueString(), plan.LanguageCode.ValueInt64(), relationship)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(setOneToManyRelationshipModelFromDto(&plan, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *RelationshipResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state RelationshipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.MetadataClient.DeleteRelationship(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+	}
+}
+
+func (r *RelationshipResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || !guidRegex.MatchString(parts[0]) || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id/table_logical_name/relationship_schema_name' or 'environment_id/table_logical_name/relationship_id', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("table_logical_name"), strings.ToLower(parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("language_code"), int64(defaultLanguageCode))...)
+	if guidRegex.MatchString(parts[2]) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("schema_name"), parts[2])...)
+	}
+}
+
+// oneToManyRelationshipDtoFromModel returns the definition of a one-to-many relationship. The referenced table
+// is `table_logical_name` of one-to-many and `related_table_logical_name` of many-to-one relationships.
+func oneToManyRelationshipDtoFromModel(ctx context.Context, model *RelationshipResourceModel) (oneToManyRelationshipDto, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	relationship := oneToManyRelationshipDto{
+		SchemaName:        model.SchemaName.ValueString(),
+		ReferencedEntity:  model.TableLogicalName.ValueString(),
+		ReferencingEntity: model.RelatedTableLogicalName.ValueString(),
+	}
+	if model.Type.ValueString() == relationshipManyToOne {
+		relationship.ReferencedEntity, relationship.ReferencingEntity = relationship.ReferencingEntity, relationship.ReferencedEntity
+	}
+
+	if model.LookupColumn != nil {
+		languageCode := model.LanguageCode.ValueInt64()
+		relationship.Lookup = &AttributeMetadataDto{
+			SchemaName:    model.LookupColumn.SchemaName.ValueString(),
+			DisplayName:   newLabel(model.LookupColumn.DisplayName.ValueString(), languageCode),
+			Description:   newLabel(model.LookupColumn.Description.ValueString(), languageCode),
+			RequiredLevel: &requiredLevelDto{Value: model.LookupColumn.RequiredLevel.ValueString()},
+		}
+	}
+
+	if !model.CascadeConfiguration.IsNull() && !model.CascadeConfiguration.IsUnknown() {
+		var cascadeConfiguration RelationshipCascadeConfigurationModel
+		diags.Append(model.CascadeConfiguration.As(ctx, &cascadeConfiguration, basetypes.ObjectAsOptions{UnhandledNullAsEmpty: true, UnhandledUnknownAsEmpty: true})...)
+		relationship.CascadeConfiguration = &cascadeConfigurationDto{
+			Assign:   cascadeConfiguration.Assign.ValueString(),
+			Delete:   cascadeConfiguration.Delete.ValueString(),
+			Merge:    cascadeConfiguration.Merge.ValueString(),
+			Reparent: cascadeConfiguration.Reparent.ValueString(),
+			Share:    cascadeConfiguration.Share.ValueString(),
+			Unshare:  cascadeConfiguration.Unshare.ValueString(),
+		}
+	}
+	return relationship, diags
+}
+
+func setOneToManyRelationshipModelFromDto(model *RelationshipResourceModel, relationship *oneToManyRelationshipDto) diag.Diagnostics {
+	if model.LanguageCode.IsNull() || model.LanguageCode.IsUnknown() {
+		model.LanguageCode = types.Int64Value(defaultLanguageCode)
+	}
+	languageCode := model.LanguageCode.ValueInt64()
+
+	// an imported relationship is read from the perspective of the table it was imported from.
+	if model.Type.IsNull() || model.Type.IsUnknown() || model.Type.ValueString() == relationshipManyToMany {
+		model.Type = types.StringValue(relationshipOneToMany)
+		if strings.EqualFold(model.TableLogicalName.ValueString(), relationship.ReferencingEntity) && !strings.EqualFold(relationship.ReferencingEntity, relationship.ReferencedEntity) {
+			model.Type = types.StringValue(relationshipManyToOne)
+		}
+	}
+
+	model.Id = types.StringValue(relationship.MetadataId)
+	model.SchemaName = types.StringValue(relationship.SchemaName)
+	model.TableLogicalName = types.StringValue(relationship.ReferencedEntity)
+	model.RelatedTableLogicalName = types.StringValue(relationship.ReferencingEntity)
+	if model.Type.ValueString() == relationshipManyToOne {
+		model.TableLogicalName, model.RelatedTableLogicalName = model.RelatedTableLogicalName, model.TableLogicalName
+	}
+	model.IntersectEntityName = types.StringNull()
+
+	model.LookupColumn = nil
+	if relationship.Lookup != nil {
+		requiredLevel := "None"
+		if relationship.Lookup.RequiredLevel != nil {
+			requiredLevel = relationship.Lookup.RequiredLevel.Value
+		}
+		model.LookupColumn = &RelationshipLookupColumnModel{
+			SchemaName:    types.StringValue(relationship.Lookup.SchemaName),
+			LogicalName:   types.StringValue(relationship.Lookup.LogicalName),
+			DisplayName:   types.StringValue(labelText(relationship.Lookup.DisplayName, languageCode)),
+			Description:   nullableStringValue(labelText(relationship.Lookup.Description, languageCode)),
+			RequiredLevel: types.StringValue(requiredLevel),
+		}
+	}
+
+	cascadeConfiguration := cascadeConfigurationWithoutRollupView(relationship.CascadeConfiguration)
+	cascadeConfigurationObject, diags := types.ObjectValue(cascadeConfigurationAttributeTypes, map[string]attr.Value{
+		"assign":   types.StringValue(cascadeConfiguration.Assign),
+		"delete":   types.StringValue(cascadeConfiguration.Delete),
+		"merge":    types.StringValue(cascadeConfiguration.Merge),
+		"reparent": types.StringValue(cascadeConfiguration.Reparent),
+		"share":    types.StringValue(cascadeConfiguration.Share),
+		"unshare":  types.StringValue(cascadeConfiguration.Unshare),
+	})
+	model.CascadeConfiguration = cascadeConfigurationObject
+	return diags
+}
+
+func setManyToManyRelationshipModelFromDto(model *RelationshipResourceModel, relationship *manyToManyRelationshipDto) {
+	if model.LanguageCode.IsNull() || model.LanguageCode.IsUnknown() {
+		model.LanguageCode = types.Int64Value(defaultLanguageCode)
+	}
+
+	// the tables of many-to-many relationships are interchangeable, so the order of the configuration is kept.
+	swapTables := strings.EqualFold(model.TableLogicalName.ValueString(), relationship.Entity2LogicalName) && !strings.EqualFold(relationship.Entity1LogicalName, relationship.Entity2LogicalName)
+
+	model.Type = types.StringValue(relationshipManyToMany)
+	model.Id = types.StringValue(relationship.MetadataId)
+	model.SchemaName = types.StringValue(relationship.SchemaName)
+	model.TableLogicalName = types.StringValue(relationship.Entity1LogicalName)
+	model.RelatedTableLogicalName = types.StringValue(relationship.Entity2LogicalName)
+	if swapTables {
+		model.TableLogicalName, model.RelatedTableLogicalName = model.RelatedTableLogicalName, model.TableLogicalName
+	}
+	model.IntersectEntityName = types.StringValue(relationship.IntersectEntityName)
+	model.LookupColumn = nil
+	model.CascadeConfiguration = types.ObjectNull(cascadeConfigurationAttributeTypes)
+}
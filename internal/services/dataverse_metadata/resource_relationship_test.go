@@ -0,0 +1,291 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
+)
+
+const testRelationshipId = "77777777-7777-7777-7777-777777777777"
+
+func TestUnitRelationshipResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+	dataverse_metadata.RegisterMetadataEnvironmentMocks()
+
+	relationshipResponse, lookupColumnResponse := "get_relationship_created.json", "get_lookup_column_created.json"
+	apiUrl := fmt.Sprintf("https://%s/api/data/v9.2", testEnvironmentHost)
+	relationshipKey := fmt.Sprintf("(%s|SchemaName=%%27cr123_account_cr123_project%%27)", testRelationshipId)
+	lookupColumnUrl := apiUrl + "/EntityDefinitions%28LogicalName=%27cr123_project%27%29/Attributes%28LogicalName=%27cr123_accountid%27%29"
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^`+regexp.QuoteMeta(apiUrl+"/EntityDefinitions%28LogicalName=%27account%27%29?%24select=")),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/get_account_table.json").String()))
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^`+regexp.QuoteMeta(apiUrl+"/EntityDefinitions%2870000000-0000-0000-0000-000000000001%29/Attributes%28LogicalName=%27name%27%29/Microsoft.Dynamics.CRM.StringAttributeMetadata?")),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/get_account_primary_name_column.json").String()))
+
+	httpmock.RegisterResponder("POST", apiUrl+"/RelationshipDefinitions",
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"ReferencedEntity":"account"`) || !strings.Contains(string(body), `"ReferencingEntity":"cr123_project"`) || !strings.Contains(string(body), `"SchemaName":"cr123_AccountId"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing relationship"}}`), nil
+			}
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("%s/RelationshipDefinitions(%s)", apiUrl, testRelationshipId))
+			return resp, nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^`+regexp.QuoteMeta(apiUrl+"/RelationshipDefinitions%28")+relationshipKey+regexp.QuoteMeta("%29?%24select=SchemaName%2CRelationshipType")+`$`),
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/get_relationship_type.json").String()))
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(`^`+regexp.QuoteMeta(apiUrl+"/RelationshipDefinitions%28")+relationshipKey+regexp.QuoteMeta("%29/Microsoft.Dynamics.CRM.OneToManyRelationshipMetadata")+`$`),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/"+relationshipResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", lookupColumnUrl+"/Microsoft.Dynamics.CRM.LookupAttributeMetadata",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Relationship_Validate_CRUD/"+lookupColumnResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("%s/RelationshipDefinitions%%28%s%%29", apiUrl, testRelationshipId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Delete":"Restrict"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing cascade configuration"}}`), nil
+			}
+			relationshipResponse = "get_relationship_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("PUT", lookupColumnUrl,
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Label":"Customer"`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing lookup column label"}}`), nil
+			}
+			lookupColumnResponse = "get_lookup_column_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("POST", apiUrl+"/PublishXml",
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("%s/RelationshipDefinitions%%28%s%%29", apiUrl, testRelationshipId),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_relationship" "project_account" {
+					environment_id             = "` + testEnvironmentId + `"
+					schema_name                = "cr123_account_cr123_project"
+					type                       = "ManyToOne"
+					table_logical_name         = "cr123_project"
+					related_table_logical_name = "account"
+
+					lookup_column = {
+						schema_name  = "cr123_AccountId"
+						display_name = "Account"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "id", testRelationshipId),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "lookup_column.logical_name", "cr123_accountid"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "lookup_column.required_level", "None"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "cascade_configuration.delete", "RemoveLink"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_relationship.project_account", "intersect_entity_name"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_relationship" "project_account" {
+					environment_id             = "` + testEnvironmentId + `"
+					schema_name                = "cr123_account_cr123_project"
+					type                       = "ManyToOne"
+					table_logical_name         = "cr123_project"
+					related_table_logical_name = "account"
+
+					lookup_column = {
+						schema_name    = "cr123_AccountId"
+						display_name   = "Customer"
+						required_level = "ApplicationRequired"
+					}
+
+					cascade_configuration = {
+						delete  = "Restrict"
+						share   = "Cascade"
+						unshare = "Cascade"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "lookup_column.display_name", "Customer"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "lookup_column.required_level", "ApplicationRequired"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "cascade_configuration.delete", "Restrict"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "cascade_configuration.share", "Cascade"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "cascade_configuration.assign", "NoCascade"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_dataverse_relationship.project_account",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testEnvironmentId + "/cr123_project/cr123_account_cr123_project",
+			},
+		},
+	})
+}
+
+func TestUnitRelationshipResource_Validate_Type_Specific_Attributes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_relationship" "project_contact" {
+					environment_id             = "` + testEnvironmentId + `"
+					schema_name                = "cr123_project_contact"
+					type                       = "ManyToMany"
+					table_logical_name         = "cr123_project"
+					related_table_logical_name = "contact"
+				}`,
+				ExpectError: regexp.MustCompile("Missing intersect entity name"),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_relationship" "project_account" {
+					environment_id             = "` + testEnvironmentId + `"
+					schema_name                = "cr123_account_cr123_project"
+					type                       = "OneToMany"
+					table_logical_name         = "account"
+					related_table_logical_name = "cr123_project"
+					intersect_entity_name      = "cr123_account_project"
+				}`,
+				ExpectError: regexp.MustCompile("Missing lookup column"),
+			},
+		},
+	})
+}
+
+func TestAccRelationshipResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: relationshipAcceptanceResourceConfig(mocks.TestName(), "RemoveLink"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_dataverse_relationship.project_account", "id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "lookup_column.logical_name", "tfp_accountid"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_contact", "intersect_entity_name", "tfp_project_contact"),
+				),
+			},
+			{
+				Config: relationshipAcceptanceResourceConfig(mocks.TestName(), "Restrict"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_relationship.project_account", "cascade_configuration.delete", "Restrict"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_dataverse_relationship.project_account",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"solution_unique_name"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					environmentState := state.RootModule().Resources["powerplatform_environment.environment"]
+					return environmentState.Primary.ID + "/tfp_project/tfp_account_tfp_project", nil
+				},
+			},
+		},
+	})
+}
+
+func relationshipAcceptanceResourceConfig(environmentDisplayName, deleteBehavior string) string {
+	return fmt.Sprintf(`
+resource "powerplatform_environment" "environment" {
+  display_name     = "%s"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "time_sleep" "wait_120_seconds" {
+  depends_on      = [powerplatform_environment.environment]
+  create_duration = "120s"
+}
+
+resource "powerplatform_publisher" "publisher" {
+  depends_on           = [time_sleep.wait_120_seconds]
+  environment_id       = powerplatform_environment.environment.id
+  uniquename           = "terraformpublisher"
+  friendly_name        = "Terraform Publisher"
+  customization_prefix = "tfp"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.publisher.environment_id
+  schema_name             = "tfp_Project"
+  display_name            = "Project"
+  display_collection_name = "Projects"
+
+  primary_name_column = {
+    schema_name  = "tfp_Name"
+    display_name = "Name"
+  }
+}
+
+resource "powerplatform_dataverse_relationship" "project_account" {
+  environment_id             = powerplatform_dataverse_table.project.environment_id
+  schema_name                = "tfp_account_tfp_project"
+  type                       = "ManyToOne"
+  table_logical_name         = powerplatform_dataverse_table.project.logical_name
+  related_table_logical_name = "account"
+
+  lookup_column = {
+    schema_name  = "tfp_AccountId"
+    display_name = "Account"
+  }
+
+  cascade_configuration = {
+    delete = "%s"
+  }
+}
+
+resource "powerplatform_dataverse_relationship" "project_contact" {
+  environment_id             = powerplatform_dataverse_table.project.environment_id
+  schema_name                = "tfp_project_contact"
+  type                       = "ManyToMany"
+  table_logical_name         = powerplatform_dataverse_table.project.logical_name
+  related_table_logical_name = "contact"
+  intersect_entity_name      = "tfp_project_contact"
+}
+`, environmentDisplayName, deleteBehavior)
+}
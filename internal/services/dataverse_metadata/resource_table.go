@@ -0,0 +1,418 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &TableResource{}
+var _ resource.ResourceWithConfigure = &TableResource{}
+var _ resource.ResourceWithImportState = &TableResource{}
+
+// schemaNameRegex matches schema names of custom components, which start with the customization prefix of their publisher.
+var schemaNameRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*_[A-Za-z0-9_]+$`)
+
+var guidRegex = regexp.MustCompile(helpers.GuidRegex)
+
+func NewTableResource() resource.Resource {
+	return &TableResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "dataverse_table",
+		},
+	}
+}
+
+func (r *TableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *TableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a custom Dataverse table. Changes to the table are published after they have been applied. See [Create and update table definitions using the Web API](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/create-update-entity-definitions-using-web-api) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Metadata id of the table.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse-enabled environment containing the table.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"solution_unique_name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the unmanaged solution the table is added to when it is created. When omitted, the table is only added to the default solution.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema_name": schema.StringAttribute{
+				MarkdownDescription: "Schema name of the table, starting with the customization prefix of the publisher, for example `cr123_Project`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(schemaNameRegex, "schema_name must start with a customization prefix followed by '_'"),
+				},
+			},
+			"logical_name": schema.StringAttribute{
+				MarkdownDescription: "Logical name of the table, the lowercase schema name.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"entity_set_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the entity set used to address the records of the table in the Web API.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"language_code": schema.Int64Attribute{
+				MarkdownDescription: "Language code of the display names and descriptions. Defaults to `1033` (English).",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(defaultLanguageCode),
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the table.",
+				Required:            true,
+			},
+			"display_collection_name": schema.StringAttribute{
+				MarkdownDescription: "Plural display name of the table.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the table.",
+				Optional:            true,
+			},
+			"ownership_type": schema.StringAttribute{
+				MarkdownDescription: "Ownership of the records of the table. Valid values are `UserOwned` and `OrganizationOwned`. Defaults to `UserOwned`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UserOwned"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("UserOwned", "OrganizationOwned"),
+				},
+			},
+			"has_activities": schema.BoolAttribute{
+				MarkdownDescription: "Whether activities can be associated with the records of the table. Activities can't be disabled once they have been enabled.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"has_notes": schema.BoolAttribute{
+				MarkdownDescription: "Whether notes and attachments can be associated with the records of the table.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_audit_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether changes to the records of the table are audited.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"change_tracking_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether change tracking is enabled for the table, which is required to synchronize its data with external systems.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"primary_name_column": schema.SingleNestedAttribute{
+				MarkdownDescription: "Primary name column of the table. It is created together with the table.",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"schema_name": schema.StringAttribute{
+						MarkdownDescription: "Schema name of the column, starting with the customization prefix of the publisher, for example `cr123_Name`.",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(schemaNameRegex, "schema_name must start with a customization prefix followed by '_'"),
+						},
+					},
+					"logical_name": schema.StringAttribute{
+						MarkdownDescription: "Logical name of the column.",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"display_name": schema.StringAttribute{
+						MarkdownDescription: "Display name of the column.",
+						Required:            true,
+					},
+					"description": schema.StringAttribute{
+						MarkdownDescription: "Description of the column.",
+						Optional:            true,
+					},
+					"max_length": schema.Int64Attribute{
+						MarkdownDescription: "Maximum length of the column values. Defaults to `100`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             int64default.StaticInt64(100),
+						Validators: []validator.Int64{
+							int64validator.Between(1, 4000),
+						},
+					},
+					"required_level": schema.StringAttribute{
+						MarkdownDescription: "Requirement level of the column. Valid values are `None`, `Recommended` and `ApplicationRequired`. Defaults to `None`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("None"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("None", "Recommended", "ApplicationRequired"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerClient, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.MetadataClient = newDataverseMetadataClient(providerClient.Api)
+}
+
+func (r *TableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan TableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, primaryNameColumn := tableDtoFromModel(&plan)
+	createdTable, createdPrimaryNameColumn, err := r.MetadataClient.CreateTable(ctx, plan.EnvironmentId.ValueString(), plan.SolutionUniqueName.ValueString(), table, primaryNameColumn)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setTableModelFromDto(&plan, createdTable, createdPrimaryNameColumn)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state TableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, primaryNameColumn, err := r.MetadataClient.GetTable(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), state.LogicalName.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setTableModelFromDto(&state, table, primaryNameColumn)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *TableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan TableResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state TableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	table, primaryNameColumn := tableDtoFromModel(&plan)
+	updatedTable, updatedPrimaryNameColumn, err := r.MetadataClient.UpdateTable(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), plan.SolutionUniqueName.ValueString(), plan.LanguageCode.ValueInt64(), table, primaryNameColumn)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setTableModelFromDto(&plan, updatedTable, updatedPrimaryNameColumn)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *TableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state TableResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.MetadataClient.DeleteTable(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+	}
+}
+
+func (r *TableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, table, found := strings.Cut(req.ID, "/")
+	if !found || !guidRegex.MatchString(environmentId) || table == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id/table_logical_name' or 'environment_id/table_id', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("language_code"), int64(defaultLanguageCode))...)
+	if guidRegex.MatchString(table) {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), table)...)
+	} else {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("logical_name"), strings.ToLower(table))...)
+	}
+}
+
+func tableDtoFromModel(model *TableResourceModel) (entityMetadataDto, AttributeMetadataDto) {
+	languageCode := model.LanguageCode.ValueInt64()
+	changeTrackingEnabled := model.ChangeTrackingEnabled.ValueBool()
+
+	table := entityMetadataDto{
+		SchemaName:            model.SchemaName.ValueString(),
+		DisplayName:           newLabel(model.DisplayName.ValueString(), languageCode),
+		DisplayCollectionName: newLabel(model.DisplayCollectionName.ValueString(), languageCode),
+		Description:           newLabel(model.Description.ValueString(), languageCode),
+		OwnershipType:         model.OwnershipType.ValueString(),
+		HasActivities:         model.HasActivities.ValueBool(),
+		HasNotes:              model.HasNotes.ValueBool(),
+		IsAuditEnabled:        &booleanManagedPropertyDto{Value: model.IsAuditEnabled.ValueBool()},
+		ChangeTrackingEnabled: &changeTrackingEnabled,
+	}
+
+	primaryNameColumn := AttributeMetadataDto{}
+	if model.PrimaryNameColumn != nil {
+		primaryNameColumn = AttributeMetadataDto{
+			SchemaName:    model.PrimaryNameColumn.SchemaName.ValueString(),
+			DisplayName:   newLabel(model.PrimaryNameColumn.DisplayName.ValueString(), languageCode),
+			Description:   newLabel(model.PrimaryNameColumn.Description.ValueString(), languageCode),
+			MaxLength:     model.PrimaryNameColumn.MaxLength.ValueInt64Pointer(),
+			RequiredLevel: &requiredLevelDto{Value: model.PrimaryNameColumn.RequiredLevel.ValueString()},
+		}
+	}
+	return table, primaryNameColumn
+}
+
+func setTableModelFromDto(model *TableResourceModel, table *entityMetadataDto, primaryNameColumn *AttributeMetadataDto) {
+	if model.LanguageCode.IsNull() || model.LanguageCode.IsUnknown() {
+		model.LanguageCode = types.Int64Value(defaultLanguageCode)
+	}
+	languageCode := model.LanguageCode.ValueInt64()
+
+	model.Id = types.StringValue(table.MetadataId)
+	model.SchemaName = types.StringValue(table.SchemaName)
+	model.LogicalName = types.StringValue(table.LogicalName)
+	model.EntitySetName = types.StringValue(table.EntitySetName)
+	model.DisplayName = types.StringValue(labelText(table.DisplayName, languageCode))
+	model.DisplayCollectionName = types.StringValue(labelText(table.DisplayCollectionName, languageCode))
+	model.Description = nullableStringValue(labelText(table.Description, languageCode))
+	model.OwnershipType = types.StringValue(table.OwnershipType)
+	model.HasActivities = types.BoolValue(table.HasActivities)
+	model.HasNotes = types.BoolValue(table.HasNotes)
+	model.IsAuditEnabled = types.BoolValue(table.IsAuditEnabled != nil && table.IsAuditEnabled.Value)
+	model.ChangeTrackingEnabled = types.BoolValue(table.ChangeTrackingEnabled != nil && *table.ChangeTrackingEnabled)
+
+	requiredLevel := "None"
+	if primaryNameColumn.RequiredLevel != nil {
+		requiredLevel = primaryNameColumn.RequiredLevel.Value
+	}
+	model.PrimaryNameColumn = &PrimaryNameColumnModel{
+		SchemaName:    types.StringValue(primaryNameColumn.SchemaName),
+		LogicalName:   types.StringValue(primaryNameColumn.LogicalName),
+		DisplayName:   types.StringValue(labelText(primaryNameColumn.DisplayName, languageCode)),
+		Description:   nullableStringValue(labelText(primaryNameColumn.Description, languageCode)),
+		MaxLength:     types.Int64PointerValue(primaryNameColumn.MaxLength),
+		RequiredLevel: types.StringValue(requiredLevel),
+	}
+}
+
+func nullableStringValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
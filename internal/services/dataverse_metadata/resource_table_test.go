@@ -0,0 +1,242 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dataverse_metadata_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/dataverse_metadata"
+)
+
+const (
+	testEnvironmentId   = "00000000-0000-0000-0000-000000000001"
+	testEnvironmentHost = "00000000-0000-0000-0000-000000000001.crm4.dynamics.com"
+	testTableId         = "22222222-2222-2222-2222-222222222222"
+)
+
+func TestUnitTableResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+	dataverse_metadata.RegisterMetadataEnvironmentMocks()
+
+	tableResponse, columnResponse := "get_table_created.json", "get_primary_name_column_created.json"
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions", testEnvironmentHost),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"SchemaName":"cr123_Project"`) || !strings.Contains(string(body), `"IsPrimaryName":true`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing table or primary name column"}}`), nil
+			}
+
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Set("OData-EntityId", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions(%s)", testEnvironmentHost, testTableId))
+			return resp, nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(fmt.Sprintf(`^https://%s/api/data/v9\.2/EntityDefinitions%%28%s%%29\?%%24select=`, regexp.QuoteMeta(testEnvironmentHost), testTableId)),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+tableResponse).String()), nil
+		})
+
+	httpmock.RegisterRegexpResponder("GET", regexp.MustCompile(fmt.Sprintf(`^https://%s/api/data/v9\.2/EntityDefinitions%%28%s%%29/Attributes%%28LogicalName=%%27cr123_name%%27%%29/Microsoft\.Dynamics\.CRM\.StringAttributeMetadata\?%%24select=`, regexp.QuoteMeta(testEnvironmentHost), testTableId)),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+columnResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28%s%%29", testEnvironmentHost, testTableId),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+tableResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28%s%%29/Attributes%%28LogicalName=%%27cr123_name%%27%%29/Microsoft.Dynamics.CRM.StringAttributeMetadata", testEnvironmentHost, testTableId),
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Table_Validate_CRUD/"+columnResponse).String()), nil
+		})
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28%s%%29", testEnvironmentHost, testTableId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"Label":"Customer Projects"`) || !strings.Contains(string(body), `"ChangeTrackingEnabled":true`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing updated table"}}`), nil
+			}
+			tableResponse = "get_table_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("PUT", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28%s%%29/Attributes%%28LogicalName=%%27cr123_name%%27%%29", testEnvironmentHost, testTableId),
+		func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), `"MaxLength":200`) {
+				return httpmock.NewStringResponse(http.StatusBadRequest, `{"error":{"message":"missing updated primary name column"}}`), nil
+			}
+			columnResponse = "get_primary_name_column_updated.json"
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("POST", fmt.Sprintf("https://%s/api/data/v9.2/PublishXml", testEnvironmentHost),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	httpmock.RegisterResponder("DELETE", fmt.Sprintf("https://%s/api/data/v9.2/EntityDefinitions%%28%s%%29", testEnvironmentHost, testTableId),
+		httpmock.NewStringResponder(http.StatusNoContent, ""))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_dataverse_table" "project" {
+					environment_id          = "` + testEnvironmentId + `"
+					schema_name             = "cr123_Project"
+					display_name            = "Project"
+					display_collection_name = "Projects"
+					has_notes               = true
+
+					primary_name_column = {
+						schema_name    = "cr123_Name"
+						display_name   = "Name"
+						required_level = "ApplicationRequired"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "id", testTableId),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "logical_name", "cr123_project"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "entity_set_name", "cr123_projects"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "ownership_type", "UserOwned"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "is_audit_enabled", "false"),
+					resource.TestCheckNoResourceAttr("powerplatform_dataverse_table.project", "description"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "primary_name_column.logical_name", "cr123_name"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "primary_name_column.max_length", "100"),
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_dataverse_table" "project" {
+					environment_id          = "` + testEnvironmentId + `"
+					schema_name             = "cr123_Project"
+					display_name            = "Project"
+					display_collection_name = "Customer Projects"
+					description             = "Projects run for customers"
+					has_notes               = true
+					is_audit_enabled        = true
+					change_tracking_enabled = true
+
+					primary_name_column = {
+						schema_name    = "cr123_Name"
+						display_name   = "Project Name"
+						max_length     = 200
+						required_level = "ApplicationRequired"
+					}
+				}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "display_collection_name", "Customer Projects"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "description", "Projects run for customers"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "is_audit_enabled", "true"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "change_tracking_enabled", "true"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "primary_name_column.display_name", "Project Name"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "primary_name_column.max_length", "200"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_dataverse_table.project",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testEnvironmentId + "/cr123_project",
+			},
+		},
+	})
+}
+
+func TestAccTableResource_Validate_Create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"time": {
+				Source: "hashicorp/time",
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: tableAcceptanceResourceConfig(mocks.TestName(), "Projects", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_dataverse_table.project", "id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "logical_name", "tfp_project"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "display_collection_name", "Projects"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "primary_name_column.logical_name", "tfp_name"),
+				),
+			},
+			{
+				Config: tableAcceptanceResourceConfig(mocks.TestName(), "Customer Projects", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "display_collection_name", "Customer Projects"),
+					resource.TestCheckResourceAttr("powerplatform_dataverse_table.project", "is_audit_enabled", "true"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_dataverse_table.project",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"solution_unique_name"},
+				ImportStateIdFunc: func(state *terraform.State) (string, error) {
+					environmentState := state.RootModule().Resources["powerplatform_environment.environment"]
+					tableState := state.RootModule().Resources["powerplatform_dataverse_table.project"]
+					return environmentState.Primary.ID + "/" + tableState.Primary.ID, nil
+				},
+			},
+		},
+	})
+}
+
+func tableAcceptanceResourceConfig(environmentDisplayName, displayCollectionName string, auditEnabled bool) string {
+	return fmt.Sprintf(`
+resource "powerplatform_environment" "environment" {
+  display_name     = "%s"
+  location         = "unitedstates"
+  environment_type = "Sandbox"
+  dataverse = {
+    language_code     = "1033"
+    currency_code     = "USD"
+    security_group_id = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+resource "time_sleep" "wait_120_seconds" {
+  depends_on      = [powerplatform_environment.environment]
+  create_duration = "120s"
+}
+
+resource "powerplatform_publisher" "publisher" {
+  depends_on           = [time_sleep.wait_120_seconds]
+  environment_id       = powerplatform_environment.environment.id
+  uniquename           = "terraformpublisher"
+  friendly_name        = "Terraform Publisher"
+  customization_prefix = "tfp"
+}
+
+resource "powerplatform_dataverse_table" "project" {
+  environment_id          = powerplatform_publisher.publisher.environment_id
+  schema_name             = "${powerplatform_publisher.publisher.customization_prefix}_Project"
+  display_name            = "Project"
+  display_collection_name = "%s"
+  is_audit_enabled        = %t
+
+  primary_name_column = {
+    schema_name  = "${powerplatform_publisher.publisher.customization_prefix}_Name"
+    display_name = "Name"
+  }
+}
+`, environmentDisplayName, displayCollectionName, auditEnabled)
+}
@@ -32,14 +32,13 @@ func (client *client) GetPolicies(ctx context.Context) ([]dlpPolicyModelDto, err
 		Host:   client.Api.GetConfig().Urls.BapiUrl,
 		Path:   "providers/PowerPlatform.Governance/v2/policies",
 	}
-	policiesArray := dlpPolicyDefinitionArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &policiesArray)
+	policyDefinitions, _, err := api.ExecuteForAllPages[dlpPolicyDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	policies := make([]dlpPolicyModelDto, 0)
-	for _, policy := range policiesArray.Value {
+	for _, policy := range policyDefinitions {
 		apiUrl := &url.URL{
 			Scheme: constants.HTTPS,
 			Host:   client.Api.GetConfig().Urls.BapiUrl,
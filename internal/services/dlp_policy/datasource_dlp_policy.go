@@ -47,64 +47,7 @@ func (d *DataLossPreventionPolicyDataSource) Schema(ctx context.Context, req dat
 	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
 	defer exitContext()
 
-	connectorSchema := schema.NestedAttributeObject{
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				MarkdownDescription: "ID of the connector",
-				Optional:            true,
-			},
-			"default_action_rule_behavior": schema.StringAttribute{
-				MarkdownDescription: "Default action rule behavior for the connector (\"Allow\", \"Block\")",
-				Optional:            true,
-				Validators: []validator.String{
-					stringvalidator.OneOf("Allow", "Block", ""),
-				},
-			},
-			"action_rules": schema.ListNestedAttribute{
-				MarkdownDescription: "Action rules for the connector",
-				Optional:            true,
-
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"action_id": schema.StringAttribute{
-							MarkdownDescription: "ID of the action rule",
-							Required:            true,
-						},
-						"behavior": schema.StringAttribute{
-							MarkdownDescription: "Behavior of the action rule (\"Allow\", \"Block\")",
-							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.OneOf("Allow", "Block"),
-							},
-						},
-					},
-				},
-			},
-			"endpoint_rules": schema.ListNestedAttribute{
-				MarkdownDescription: "Endpoint rules for the connector",
-				Optional:            true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"order": schema.Int64Attribute{
-							MarkdownDescription: "Order of the endpoint rule",
-							Required:            true,
-						},
-						"behavior": schema.StringAttribute{
-							MarkdownDescription: "Behavior of the endpoint rule (\"Allow\", \"Deny\")",
-							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.OneOf("Allow", "Deny"),
-							},
-						},
-						"endpoint": schema.StringAttribute{
-							MarkdownDescription: "Endpoint of the endpoint rule",
-							Required:            true,
-						},
-					},
-				},
-			},
-		},
-	}
+	connectorSchema := connectorDataSourceSchema()
 
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Fetches the list of Data Loss Prevention Policies in a Power Platform tenant. See [Manage data loss prevention policies](https://learn.microsoft.com/power-platform/admin/prevent-data-loss) for more information.",
@@ -208,6 +151,68 @@ func (d *DataLossPreventionPolicyDataSource) Schema(ctx context.Context, req dat
 	}
 }
 
+// connectorDataSourceSchema returns the schema of a connector in the connector groups of a policy.
+func connectorDataSourceSchema() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the connector",
+				Optional:            true,
+			},
+			"default_action_rule_behavior": schema.StringAttribute{
+				MarkdownDescription: "Default action rule behavior for the connector (\"Allow\", \"Block\")",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("Allow", "Block", ""),
+				},
+			},
+			"action_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Action rules for the connector",
+				Optional:            true,
+
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action_id": schema.StringAttribute{
+							MarkdownDescription: "ID of the action rule",
+							Required:            true,
+						},
+						"behavior": schema.StringAttribute{
+							MarkdownDescription: "Behavior of the action rule (\"Allow\", \"Block\")",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Block"),
+							},
+						},
+					},
+				},
+			},
+			"endpoint_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Endpoint rules for the connector",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"order": schema.Int64Attribute{
+							MarkdownDescription: "Order of the endpoint rule",
+							Required:            true,
+						},
+						"behavior": schema.StringAttribute{
+							MarkdownDescription: "Behavior of the endpoint rule (\"Allow\", \"Deny\")",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("Allow", "Deny"),
+							},
+						},
+						"endpoint": schema.StringAttribute{
+							MarkdownDescription: "Endpoint of the endpoint rule",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (d *DataLossPreventionPolicyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
 	defer exitContext()
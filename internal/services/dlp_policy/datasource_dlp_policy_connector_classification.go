@@ -0,0 +1,292 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dlp_policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connectors"
+)
+
+var (
+	_ datasource.DataSource              = &DataLossPreventionPolicyConnectorClassificationDataSource{}
+	_ datasource.DataSourceWithConfigure = &DataLossPreventionPolicyConnectorClassificationDataSource{}
+)
+
+func NewDataLossPreventionPolicyConnectorClassificationDataSource() datasource.DataSource {
+	return &DataLossPreventionPolicyConnectorClassificationDataSource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "data_loss_prevention_policy_connector_classification",
+		},
+	}
+}
+
+func (d *DataLossPreventionPolicyConnectorClassificationDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	d.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = d.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (d *DataLossPreventionPolicyConnectorClassificationDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	connectorSchema := connectorDataSourceSchema()
+
+	dataGroupValidators := []validator.String{
+		stringvalidator.OneOf(dataGroupBusiness, dataGroupNonBusiness, dataGroupBlocked),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Compares the connectors available in a Power Platform tenant with the connector groups of a Data Loss Prevention Policy, and reports the connectors that the policy doesn't classify. " +
+			"Unclassified connectors fall into the default classification of the policy, which also applies to connectors newly released by Microsoft. " +
+			"Classification rules can put unclassified connectors into a group based on their tier and publisher. See [Manage data loss prevention policies](https://learn.microsoft.com/power-platform/admin/prevent-data-loss) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: false,
+				Update: false,
+				Delete: false,
+				Read:   false,
+			}),
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the environment whose connectors are compared. If not specified, the tenant-level connectors of the 'Default' environment are compared.",
+				Optional:            true,
+			},
+			"default_connectors_classification": schema.StringAttribute{
+				MarkdownDescription: "Default classification of the policy for connectors that are not classified explicitly (\"General\", \"Confidential\", \"Blocked\")",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("General", "Confidential", "Blocked"),
+				},
+			},
+			"business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Connectors for sensitive data in the policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"non_business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Connectors for non-sensitive data in the policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"blocked_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Blocked connectors in the policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"released_after": schema.StringAttribute{
+				MarkdownDescription: "Unclassified connectors created after this time (RFC3339, e.g. `2025-01-01T00:00:00Z`) are reported as newly released",
+				Optional:            true,
+			},
+			"classification_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Rules that put unclassified connectors into a group. The first rule that matches both the tier and the publisher of a connector applies. Rules that would block an unblockable connector are skipped.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tier": schema.StringAttribute{
+							MarkdownDescription: "Tier of the connectors the rule matches, e.g. \"Standard\" or \"Premium\". If not specified, the rule matches every tier.",
+							Optional:            true,
+						},
+						"publisher": schema.StringAttribute{
+							MarkdownDescription: "Publisher of the connectors the rule matches, e.g. \"Microsoft\". If not specified, the rule matches every publisher.",
+							Optional:            true,
+						},
+						"data_group": schema.StringAttribute{
+							MarkdownDescription: "Group the matching connectors are put into (\"Business\", \"NonBusiness\", \"Blocked\")",
+							Required:            true,
+							Validators:          dataGroupValidators,
+						},
+					},
+				},
+			},
+			"unclassified_connectors": schema.ListNestedAttribute{
+				MarkdownDescription: "Connectors that are in none of the connector groups of the policy",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Id of the connector",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the connector",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the connector",
+							Computed:            true,
+						},
+						"tier": schema.StringAttribute{
+							MarkdownDescription: "Tier of the connector",
+							Computed:            true,
+						},
+						"publisher": schema.StringAttribute{
+							MarkdownDescription: "Publisher of the connector",
+							Computed:            true,
+						},
+						"created_time": schema.StringAttribute{
+							MarkdownDescription: "Time when the connector was created",
+							Computed:            true,
+						},
+						"unblockable": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if the connector can't be blocked",
+							Computed:            true,
+						},
+						"newly_released": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if the connector was created after `released_after`",
+							Computed:            true,
+						},
+						"data_group": schema.StringAttribute{
+							MarkdownDescription: "Group the connector falls into (\"Business\", \"NonBusiness\", \"Blocked\")",
+							Computed:            true,
+						},
+						"matched_rule": schema.BoolAttribute{
+							MarkdownDescription: "Indicates if a classification rule put the connector into its group. Otherwise it falls into the default classification of the policy.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"effective_business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "`business_connectors` together with the unclassified connectors that classification rules put into the Business group",
+				Computed:            true,
+				NestedObject:        connectorSchema,
+			},
+			"effective_non_business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "`non_business_connectors` together with the unclassified connectors that classification rules put into the NonBusiness group",
+				Computed:            true,
+				NestedObject:        connectorSchema,
+			},
+			"effective_blocked_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "`blocked_connectors` together with the unclassified connectors that classification rules put into the Blocked group",
+				Computed:            true,
+				NestedObject:        connectorSchema,
+			},
+		},
+	}
+}
+
+func (d *DataLossPreventionPolicyConnectorClassificationDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.ConnectorsClient = connectors.NewConnectorsClient(client.Api)
+}
+
+func (d *DataLossPreventionPolicyConnectorClassificationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	var state connectorClassificationDataSourceModel
+
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var releasedAfter time.Time
+	if !state.ReleasedAfter.IsNull() {
+		var err error
+		releasedAfter, err = time.Parse(time.RFC3339, state.ReleasedAfter.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("released_after"), "Invalid released_after", fmt.Sprintf("'%s' is not a valid RFC3339 time: %s", state.ReleasedAfter.ValueString(), err.Error()))
+			return
+		}
+	}
+
+	dataGroups, err := newConnectorDataGroups(ctx, state.DefaultConnectorsClassification.ValueString(), state.BusinessGeneralConnectors, state.NonBusinessConfidentialConnectors, state.BlockedConnectors)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid policy", err.Error())
+		return
+	}
+
+	connectorDtos, err := d.ConnectorsClient.GetConnectors(ctx, state.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", d.FullTypeName()), err.Error())
+		return
+	}
+	sort.Slice(connectorDtos, func(i, j int) bool {
+		return connectorDtos[i].Id < connectorDtos[j].Id
+	})
+
+	effectiveConnectors := map[string][]attr.Value{
+		dataGroupBusiness:    state.BusinessGeneralConnectors.Elements(),
+		dataGroupNonBusiness: state.NonBusinessConfidentialConnectors.Elements(),
+		dataGroupBlocked:     state.BlockedConnectors.Elements(),
+	}
+
+	state.UnclassifiedConnectors = []unclassifiedConnectorDataSourceModel{}
+	for _, connector := range connectorDtos {
+		if dataGroups.isClassified(connector.Id) {
+			continue
+		}
+
+		dataGroup, matchedRule := classifyConnector(connector, state.ClassificationRules, dataGroups.defaultDataGroup)
+		if matchedRule {
+			effectiveConnectors[dataGroup] = append(effectiveConnectors[dataGroup], convertToAttrValueUnconfiguredConnector(connector.Id))
+		}
+
+		newlyReleased := false
+		if !state.ReleasedAfter.IsNull() && connector.Properties.CreatedTime != "" {
+			createdTime, err := time.Parse(time.RFC3339, connector.Properties.CreatedTime)
+			newlyReleased = err == nil && createdTime.After(releasedAfter)
+		}
+
+		state.UnclassifiedConnectors = append(state.UnclassifiedConnectors, unclassifiedConnectorDataSourceModel{
+			Id:            types.StringValue(connector.Id),
+			Name:          types.StringValue(connector.Name),
+			DisplayName:   types.StringValue(connector.Properties.DisplayName),
+			Tier:          types.StringValue(connector.Properties.Tier),
+			Publisher:     types.StringValue(connector.Properties.Publisher),
+			CreatedTime:   types.StringValue(connector.Properties.CreatedTime),
+			Unblockable:   types.BoolValue(connector.Properties.Unblockable),
+			NewlyReleased: types.BoolValue(newlyReleased),
+			DataGroup:     types.StringValue(dataGroup),
+			MatchedRule:   types.BoolValue(matchedRule),
+		})
+	}
+
+	state.EffectiveBusinessConnectors = types.SetValueMust(connectorSetObjectType, effectiveConnectors[dataGroupBusiness])
+	state.EffectiveNonBusinessConnectors = types.SetValueMust(connectorSetObjectType, effectiveConnectors[dataGroupNonBusiness])
+	state.EffectiveBlockedConnectors = types.SetValueMust(connectorSetObjectType, effectiveConnectors[dataGroupBlocked])
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
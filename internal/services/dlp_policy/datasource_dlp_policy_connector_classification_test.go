@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dlp_policy_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestAccDlpPolicyConnectorClassificationDataSource_Validate_Read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_data_loss_prevention_policy_connector_classification" "classification" {
+					default_connectors_classification = "Blocked"
+
+					business_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+					]
+
+					classification_rules = [
+						{
+							tier       = "Premium"
+							publisher  = "Microsoft"
+							data_group = "Business"
+						},
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.#", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_business_connectors.*", map[string]string{
+						"id": "/providers/Microsoft.PowerApps/apis/shared_sql",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDlpPolicyConnectorClassificationDataSource_Validate_Read(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/PowerPlatform.Governance/v1/connectors/metadata/virtual`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Classification_Validate_Read/get_virtual.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/PowerPlatform.Governance/v1/connectors/metadata/unblockable`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Classification_Validate_Read/get_unblockable.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://api.powerapps.com/providers/Microsoft.PowerApps/apis?%24filter=environment+eq+%27~Default%27&api-version=2019-05-01&hideDlpExemptApis=true&showAllDlpEnforceableApis=true&showApisWithToS=true`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Classification_Validate_Read/get_apis.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_data_loss_prevention_policy_connector_classification" "classification" {
+					default_connectors_classification = "General"
+					released_after                    = "2025-01-01T00:00:00Z"
+
+					business_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+					]
+
+					classification_rules = [
+						{
+							tier       = "Premium"
+							publisher  = "Microsoft"
+							data_group = "Business"
+						},
+						{
+							tier       = "Premium"
+							data_group = "Blocked"
+						},
+						{
+							tier       = "Standard"
+							data_group = "Blocked"
+						},
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.#", "5"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.id", "/providers/Microsoft.PowerApps/apis/shared_approvals"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.name", "shared_approvals"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.display_name", "Approvals"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.tier", "Standard"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.publisher", "Microsoft"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.created_time", "2025-06-01T10:00:00.0000000Z"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.unblockable", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.newly_released", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.data_group", "NonBusiness"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.0.matched_rule", "false"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.1.id", "/providers/Microsoft.PowerApps/apis/shared_newservice"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.1.newly_released", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.1.data_group", "Blocked"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.1.matched_rule", "true"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.2.id", "/providers/Microsoft.PowerApps/apis/shared_salesforce"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.2.newly_released", "false"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.2.data_group", "Blocked"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.3.id", "/providers/Microsoft.PowerApps/apis/shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.3.data_group", "Business"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.4.id", "Http"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.4.data_group", "NonBusiness"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "unclassified_connectors.4.matched_rule", "false"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_business_connectors.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_business_connectors.*", map[string]string{
+						"id": "/providers/Microsoft.PowerApps/apis/shared_sharepointonline",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_business_connectors.*", map[string]string{
+						"id":                           "/providers/Microsoft.PowerApps/apis/shared_sql",
+						"default_action_rule_behavior": "",
+						"action_rules.#":               "0",
+						"endpoint_rules.#":             "0",
+					}),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_non_business_connectors.#", "0"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_blocked_connectors.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_blocked_connectors.*", map[string]string{
+						"id": "/providers/Microsoft.PowerApps/apis/shared_salesforce",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.powerplatform_data_loss_prevention_policy_connector_classification.classification", "effective_blocked_connectors.*", map[string]string{
+						"id": "/providers/Microsoft.PowerApps/apis/shared_newservice",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDlpPolicyConnectorClassificationDataSource_Validate_Released_After(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_data_loss_prevention_policy_connector_classification" "classification" {
+					default_connectors_classification = "General"
+					released_after                    = "last week"
+				}`,
+
+				ExpectError: regexp.MustCompile("Invalid released_after"),
+			},
+		},
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dlp_policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/powerapps"
+)
+
+var (
+	_ datasource.DataSource              = &DataLossPreventionPolicyImpactDataSource{}
+	_ datasource.DataSourceWithConfigure = &DataLossPreventionPolicyImpactDataSource{}
+)
+
+func NewDataLossPreventionPolicyImpactDataSource() datasource.DataSource {
+	return &DataLossPreventionPolicyImpactDataSource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "data_loss_prevention_policy_impact",
+		},
+	}
+}
+
+func (d *DataLossPreventionPolicyImpactDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	d.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = d.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (d *DataLossPreventionPolicyImpactDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	connectorSchema := connectorDataSourceSchema()
+
+	connectorsAttribute := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Computed:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Simulates a proposed Data Loss Prevention Policy against the apps and connections of a set of environments, and reports the ones the policy would break. " +
+			"An app breaks when it uses a blocked connector, or business and non-business connectors together. A connection breaks when its connector is blocked. " +
+			"Custom connector URL patterns, action rules and endpoint rules are not evaluated. See [Manage data loss prevention policies](https://learn.microsoft.com/power-platform/admin/prevent-data-loss) for more information.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: false,
+				Update: false,
+				Delete: false,
+				Read:   false,
+			}),
+			"default_connectors_classification": schema.StringAttribute{
+				MarkdownDescription: "Default classification of the proposed policy for connectors that are not classified explicitly (\"General\", \"Confidential\", \"Blocked\")",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("General", "Confidential", "Blocked"),
+				},
+			},
+			"environments": schema.SetAttribute{
+				MarkdownDescription: "Environments whose apps and connections are analyzed",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Connectors for sensitive data in the proposed policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"non_business_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Connectors for non-sensitive data in the proposed policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"blocked_connectors": schema.SetNestedAttribute{
+				MarkdownDescription: "Blocked connectors in the proposed policy",
+				Optional:            true,
+				NestedObject:        connectorSchema,
+			},
+			"apps": schema.ListNestedAttribute{
+				MarkdownDescription: "Apps that would break under the proposed policy",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"environment_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the environment of the app",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Unique name of the app",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the app",
+							Computed:            true,
+						},
+						"business_connectors":     connectorsAttribute("Connectors used by the app that are classified as business"),
+						"non_business_connectors": connectorsAttribute("Connectors used by the app that are classified as non-business"),
+						"blocked_connectors":      connectorsAttribute("Connectors used by the app that are blocked"),
+						"violations": schema.SetAttribute{
+							MarkdownDescription: "Reasons why the app would break (\"BlockedConnectors\", \"MixedDataGroups\")",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"connections": schema.ListNestedAttribute{
+				MarkdownDescription: "Connections whose connector would be blocked under the proposed policy",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"environment_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the environment of the connection",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Unique name of the connection",
+							Computed:            true,
+						},
+						"display_name": schema.StringAttribute{
+							MarkdownDescription: "Display name of the connection",
+							Computed:            true,
+						},
+						"connector_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the connector of the connection",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataLossPreventionPolicyImpactDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.ConnectionsClient = connection.NewConnectionsClient(client.Api)
+	d.PowerAppsClient = powerapps.NewPowerAppsClient(client.Api)
+}
+
+func (d *DataLossPreventionPolicyImpactDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	var state policyImpactDataSourceModel
+
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dataGroups, err := newConnectorDataGroups(ctx, state.DefaultConnectorsClassification.ValueString(), state.BusinessGeneralConnectors, state.NonBusinessConfidentialConnectors, state.BlockedConnectors)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid proposed policy", err.Error())
+		return
+	}
+
+	environments := []string{}
+	resp.Diagnostics.Append(state.Environments.ElementsAs(ctx, &environments, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(environments)
+
+	state.Apps = []policyImpactAppDataSourceModel{}
+	state.Connections = []policyImpactConnectionDataSourceModel{}
+	for _, environmentId := range environments {
+		apps, err := d.PowerAppsClient.GetEnvironmentPowerApps(ctx, environmentId)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading apps of environment '%s' in %s", environmentId, d.FullTypeName()), err.Error())
+			return
+		}
+		for _, app := range apps {
+			connectorIds := []string{}
+			for _, connectionReference := range app.Properties.ConnectionReferences {
+				connectorIds = append(connectorIds, connectionReference.Id)
+			}
+			grouped, violations := dataGroups.groupConnectors(connectorIds)
+			if len(violations) == 0 {
+				continue
+			}
+			state.Apps = append(state.Apps, policyImpactAppDataSourceModel{
+				EnvironmentId:         types.StringValue(environmentId),
+				Name:                  types.StringValue(app.Name),
+				DisplayName:           types.StringValue(app.Properties.DisplayName),
+				BusinessConnectors:    convertToAttrValueStrings(grouped[dataGroupBusiness]),
+				NonBusinessConnectors: convertToAttrValueStrings(grouped[dataGroupNonBusiness]),
+				BlockedConnectors:     convertToAttrValueStrings(grouped[dataGroupBlocked]),
+				Violations:            convertToAttrValueStrings(violations),
+			})
+		}
+
+		connections, err := d.ConnectionsClient.GetConnections(ctx, environmentId)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading connections of environment '%s' in %s", environmentId, d.FullTypeName()), err.Error())
+			return
+		}
+		for _, conn := range connections {
+			if dataGroups.dataGroup(conn.Properties.ApiId) != dataGroupBlocked {
+				continue
+			}
+			state.Connections = append(state.Connections, policyImpactConnectionDataSourceModel{
+				EnvironmentId: types.StringValue(environmentId),
+				Name:          types.StringValue(conn.Name),
+				DisplayName:   types.StringValue(conn.Properties.DisplayName),
+				ConnectorId:   types.StringValue(policyConnectorId(connectorName(conn.Properties.ApiId))),
+			})
+		}
+	}
+
+	sort.SliceStable(state.Apps, func(i, j int) bool {
+		return state.Apps[i].EnvironmentId.ValueString() < state.Apps[j].EnvironmentId.ValueString() ||
+			state.Apps[i].EnvironmentId.ValueString() == state.Apps[j].EnvironmentId.ValueString() && state.Apps[i].Name.ValueString() < state.Apps[j].Name.ValueString()
+	})
+	sort.SliceStable(state.Connections, func(i, j int) bool {
+		return state.Connections[i].EnvironmentId.ValueString() < state.Connections[j].EnvironmentId.ValueString() ||
+			state.Connections[i].EnvironmentId.ValueString() == state.Connections[j].EnvironmentId.ValueString() && state.Connections[i].Name.ValueString() < state.Connections[j].Name.ValueString()
+	})
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
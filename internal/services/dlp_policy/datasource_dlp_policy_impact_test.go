@@ -0,0 +1,145 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package dlp_policy_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestAccDlpPolicyImpactDataSource_Validate_Read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "env" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+				}
+
+				data "powerplatform_data_loss_prevention_policy_impact" "impact" {
+					environments                      = [powerplatform_environment.env.id]
+					default_connectors_classification = "Blocked"
+
+					business_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.#", "0"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDlpPolicyImpactDataSource_Validate_Read(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", `https://api.powerapps.com/providers/Microsoft.PowerApps/scopes/admin/environments/00000000-0000-0000-0000-000000000001/apps?api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Impact_Validate_Read/get_apps_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://000000000000000000000000000000.01.environment.api.powerplatform.com/connectivity/connections?api-version=1`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Impact_Validate_Read/get_connections_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_data_loss_prevention_policy_impact" "impact" {
+					environments                      = ["00000000-0000-0000-0000-000000000001"]
+					default_connectors_classification = "General"
+
+					business_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_office365users"
+						},
+					]
+
+					blocked_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_dropbox"
+						},
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.#", "2"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.environment_id", "00000000-0000-0000-0000-000000000001"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.name", "00000000-0000-0000-0000-000000000011"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.display_name", "Expense Approvals"),
+					resource.TestCheckTypeSetElemAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.business_connectors.*", "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"),
+					resource.TestCheckTypeSetElemAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.non_business_connectors.*", "/providers/Microsoft.PowerApps/apis/shared_twitter"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.blocked_connectors.#", "0"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.violations.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.0.violations.*", "MixedDataGroups"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.1.name", "00000000-0000-0000-0000-000000000013"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.1.display_name", "File Drop"),
+					resource.TestCheckTypeSetElemAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.1.blocked_connectors.*", "/providers/Microsoft.PowerApps/apis/shared_dropbox"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.1.violations.#", "1"),
+					resource.TestCheckTypeSetElemAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "apps.1.violations.*", "BlockedConnectors"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.#", "1"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.0.environment_id", "00000000-0000-0000-0000-000000000001"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.0.name", "00000000000000000000000000000201"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.0.display_name", "admin@contoso.com"),
+					resource.TestCheckResourceAttr("data.powerplatform_data_loss_prevention_policy_impact.impact", "connections.0.connector_id", "/providers/Microsoft.PowerApps/apis/shared_dropbox"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDlpPolicyImpactDataSource_Validate_Duplicate_Connector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_data_loss_prevention_policy_impact" "impact" {
+					environments                      = ["00000000-0000-0000-0000-000000000001"]
+					default_connectors_classification = "General"
+
+					business_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+					]
+
+					blocked_connectors = [
+						{
+							id = "/providers/Microsoft.PowerApps/apis/shared_sharepointonline"
+						},
+					]
+				}`,
+
+				ExpectError: regexp.MustCompile("Invalid proposed policy"),
+			},
+		},
+	})
+}
@@ -39,10 +39,6 @@ type dlpPolicyDefinitionDto struct {
 	LastModifiedTime                string                  `json:"lastModifiedTime,omitempty"`
 }
 
-type dlpPolicyDefinitionArrayDto struct {
-	Value []dlpPolicyDto `json:"value"`
-}
-
 type dlpPolicyLastActionDto struct {
 	DisplayName string `json:"displayName"`
 }
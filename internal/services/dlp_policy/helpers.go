@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connectors"
 )
 
 func covertDlpPolicyToPolicyModelDto(policy dlpPolicyDto) (*dlpPolicyModelDto, error) {
@@ -330,3 +331,140 @@ func convertToAtrValueEndpointRule(connector dlpConnectorModelDto) []attr.Value
 	}
 	return endpointRules
 }
+
+const (
+	dataGroupBusiness    = "Business"
+	dataGroupNonBusiness = "NonBusiness"
+	dataGroupBlocked     = "Blocked"
+
+	violationBlockedConnectors = "BlockedConnectors"
+	violationMixedDataGroups   = "MixedDataGroups"
+)
+
+// connectorDataGroups resolves the data group of connectors in a proposed policy. Connectors that
+// are not classified explicitly fall into the data group of the default classification.
+type connectorDataGroups struct {
+	defaultDataGroup string
+	dataGroups       map[string]string
+}
+
+func newConnectorDataGroups(ctx context.Context, defaultClassification string, business, nonBusiness, blocked basetypes.SetValue) (*connectorDataGroups, error) {
+	groups := connectorDataGroups{
+		defaultDataGroup: convertConnectorRuleClassificationValues(defaultClassification),
+		dataGroups:       map[string]string{},
+	}
+	for _, group := range []struct {
+		dataGroup      string
+		connectorsAttr basetypes.SetValue
+	}{
+		{dataGroupBusiness, business},
+		{dataGroupNonBusiness, nonBusiness},
+		{dataGroupBlocked, blocked},
+	} {
+		dataGroup := group.dataGroup
+		connectorGroup, err := getConnectorGroup(ctx, group.connectorsAttr)
+		if err != nil {
+			return nil, err
+		}
+		for _, connector := range connectorGroup.Connectors {
+			name := connectorName(connector.Id)
+			if previous, ok := groups.dataGroups[name]; ok && previous != dataGroup {
+				return nil, fmt.Errorf("connector '%s' is classified as both %s and %s", connector.Id, previous, dataGroup)
+			}
+			groups.dataGroups[name] = dataGroup
+		}
+	}
+	return &groups, nil
+}
+
+// dataGroup returns the data group of a connector: Business, NonBusiness or Blocked.
+func (groups *connectorDataGroups) dataGroup(connectorId string) string {
+	if dataGroup, ok := groups.dataGroups[connectorName(connectorId)]; ok {
+		return dataGroup
+	}
+	return groups.defaultDataGroup
+}
+
+// isClassified reports whether a connector is classified explicitly in one of the connector groups of the policy.
+func (groups *connectorDataGroups) isClassified(connectorId string) bool {
+	_, ok := groups.dataGroups[connectorName(connectorId)]
+	return ok
+}
+
+// groupConnectors sorts connectors into their data groups and returns the policy violations of using them together,
+// either because one of them is blocked or because business and non-business connectors are mixed.
+func (groups *connectorDataGroups) groupConnectors(connectorIds []string) (map[string][]string, []string) {
+	grouped := map[string][]string{}
+	seen := map[string]bool{}
+	for _, connectorId := range connectorIds {
+		name := connectorName(connectorId)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		dataGroup := groups.dataGroup(connectorId)
+		grouped[dataGroup] = append(grouped[dataGroup], policyConnectorId(name))
+	}
+	for _, connectors := range grouped {
+		sort.Strings(connectors)
+	}
+
+	violations := []string{}
+	if len(grouped[dataGroupBlocked]) > 0 {
+		violations = append(violations, violationBlockedConnectors)
+	}
+	if len(grouped[dataGroupBusiness]) > 0 && len(grouped[dataGroupNonBusiness]) > 0 {
+		violations = append(violations, violationMixedDataGroups)
+	}
+	return grouped, violations
+}
+
+// connectorName returns the lowercase name of a connector from its id,
+// e.g. shared_sharepointonline for /providers/Microsoft.PowerApps/apis/shared_sharepointonline.
+func connectorName(connectorId string) string {
+	nameSplit := strings.Split(connectorId, "/")
+	return strings.ToLower(nameSplit[len(nameSplit)-1])
+}
+
+func policyConnectorId(name string) string {
+	return "/providers/Microsoft.PowerApps/apis/" + name
+}
+
+func convertToAttrValueStrings(values []string) basetypes.SetValue {
+	elements := []attr.Value{}
+	for _, value := range values {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// classifyConnector returns the data group an unclassified connector falls into: the data group of the first
+// classification rule that matches its tier and publisher, or the default data group of the policy. Unblockable
+// connectors are never blocked, rules that would block them are skipped and the default puts them into NonBusiness.
+func classifyConnector(connector connectors.ConnectorDto, rules []connectorClassificationRuleModel, defaultDataGroup string) (string, bool) {
+	for _, rule := range rules {
+		if !rule.Tier.IsNull() && !strings.EqualFold(rule.Tier.ValueString(), connector.Properties.Tier) {
+			continue
+		}
+		if !rule.Publisher.IsNull() && !strings.EqualFold(rule.Publisher.ValueString(), connector.Properties.Publisher) {
+			continue
+		}
+		if rule.DataGroup.ValueString() == dataGroupBlocked && connector.Properties.Unblockable {
+			continue
+		}
+		return rule.DataGroup.ValueString(), true
+	}
+	if defaultDataGroup == dataGroupBlocked && connector.Properties.Unblockable {
+		return dataGroupNonBusiness, false
+	}
+	return defaultDataGroup, false
+}
+
+func convertToAttrValueUnconfiguredConnector(connectorId string) attr.Value {
+	return types.ObjectValueMust(connectorSetObjectType.AttrTypes, map[string]attr.Value{
+		"id":                           types.StringValue(connectorId),
+		"default_action_rule_behavior": types.StringValue(""),
+		"action_rules":                 types.ListValueMust(actionRuleListObjectType, []attr.Value{}),
+		"endpoint_rules":               types.ListValueMust(endpointRuleListObjectType, []attr.Value{}),
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connection"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/connectors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/services/powerapps"
 )
 
 type policiesListDataSourceModel struct {
@@ -113,3 +116,76 @@ type DataLossPreventionPolicyDataSource struct {
 	helpers.TypeInfo
 	DlpPolicyClient client
 }
+
+type DataLossPreventionPolicyImpactDataSource struct {
+	helpers.TypeInfo
+	ConnectionsClient connection.Client
+	PowerAppsClient   powerapps.Client
+}
+
+type policyImpactDataSourceModel struct {
+	Timeouts                          timeouts.Value                          `tfsdk:"timeouts"`
+	DefaultConnectorsClassification   types.String                            `tfsdk:"default_connectors_classification"`
+	Environments                      types.Set                               `tfsdk:"environments"`
+	NonBusinessConfidentialConnectors types.Set                               `tfsdk:"non_business_connectors"`
+	BusinessGeneralConnectors         types.Set                               `tfsdk:"business_connectors"`
+	BlockedConnectors                 types.Set                               `tfsdk:"blocked_connectors"`
+	Apps                              []policyImpactAppDataSourceModel        `tfsdk:"apps"`
+	Connections                       []policyImpactConnectionDataSourceModel `tfsdk:"connections"`
+}
+
+type policyImpactAppDataSourceModel struct {
+	EnvironmentId         types.String `tfsdk:"environment_id"`
+	Name                  types.String `tfsdk:"name"`
+	DisplayName           types.String `tfsdk:"display_name"`
+	BusinessConnectors    types.Set    `tfsdk:"business_connectors"`
+	NonBusinessConnectors types.Set    `tfsdk:"non_business_connectors"`
+	BlockedConnectors     types.Set    `tfsdk:"blocked_connectors"`
+	Violations            types.Set    `tfsdk:"violations"`
+}
+
+type policyImpactConnectionDataSourceModel struct {
+	EnvironmentId types.String `tfsdk:"environment_id"`
+	Name          types.String `tfsdk:"name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	ConnectorId   types.String `tfsdk:"connector_id"`
+}
+
+type DataLossPreventionPolicyConnectorClassificationDataSource struct {
+	helpers.TypeInfo
+	ConnectorsClient connectors.Client
+}
+
+type connectorClassificationDataSourceModel struct {
+	Timeouts                          timeouts.Value                         `tfsdk:"timeouts"`
+	EnvironmentId                     types.String                           `tfsdk:"environment_id"`
+	DefaultConnectorsClassification   types.String                           `tfsdk:"default_connectors_classification"`
+	NonBusinessConfidentialConnectors types.Set                              `tfsdk:"non_business_connectors"`
+	BusinessGeneralConnectors         types.Set                              `tfsdk:"business_connectors"`
+	BlockedConnectors                 types.Set                              `tfsdk:"blocked_connectors"`
+	ReleasedAfter                     types.String                           `tfsdk:"released_after"`
+	ClassificationRules               []connectorClassificationRuleModel     `tfsdk:"classification_rules"`
+	UnclassifiedConnectors            []unclassifiedConnectorDataSourceModel `tfsdk:"unclassified_connectors"`
+	EffectiveNonBusinessConnectors    types.Set                              `tfsdk:"effective_non_business_connectors"`
+	EffectiveBusinessConnectors       types.Set                              `tfsdk:"effective_business_connectors"`
+	EffectiveBlockedConnectors        types.Set                              `tfsdk:"effective_blocked_connectors"`
+}
+
+type connectorClassificationRuleModel struct {
+	Tier      types.String `tfsdk:"tier"`
+	Publisher types.String `tfsdk:"publisher"`
+	DataGroup types.String `tfsdk:"data_group"`
+}
+
+type unclassifiedConnectorDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Tier          types.String `tfsdk:"tier"`
+	Publisher     types.String `tfsdk:"publisher"`
+	CreatedTime   types.String `tfsdk:"created_time"`
+	Unblockable   types.Bool   `tfsdk:"unblockable"`
+	NewlyReleased types.Bool   `tfsdk:"newly_released"`
+	DataGroup     types.String `tfsdk:"data_group"`
+	MatchedRule   types.Bool   `tfsdk:"matched_rule"`
+}
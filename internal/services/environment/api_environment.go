@@ -809,13 +809,12 @@ func (client *Client) GetEnvironments(ctx context.Context) ([]EnvironmentDto, er
 	values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
 	apiUrl.RawQuery = values.Encode()
 
-	envArray := environmentArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &envArray)
+	environments, _, err := api.ExecuteForAllPages[EnvironmentDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	return envArray.Value, nil
+	return environments, nil
 }
 
 func (client *Client) GetDefaultCurrencyForEnvironment(ctx context.Context, environmentId string) (*TransactionCurrencyDto, error) {
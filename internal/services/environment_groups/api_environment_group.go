@@ -141,13 +141,12 @@ func (client *client) GetEnvironmentsInEnvironmentGroup(ctx context.Context, env
 	values.Add("$filter", fmt.Sprintf("properties/parentEnvironmentGroup/id eq %s", environmentGroupId))
 	apiUrl.RawQuery = values.Encode()
 
-	environments := environmentArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &environments)
+	environments, _, err := api.ExecuteForAllPages[environmentDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get environments in environment group: %w", err)
 	}
 
-	return environments.Value, nil
+	return environments, nil
 }
 
 func (client *client) RemoveEnvironmentFromEnvironmentGroup(ctx context.Context, environmentGroupId, environmentId string) error {
@@ -16,10 +16,6 @@ type environmentGroupDto struct {
 	CreatedBy   environmentGroupPrincipalDto `json:"createdBy,omitempty"`
 }
 
-type environmentArrayDto struct {
-	Value []environmentDto `json:"value"`
-}
-
 type environmentDto struct {
 	Name       string                   `json:"name"`
 	Properties environmentPropertiesDto `json:"properties"`
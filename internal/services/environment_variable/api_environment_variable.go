@@ -128,8 +128,7 @@ func (client *client) getDefinitionBySchemaName(ctx context.Context, environment
 
 	apiURL := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/environmentvariabledefinitions", constants.DATAVERSE_API_VERSION), values)
 
-	response := environmentVariableDefinitionArrayDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiURL, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+	definitions, resp, err := api.ExecuteForAllPages[environmentVariableDefinitionDto](ctx, client.Api, nil, apiURL, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -140,7 +139,7 @@ func (client *client) getDefinitionBySchemaName(ctx context.Context, environment
 		return nil, err
 	}
 
-	switch len(response.Value) {
+	switch len(definitions) {
 	case 0:
 		return nil, customerrors.WrapIntoProviderError(
 			fmt.Errorf("environment variable definition with schema name '%s' not found", schemaName),
@@ -148,7 +147,7 @@ func (client *client) getDefinitionBySchemaName(ctx context.Context, environment
 			fmt.Sprintf("environment variable definition with schema name '%s' not found", schemaName),
 		)
 	case 1:
-		return &response.Value[0], nil
+		return &definitions[0], nil
 	default:
 		return nil, fmt.Errorf("multiple environment variable definitions found for schema name '%s'", schemaName)
 	}
@@ -166,8 +165,7 @@ func (client *client) getCurrentValueByDefinitionID(ctx context.Context, environ
 
 	apiURL := helpers.BuildApiUrl(environmentHost, fmt.Sprintf("/api/data/%s/environmentvariablevalues", constants.DATAVERSE_API_VERSION), values)
 
-	response := environmentVariableValueArrayDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiURL, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &response)
+	currentValues, resp, err := api.ExecuteForAllPages[environmentVariableValueDto](ctx, client.Api, nil, apiURL, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +176,11 @@ func (client *client) getCurrentValueByDefinitionID(ctx context.Context, environ
 		return nil, err
 	}
 
-	switch len(response.Value) {
+	switch len(currentValues) {
 	case 0:
 		return nil, nil
 	case 1:
-		return &response.Value[0], nil
+		return &currentValues[0], nil
 	default:
 		return nil, fmt.Errorf("multiple current values found for environment variable definition '%s'", definitionID)
 	}
@@ -3,10 +3,6 @@
 
 package environmentvariable
 
-type environmentVariableDefinitionArrayDto struct {
-	Value []environmentVariableDefinitionDto `json:"value"`
-}
-
 type environmentVariableDefinitionDto struct {
 	EnvironmentVariableDefinitionId string `json:"environmentvariabledefinitionid"`
 	SchemaName                      string `json:"schemaname"`
@@ -18,10 +14,6 @@ type environmentVariableDefinitionDto struct {
 	SecretStore                     int64  `json:"secretstore"`
 }
 
-type environmentVariableValueArrayDto struct {
-	Value []environmentVariableValueDto `json:"value"`
-}
-
 type environmentVariableValueDto struct {
 	EnvironmentVariableValueId string `json:"environmentvariablevalueid"`
 	SchemaName                 string `json:"schemaname"`
@@ -5,8 +5,6 @@ package languages
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -35,22 +33,10 @@ func (client *client) GetLanguagesByLocation(ctx context.Context, location strin
 	values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
 	apiUrl.RawQuery = values.Encode()
 
-	languages := languagesArrayDto{}
-
-	response, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, nil)
-	if err != nil {
-		return languages, err
-	}
-
-	defer response.HttpResponse.Body.Close()
-
-	if len(response.BodyAsBytes) == 0 {
-		return languages, errors.New("empty response body")
-	}
-	err = json.Unmarshal(response.BodyAsBytes, &languages)
+	languages, _, err := api.ExecuteForAllPages[languageDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
-		return languages, err
+		return languagesArrayDto{}, err
 	}
 
-	return languages, nil
+	return languagesArrayDto{Value: languages}, nil
 }
@@ -37,10 +37,9 @@ func (client *Client) GetBillingPolicies(ctx context.Context) ([]BillingPolicyDt
 	values.Add("api-version", "2022-03-01-preview")
 	apiUrl.RawQuery = values.Encode()
 
-	policies := BillingPolicyArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &policies)
+	policies, _, err := api.ExecuteForAllPages[BillingPolicyDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 
-	return policies.Value, err
+	return policies, err
 }
 
 func (client *Client) GetBillingPolicy(ctx context.Context, billingId string) (*BillingPolicyDto, error) {
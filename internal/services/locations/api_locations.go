@@ -34,10 +34,9 @@ func (client *client) GetLocations(ctx context.Context) (locationDto, error) {
 		RawQuery: values.Encode(),
 	}
 
-	var locations locationDto
-	_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &locations)
+	locations, _, err := api.ExecuteForAllPages[locationsArrayDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
-		return locations, fmt.Errorf("failed to get locations: %w", err)
+		return locationDto{}, fmt.Errorf("failed to get locations: %w", err)
 	}
-	return locations, nil
+	return locationDto{Value: locations}, nil
 }
@@ -6,7 +6,9 @@ package managedsolution
 import (
 	"archive/zip"
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
@@ -266,13 +268,12 @@ func (client *Client) getConnections(ctx context.Context, environmentId string)
 	values.Add("api-version", "1")
 	apiURL.RawQuery = values.Encode()
 
-	connections := connectionArrayDto{}
-	_, err := client.Api.Execute(ctx, nil, "GET", apiURL.String(), nil, nil, []int{http.StatusOK}, &connections)
+	connections, _, err := api.ExecuteForAllPages[connectionDto](ctx, client.Api, nil, apiURL.String(), nil, []int{http.StatusOK}, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connections: %w", err)
 	}
 
-	return connections.Value, nil
+	return connections, nil
 }
 
 func (client *Client) GetUnmanagedEnvironmentVariableDefinitions(ctx context.Context, environmentId string, schemaNames []string) (map[string]bool, error) {
@@ -729,12 +730,29 @@ func validateDependencies(required map[string]string, installed []solution.Solut
 	return fmt.Errorf("dependency validation failed: %s", strings.Join(problems, "; "))
 }
 
+// managedSolutionCacheDir is the directory below the user cache directory where packages downloaded with a declared
+// checksum are kept, so an unchanged artifact is only downloaded once.
+const managedSolutionCacheDir = "terraform-provider-power-platform/managed-solutions"
+
+// maxSourceDownloadBytes bounds the size of a downloaded package. Dataverse rejects solution imports long before it.
+const maxSourceDownloadBytes int64 = 1 << 30
+
+// userCacheDir is replaced in tests to keep the download cache out of the user profile.
+var userCacheDir = os.UserCacheDir
+
 func resolveSourceToPath(ctx context.Context, source *SourceModel) (string, func(), error) {
 	if source == nil {
 		return "", nil, errors.New("source is required")
 	}
 
+	expectedChecksum := strings.ToLower(normalizedSourceString(source.ExpectedSha256))
+
 	if !source.Path.IsNull() && !source.Path.IsUnknown() && source.Path.ValueString() != "" {
+		if expectedChecksum != "" {
+			if err := verifyFileChecksum(source.Path.ValueString(), expectedChecksum); err != nil {
+				return "", nil, err
+			}
+		}
 		return source.Path.ValueString(), func() {}, nil
 	}
 
@@ -742,35 +760,174 @@ func resolveSourceToPath(ctx context.Context, source *SourceModel) (string, func
 		return "", nil, errors.New("source.path or source.url must be set")
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL.ValueString(), nil)
+	if expectedChecksum != "" {
+		cacheDir, err := sourceCacheDir()
+		if err == nil {
+			cachedPath := filepath.Join(cacheDir, expectedChecksum+".zip")
+			if verifyFileChecksum(cachedPath, expectedChecksum) == nil {
+				tflog.Debug(ctx, fmt.Sprintf("Using cached managed solution package '%s'", cachedPath))
+				return cachedPath, func() {}, nil
+			}
+
+			downloadedPath, err := downloadSource(ctx, source, cacheDir, expectedChecksum)
+			if err != nil {
+				return "", nil, err
+			}
+			if err := os.Rename(downloadedPath, cachedPath); err == nil {
+				return cachedPath, func() {}, nil
+			}
+			return downloadedPath, func() {
+				_ = os.Remove(downloadedPath)
+			}, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Managed solution download cache is not available: %s", err.Error()))
+	}
+
+	downloadedPath, err := downloadSource(ctx, source, "", expectedChecksum)
 	if err != nil {
 		return "", nil, err
 	}
 
-	response, err := http.DefaultClient.Do(request)
+	return downloadedPath, func() {
+		_ = os.Remove(downloadedPath)
+	}, nil
+}
+
+func sourceCacheDir() (string, error) {
+	baseDir, err := userCacheDir()
 	if err != nil {
-		return "", nil, err
+		return "", err
+	}
+
+	cacheDir := filepath.Join(baseDir, filepath.FromSlash(managedSolutionCacheDir))
+	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
+		return "", err
+	}
+	return cacheDir, nil
+}
+
+// downloadSource downloads the package into a new file in dir, or the default temporary directory when dir is empty,
+// and verifies it against the expected checksum when one is set.
+func downloadSource(ctx context.Context, source *SourceModel, dir, expectedChecksum string) (string, error) {
+	downloadURL, err := sourceDownloadURL(source)
+	if err != nil {
+		return "", err
+	}
+	// The query string commonly carries credentials such as SAS signatures, so it is never part of an error.
+	redactedURL := normalizedSourceURL(source.URL)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return "", err
+	}
+
+	headers, diags := expandStringMap(source.Headers)
+	if diags.HasError() {
+		return "", errors.New("source.headers must be a map of strings")
+	}
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	if bearerToken := normalizedSourceString(source.BearerToken); bearerToken != "" {
+		request.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	httpClient, err := sourceHttpClient(source)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return "", fmt.Errorf("failed to download solution package from %s: %w", redactedURL, err)
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		return "", nil, fmt.Errorf("failed to download solution package from %s: unexpected status %d", source.URL.ValueString(), response.StatusCode)
+		return "", fmt.Errorf("failed to download solution package from %s: unexpected status %d", redactedURL, response.StatusCode)
+	}
+	if response.ContentLength > maxSourceDownloadBytes {
+		return "", fmt.Errorf("solution package at %s is larger than %d bytes", redactedURL, maxSourceDownloadBytes)
 	}
 
-	tempFile, err := os.CreateTemp("", "managed-solution-*.zip")
+	tempFile, err := os.CreateTemp(dir, "managed-solution-*.zip")
 	if err != nil {
-		return "", nil, err
+		return "", err
 	}
 	defer tempFile.Close()
 
-	if _, err := io.Copy(tempFile, response.Body); err != nil {
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tempFile, hash), io.LimitReader(response.Body, maxSourceDownloadBytes+1))
+	if err != nil {
 		_ = os.Remove(tempFile.Name())
-		return "", nil, err
+		return "", err
+	}
+	if written > maxSourceDownloadBytes {
+		_ = os.Remove(tempFile.Name())
+		return "", fmt.Errorf("solution package at %s is larger than %d bytes", redactedURL, maxSourceDownloadBytes)
 	}
 
-	return tempFile.Name(), func() {
+	if checksum := hex.EncodeToString(hash.Sum(nil)); expectedChecksum != "" && checksum != expectedChecksum {
 		_ = os.Remove(tempFile.Name())
-	}, nil
+		return "", fmt.Errorf("solution package downloaded from %s has sha256 checksum %s, expected %s", redactedURL, checksum, expectedChecksum)
+	}
+
+	return tempFile.Name(), nil
+}
+
+func sourceDownloadURL(source *SourceModel) (string, error) {
+	sasToken := strings.TrimPrefix(normalizedSourceString(source.SasToken), "?")
+	if sasToken == "" {
+		return source.URL.ValueString(), nil
+	}
+
+	parsed, err := url.Parse(source.URL.ValueString())
+	if err != nil {
+		return "", errors.New("source.url is not a valid URL")
+	}
+	if parsed.RawQuery == "" {
+		parsed.RawQuery = sasToken
+	} else {
+		parsed.RawQuery += "&" + sasToken
+	}
+	return parsed.String(), nil
+}
+
+func sourceHttpClient(source *SourceModel) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	if proxyURL := normalizedSourceString(source.ProxyURL); proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("source.proxy_url '%s' is not a valid URL", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func verifyFileChecksum(filePath, expectedChecksum string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+
+	if checksum := hex.EncodeToString(hash.Sum(nil)); checksum != expectedChecksum {
+		return fmt.Errorf("solution package '%s' has sha256 checksum %s, expected %s", filePath, checksum, expectedChecksum)
+	}
+	return nil
 }
 
 func readSourceContent(sourcePath string) ([]byte, error) {
@@ -786,7 +943,12 @@ func cloneSourceModel(source *SourceModel) *SourceModel {
 		return nil
 	}
 	return &SourceModel{
-		Path: source.Path,
-		URL:  source.URL,
+		Path:           source.Path,
+		URL:            source.URL,
+		ExpectedSha256: source.ExpectedSha256,
+		BearerToken:    source.BearerToken,
+		SasToken:       source.SasToken,
+		Headers:        source.Headers,
+		ProxyURL:       source.ProxyURL,
 	}
 }
@@ -131,10 +131,6 @@ type validateSolutionImportResultDto struct {
 	ErrorMessages []any  `json:"ErrorMessages"`
 }
 
-type connectionArrayDto struct {
-	Value []connectionDto `json:"value"`
-}
-
 type connectionDto struct {
 	Name       string                  `json:"name"`
 	Properties connectionPropertiesDto `json:"properties"`
@@ -29,6 +29,11 @@ type ResourceModel struct {
 }
 
 type SourceModel struct {
-	Path types.String `tfsdk:"path"`
-	URL  types.String `tfsdk:"url"`
+	Path           types.String `tfsdk:"path"`
+	URL            types.String `tfsdk:"url"`
+	ExpectedSha256 types.String `tfsdk:"expected_sha256"`
+	BearerToken    types.String `tfsdk:"bearer_token"`
+	SasToken       types.String `tfsdk:"sas_token"`
+	Headers        types.Map    `tfsdk:"headers"`
+	ProxyURL       types.String `tfsdk:"proxy_url"`
 }
@@ -8,13 +8,16 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -22,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
@@ -57,7 +61,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 	defer exitContext()
 
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Resource for deploying managed Power Platform solutions using solution identity (`unique_name` + `version`) instead of package checksums or delivery locations. Changing only a local path or signed URL does not replay an unchanged version. Initial creation installs the managed package or adopts an exact already-installed managed version only when no connection bindings must be applied. Exact or same-version connection bindings use an ordinary managed re-import; a higher version uses Dataverse stage-and-upgrade so omitted components are removed, and lower versions are rejected before import. Import state uses `{environment_id}/{solution_id}` and the first configured apply adopts the package source without replaying only when no target bindings require reconciliation. The resource verifies managed package identity, connection bindings, environment variable packaging rules, and solution dependencies before import. Import-start requests are never retried after an ambiguous response.",
+		MarkdownDescription: "Resource for deploying managed Power Platform solutions using solution identity (`unique_name` + `version`) instead of package checksums or delivery locations. Changing only a local path or signed URL does not replay an unchanged version. Initial creation installs the managed package or adopts an exact already-installed managed version only when no connection bindings must be applied. Exact or same-version connection bindings use an ordinary managed re-import; a higher version uses Dataverse stage-and-upgrade so omitted components are removed, and lower versions are rejected before import. Import state uses `{environment_id}/{solution_id}` and the first configured apply adopts the package source without replaying only when no target bindings require reconciliation. The resource verifies managed package identity, connection bindings, environment variable packaging rules, and solution dependencies before import. Import-start requests are never retried after an ambiguous response. Deleting the resource uninstalls the solution once no other solution depends on it: the uninstall waits while dependent solutions are being uninstalled, for example in the same `terraform destroy`, and otherwise fails with the solutions and components that block it.",
 		Attributes: map[string]schema.Attribute{
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Create: true,
@@ -103,6 +107,45 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 						Optional:            true,
 						Sensitive:           true,
 					},
+					"expected_sha256": schema.StringAttribute{
+						MarkdownDescription: "Hex encoded SHA-256 checksum of the package. The package is rejected when its checksum differs. Packages downloaded from `url` are cached in the user cache directory by this checksum, so an unchanged artifact is only downloaded once.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(regexp.MustCompile(`^[0-9a-fA-F]{64}$`), "expected_sha256 must be a hex encoded SHA-256 checksum"),
+						},
+					},
+					"bearer_token": schema.StringAttribute{
+						MarkdownDescription: "Token sent in the `Authorization: Bearer` header when downloading from `url`, for example a GitHub token or a Microsoft Entra ID access token for Azure Blob Storage.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("path")),
+						},
+					},
+					"sas_token": schema.StringAttribute{
+						MarkdownDescription: "Shared access signature appended to the query string of `url` when downloading from Azure Blob Storage. A leading `?` is ignored.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("path")),
+						},
+					},
+					"headers": schema.MapAttribute{
+						MarkdownDescription: "Additional request headers used when downloading from `url`, for example `Accept = \"application/octet-stream\"` for GitHub release assets or `x-ms-version` for Azure Blob Storage.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.Map{
+							mapvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("path")),
+						},
+					},
+					"proxy_url": schema.StringAttribute{
+						MarkdownDescription: "URL of the HTTP proxy used when downloading from `url`. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables are used.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("path")),
+						},
+					},
 				},
 			},
 			"connection_references": schema.MapAttribute{
@@ -0,0 +1,130 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package managedsolution
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackageContent = "managed-solution-package"
+
+func testPackageChecksum() string {
+	sum := sha256.Sum256([]byte(testPackageContent))
+	return hex.EncodeToString(sum[:])
+}
+
+func useTestCacheDir(t *testing.T) string {
+	t.Helper()
+
+	cacheDir := t.TempDir()
+	previous := userCacheDir
+	userCacheDir = func() (string, error) { return cacheDir, nil }
+	t.Cleanup(func() { userCacheDir = previous })
+	return cacheDir
+}
+
+func TestUnitResolveSourceToPath_SendsCredentialsAndCachesByChecksum(t *testing.T) {
+	cacheDir := useTestCacheDir(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		require.Equal(t, "application/octet-stream", r.Header.Get("Accept"))
+		require.Equal(t, "release=1&sv=2026-01-01&sig=abc", r.URL.RawQuery)
+		_, _ = w.Write([]byte(testPackageContent))
+	}))
+	defer server.Close()
+
+	source := &SourceModel{
+		URL:            types.StringValue(server.URL + "/solution.zip?release=1"),
+		ExpectedSha256: types.StringValue(testPackageChecksum()),
+		BearerToken:    types.StringValue("secret-token"),
+		SasToken:       types.StringValue("?sv=2026-01-01&sig=abc"),
+		Headers:        types.MapValueMust(types.StringType, map[string]attr.Value{"Accept": types.StringValue("application/octet-stream")}),
+	}
+
+	sourcePath, cleanup, err := resolveSourceToPath(context.Background(), source)
+	require.NoError(t, err)
+	cleanup()
+
+	require.Equal(t, filepath.Join(cacheDir, filepath.FromSlash(managedSolutionCacheDir), testPackageChecksum()+".zip"), sourcePath)
+	content, err := os.ReadFile(sourcePath)
+	require.NoError(t, err)
+	require.Equal(t, testPackageContent, string(content))
+
+	cachedPath, cleanup, err := resolveSourceToPath(context.Background(), source)
+	require.NoError(t, err)
+	cleanup()
+
+	require.Equal(t, sourcePath, cachedPath)
+	require.Equal(t, 1, requests)
+}
+
+func TestUnitResolveSourceToPath_RejectsChecksumMismatch(t *testing.T) {
+	cacheDir := useTestCacheDir(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("tampered-package"))
+	}))
+	defer server.Close()
+
+	source := &SourceModel{
+		URL:            types.StringValue(server.URL + "/solution.zip?sig=secret"),
+		ExpectedSha256: types.StringValue(testPackageChecksum()),
+	}
+
+	_, _, err := resolveSourceToPath(context.Background(), source)
+	require.ErrorContains(t, err, "expected "+testPackageChecksum())
+	require.NotContains(t, err.Error(), "sig=secret")
+
+	entries, err := os.ReadDir(filepath.Join(cacheDir, filepath.FromSlash(managedSolutionCacheDir)))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestUnitResolveSourceToPath_VerifiesLocalPathChecksum(t *testing.T) {
+	packagePath := filepath.Join(t.TempDir(), "solution.zip")
+	require.NoError(t, os.WriteFile(packagePath, []byte("tampered-package"), 0o600))
+
+	_, _, err := resolveSourceToPath(context.Background(), &SourceModel{
+		Path:           types.StringValue(packagePath),
+		ExpectedSha256: types.StringValue(testPackageChecksum()),
+	})
+	require.ErrorContains(t, err, "expected "+testPackageChecksum())
+}
+
+func TestUnitResolveSourceToPath_UsesConfiguredProxy(t *testing.T) {
+	useTestCacheDir(t)
+
+	proxiedHost := ""
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.Host
+		_, _ = w.Write([]byte(testPackageContent))
+	}))
+	defer proxy.Close()
+
+	sourcePath, cleanup, err := resolveSourceToPath(context.Background(), &SourceModel{
+		URL:      types.StringValue("http://artifacts.example.test/solution.zip"),
+		ProxyURL: types.StringValue(proxy.URL),
+	})
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.Equal(t, "artifacts.example.test", proxiedHost)
+	content, err := os.ReadFile(sourcePath)
+	require.NoError(t, err)
+	require.Equal(t, testPackageContent, string(content))
+}
@@ -8,46 +8,246 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
 	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
 	"github.com/microsoft/terraform-provider-power-platform/internal/services/environment"
 )
 
-func newPowerAppssClient(apiClient *api.Client) client {
-	return client{
+func NewPowerAppsClient(apiClient *api.Client) Client {
+	return Client{
 		Api:               apiClient,
 		environmentClient: environment.NewEnvironmentClient(apiClient),
 	}
 }
 
-type client struct {
+type Client struct {
 	Api               *api.Client
 	environmentClient environment.Client
 }
 
-func (client *client) GetPowerApps(ctx context.Context) ([]powerAppBapiDto, error) {
+func (client *Client) GetPowerApps(ctx context.Context) ([]PowerAppBapiDto, error) {
 	envs, err := client.environmentClient.GetEnvironments(ctx)
 	if err != nil {
 		return nil, err
 	}
-	apps := make([]powerAppBapiDto, 0)
+	apps := make([]PowerAppBapiDto, 0)
 	for _, env := range envs {
-		apiUrl := &url.URL{
-			Scheme: constants.HTTPS,
-			Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
-			Path:   fmt.Sprintf("/providers/Microsoft.PowerApps/scopes/admin/environments/%s/apps", env.Name),
-		}
-		values := url.Values{}
-		values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
-		apiUrl.RawQuery = values.Encode()
-
-		appsArray := powerAppArrayDto{}
-		_, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK}, &appsArray)
+		envApps, err := client.GetEnvironmentPowerApps(ctx, env.Name)
 		if err != nil {
 			return nil, err
 		}
-		apps = append(apps, appsArray.Value...)
+		apps = append(apps, envApps...)
 	}
 	return apps, nil
 }
+
+func (client *Client) GetEnvironmentPowerApps(ctx context.Context, environmentId string) ([]PowerAppBapiDto, error) {
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
+		Path:   fmt.Sprintf("/providers/Microsoft.PowerApps/scopes/admin/environments/%s/apps", environmentId),
+	}
+	values := url.Values{}
+	values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
+	apiUrl.RawQuery = values.Encode()
+
+	apps, _, err := api.ExecuteForAllPages[PowerAppBapiDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return apps, nil
+}
+
+func (client *Client) GetEnvironmentPowerApp(ctx context.Context, environmentId, appName string) (*PowerAppBapiDto, error) {
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, "")
+
+	app := PowerAppBapiDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl, nil, nil, []int{http.StatusOK, http.StatusNotFound}, &app)
+	if err != nil {
+		return nil, err
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("Power App '%s' not found", appName))
+	}
+	return &app, nil
+}
+
+// SetPowerAppOwner makes the Entra user the owner of the app. The previous owner keeps access to the app with the given role.
+func (client *Client) SetPowerAppOwner(ctx context.Context, environmentId, appName, ownerId, roleForPreviousOwner string) error {
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, "/modifyAppOwner")
+
+	owner := modifyPowerAppOwnerDto{
+		RoleForOldAppOwner: roleForPreviousOwner,
+		NewAppOwner:        ownerId,
+	}
+	_, err := client.Api.Execute(ctx, nil, "POST", apiUrl, nil, owner, []int{http.StatusOK, http.StatusAccepted}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to change owner of Power App: %w", err)
+	}
+	return nil
+}
+
+// SetPowerAppQuarantine quarantines the app, so that users can't launch it, or lifts its quarantine.
+func (client *Client) SetPowerAppQuarantine(ctx context.Context, environmentId, appName string, quarantined bool) error {
+	operation := "/unquarantine"
+	if quarantined {
+		operation = "/quarantine"
+	}
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, operation)
+
+	_, err := client.Api.Execute(ctx, nil, "POST", apiUrl, nil, nil, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to change quarantine of Power App: %w", err)
+	}
+	return nil
+}
+
+// SetPowerAppBypassConsent sets whether users launch the app without being prompted to consent to the connections it uses.
+func (client *Client) SetPowerAppBypassConsent(ctx context.Context, environmentId, appName string, bypassConsent bool) error {
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, "/setPowerAppConnectionDirectConsentBypass")
+
+	_, err := client.Api.Execute(ctx, nil, "POST", apiUrl, nil, setPowerAppBypassConsentDto{BypassConsent: bypassConsent}, []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to change consent bypass of Power App: %w", err)
+	}
+	return nil
+}
+
+func (client *Client) SharePowerApp(ctx context.Context, environmentId, appName, roleName string, principal powerAppPermissionPrincipalDto) error {
+	share := modifyPowerAppPermissionsDto{
+		Put: []modifyPowerAppPermissionsPutDto{
+			{
+				Properties: modifyPowerAppPermissionsPutPropertiesDto{
+					RoleName:                roleName,
+					Principal:               principal,
+					NotifyShareTargetOption: "Notify",
+				},
+			},
+		},
+		Delete: []modifyPowerAppPermissionsDeleteDto{},
+	}
+
+	if err := client.modifyPowerAppPermissions(ctx, environmentId, appName, share); err != nil {
+		return fmt.Errorf("failed to share Power App: %w", err)
+	}
+	return nil
+}
+
+func (client *Client) GetPowerAppShares(ctx context.Context, environmentId, appName string) ([]powerAppPermissionDto, error) {
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, "/permissions")
+
+	shares, resp, err := api.ExecuteForAllPages[powerAppPermissionDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusNotFound}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Power App shares: %w", err)
+	}
+	if resp.HttpResponse.StatusCode == http.StatusNotFound {
+		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("Power App '%s' not found", appName))
+	}
+
+	sort.SliceStable(shares, func(i, j int) bool {
+		return shares[i].Properties.Principal.Id < shares[j].Properties.Principal.Id
+	})
+	return shares, nil
+}
+
+func (client *Client) GetPowerAppShare(ctx context.Context, environmentId, appName, principalId string) (*powerAppPermissionDto, error) {
+	shares, err := client.GetPowerAppShares(ctx, environmentId, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, share := range shares {
+		if strings.EqualFold(share.Properties.Principal.Id, principalId) {
+			return &share, nil
+		}
+	}
+	return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND),
+		fmt.Sprintf("Share for principal '%s' not found", principalId))
+}
+
+func (client *Client) DeletePowerAppShare(ctx context.Context, environmentId, appName, shareId string) error {
+	share := modifyPowerAppPermissionsDto{
+		Put: []modifyPowerAppPermissionsPutDto{},
+		Delete: []modifyPowerAppPermissionsDeleteDto{
+			{
+				Id: shareId,
+			},
+		},
+	}
+
+	if err := client.modifyPowerAppPermissions(ctx, environmentId, appName, share); err != nil {
+		return fmt.Errorf("failed to delete Power App share: %w", err)
+	}
+	return nil
+}
+
+func (client *Client) modifyPowerAppPermissions(ctx context.Context, environmentId, appName string, permissions modifyPowerAppPermissionsDto) error {
+	apiUrl := client.buildPowerAppUrl(environmentId, appName, "/modifyPermissions")
+
+	_, err := client.Api.Execute(ctx, nil, "POST", apiUrl, nil, permissions, []int{http.StatusOK}, nil)
+	return err
+}
+
+func (client *Client) buildPowerAppUrl(environmentId, appName, operation string) string {
+	apiUrl := &url.URL{
+		Scheme: constants.HTTPS,
+		Host:   client.Api.GetConfig().Urls.PowerAppsUrl,
+		Path:   fmt.Sprintf("/providers/Microsoft.PowerApps/scopes/admin/environments/%s/apps/%s%s", environmentId, appName, operation),
+	}
+	values := url.Values{}
+	values.Add(constants.API_VERSION_PARAM, constants.BAP_API_VERSION)
+	apiUrl.RawQuery = values.Encode()
+	return apiUrl.String()
+}
+
+func powerAppResourceId(environmentId, appName string) string {
+	return fmt.Sprintf("%s/%s", environmentId, appName)
+}
+
+func parsePowerAppResourceId(id string) (environmentId string, appName string, err error) {
+	parts, err := splitImportId(id, "environment_id", "app_name")
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], parts[1], nil
+}
+
+func parsePowerAppShareResourceId(id string) (environmentId string, appName string, principalId string, err error) {
+	parts, err := splitImportId(id, "environment_id", "app_name", "entra_object_id")
+	if err != nil {
+		return "", "", "", err
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// splitImportId splits a `/` separated import id into exactly one non-empty part per field.
+func splitImportId(id string, fields ...string) ([]string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != len(fields) || slices.Contains(parts, "") {
+		return nil, fmt.Errorf("invalid import id %q, expected {%s}", id, strings.Join(fields, "}/{"))
+	}
+	return parts, nil
+}
+
+// powerAppType classifies the app type returned by the API as `Canvas`, `ModelDriven` or `Code`.
+// Canvas apps created before app types were introduced have no app type.
+func powerAppType(appType string) string {
+	switch appType := strings.ToLower(appType); {
+	case strings.Contains(appType, "code"):
+		return "Code"
+	case strings.Contains(appType, "modeldriven"), strings.Contains(appType, "appmodule"):
+		return "ModelDriven"
+	default:
+		return "Canvas"
+	}
+}
+
+// connectorName returns the name of the connector with the given id, e.g. `shared_sql` for `/providers/Microsoft.PowerApps/apis/shared_sql`.
+func connectorName(connectorId string) string {
+	return connectorId[strings.LastIndex(connectorId, "/")+1:]
+}
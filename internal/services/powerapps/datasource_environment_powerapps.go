@@ -6,6 +6,7 @@ package powerapps
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -49,6 +50,14 @@ func (d *EnvironmentPowerAppsDataSource) Schema(ctx context.Context, req datasou
 			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
 				Read: true,
 			}),
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "Only return the apps owned by the user with this Entra Object Id",
+				Optional:            true,
+			},
+			"connector_name": schema.StringAttribute{
+				MarkdownDescription: "Only return the apps that use a connection of this connector, e.g. `shared_sql`",
+				Optional:            true,
+			},
 			"powerapps": schema.ListNestedAttribute{
 				MarkdownDescription: "List of Power Apps",
 				Computed:            true,
@@ -70,6 +79,68 @@ func (d *EnvironmentPowerAppsDataSource) Schema(ctx context.Context, req datasou
 							MarkdownDescription: "Created time",
 							Computed:            true,
 						},
+						"last_modified_time": schema.StringAttribute{
+							MarkdownDescription: "Last modified time",
+							Computed:            true,
+						},
+						"app_type": schema.StringAttribute{
+							MarkdownDescription: "Type of the app, one of `Canvas`, `ModelDriven` or `Code`",
+							Computed:            true,
+						},
+						"owner": schema.SingleNestedAttribute{
+							MarkdownDescription: "Owner of the app",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"id": schema.StringAttribute{
+									MarkdownDescription: "Entra Object Id of the owner",
+									Computed:            true,
+								},
+								"display_name": schema.StringAttribute{
+									MarkdownDescription: "Display name of the owner",
+									Computed:            true,
+								},
+								"user_principal_name": schema.StringAttribute{
+									MarkdownDescription: "User principal name of the owner",
+									Computed:            true,
+								},
+							},
+						},
+						"connections": schema.ListNestedAttribute{
+							MarkdownDescription: "Connections used by the app",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Name of the connection reference in the app",
+										Computed:            true,
+									},
+									"display_name": schema.StringAttribute{
+										MarkdownDescription: "Display name of the connector",
+										Computed:            true,
+									},
+									"connector_id": schema.StringAttribute{
+										MarkdownDescription: "Id of the connector, e.g. `/providers/Microsoft.PowerApps/apis/shared_sql`",
+										Computed:            true,
+									},
+									"connector_name": schema.StringAttribute{
+										MarkdownDescription: "Name of the connector, e.g. `shared_sql`",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"shared_users_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of users the app is shared with",
+							Computed:            true,
+						},
+						"shared_groups_count": schema.Int64Attribute{
+							MarkdownDescription: "Number of groups the app is shared with",
+							Computed:            true,
+						},
+						"is_quarantined": schema.BoolAttribute{
+							MarkdownDescription: "Whether the app is quarantined, so that users can't launch it",
+							Computed:            true,
+						},
 					},
 				},
 			},
@@ -95,7 +166,7 @@ func (d *EnvironmentPowerAppsDataSource) Configure(ctx context.Context, req data
 		return
 	}
 
-	d.PowerAppssClient = newPowerAppssClient(client.Api)
+	d.PowerAppssClient = NewPowerAppsClient(client.Api)
 }
 
 func (d *EnvironmentPowerAppsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -115,8 +186,12 @@ func (d *EnvironmentPowerAppsDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 
+	state.PowerApps = []EnvironmentPowerAppsDataSourceModel{}
 	for _, app := range apps {
 		appModel := ConvertFromPowerAppDto(app)
+		if !matchesPowerAppFilters(&state, &appModel) {
+			continue
+		}
 		state.PowerApps = append(state.PowerApps, appModel)
 	}
 
@@ -126,3 +201,18 @@ func (d *EnvironmentPowerAppsDataSource) Read(ctx context.Context, req datasourc
 		return
 	}
 }
+
+func matchesPowerAppFilters(filters *EnvironmentPowerAppsListDataSourceModel, app *EnvironmentPowerAppsDataSourceModel) bool {
+	if !filters.OwnerId.IsNull() && !strings.EqualFold(filters.OwnerId.ValueString(), app.Owner.Id.ValueString()) {
+		return false
+	}
+	if filters.ConnectorName.IsNull() {
+		return true
+	}
+	for _, connection := range app.Connections {
+		if strings.EqualFold(filters.ConnectorName.ValueString(), connection.ConnectorName.ValueString()) {
+			return true
+		}
+	}
+	return false
+}
@@ -83,3 +83,78 @@ func TestUnitEnvironmentPowerAppsDataSource_Validate_Read(t *testing.T) {
 		},
 	})
 }
+
+func TestUnitEnvironmentPowerAppsDataSource_Validate_Read_Filters(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments?%24expand=properties%2FbillingPolicy%2Cproperties%2FcopilotPolicies&api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/Validate_Read_Filters/get_environments.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://api\.powerapps\.com/providers/Microsoft\.PowerApps/scopes/admin/environments/([\d-]+)/apps`,
+		func(req *http.Request) (*http.Response, error) {
+			id := httpmock.MustGetSubmatch(req, 1)
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/Validate_Read_Filters/get_apps_"+id+".json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_environment_powerapps" "all" {}
+
+				data "powerplatform_environment_powerapps" "jane" {
+					owner_id = "00000000-0000-0000-0000-00000000000A"
+				}
+
+				data "powerplatform_environment_powerapps" "sql" {
+					connector_name = "shared_sql"
+				}
+
+				data "powerplatform_environment_powerapps" "jane_outlook" {
+					owner_id       = "00000000-0000-0000-0000-00000000000a"
+					connector_name = "shared_office365"
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.#", "3"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.name", "00000000-0000-0000-0000-000000000011"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.app_type", "Canvas"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.last_modified_time", "2024-03-15T16:45:00.0000000Z"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.owner.id", "00000000-0000-0000-0000-00000000000a"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.owner.display_name", "Jane Doe"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.owner.user_principal_name", "jane@contoso.com"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.connections.#", "2"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.connections.0.name", "1e4b8d3a-0000-0000-0000-000000000001"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.connections.0.connector_id", "/providers/Microsoft.PowerApps/apis/shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.connections.0.connector_name", "shared_sql"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.connections.0.display_name", "SQL Server"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.shared_users_count", "12"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.shared_groups_count", "3"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.0.is_quarantined", "true"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.1.app_type", "ModelDriven"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.1.connections.#", "0"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.1.is_quarantined", "false"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.all", "powerapps.2.app_type", "Code"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.jane", "powerapps.#", "2"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.jane", "powerapps.0.name", "00000000-0000-0000-0000-000000000011"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.jane", "powerapps.1.name", "00000000-0000-0000-0000-000000000021"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.sql", "powerapps.#", "1"),
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.sql", "powerapps.0.name", "00000000-0000-0000-0000-000000000011"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_environment_powerapps.jane_outlook", "powerapps.#", "2"),
+				),
+			},
+		},
+	})
+}
@@ -3,21 +3,36 @@
 
 package powerapps
 
-type powerAppBapiDto struct {
+type PowerAppBapiDto struct {
 	Name       string                    `json:"name"`
+	AppType    string                    `json:"appType"`
 	Properties powerAppPropertiesBapiDto `json:"properties"`
 }
 
 type powerAppPropertiesBapiDto struct {
-	DisplayName      string                 `json:"displayName"`
-	Owner            powerAppCreatedByDto   `json:"owner"`
-	CreatedBy        powerAppCreatedByDto   `json:"createdBy"`
-	LastModifiedBy   powerAppCreatedByDto   `json:"lastModifiedBy"`
-	LastPublishedBy  powerAppCreatedByDto   `json:"lastPublishedBy"`
-	CreatedTime      string                 `json:"createdTime"`
-	LastModifiedTime string                 `json:"lastModifiedTime"`
-	LastPublishTime  string                 `json:"lastPublishTime"`
-	Environment      powerAppEnvironmentDto `json:"environment"`
+	DisplayName           string                                    `json:"displayName"`
+	Owner                 powerAppCreatedByDto                      `json:"owner"`
+	CreatedBy             powerAppCreatedByDto                      `json:"createdBy"`
+	LastModifiedBy        powerAppCreatedByDto                      `json:"lastModifiedBy"`
+	LastPublishedBy       powerAppCreatedByDto                      `json:"lastPublishedBy"`
+	CreatedTime           string                                    `json:"createdTime"`
+	LastModifiedTime      string                                    `json:"lastModifiedTime"`
+	LastPublishTime       string                                    `json:"lastPublishTime"`
+	Environment           powerAppEnvironmentDto                    `json:"environment"`
+	ConnectionReferences  map[string]powerAppConnectionReferenceDto `json:"connectionReferences"`
+	SharedUsersCount      int64                                     `json:"sharedUsersCount"`
+	SharedGroupsCount     int64                                     `json:"sharedGroupsCount"`
+	ExecutionRestrictions powerAppExecutionRestrictionsDto          `json:"executionRestrictions"`
+	BypassConsent         bool                                      `json:"bypassConsent"`
+}
+
+type powerAppExecutionRestrictionsDto struct {
+	IsTemporarilyDisabled bool `json:"isTemporarilyDisabled"`
+}
+
+type powerAppConnectionReferenceDto struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName"`
 }
 
 type powerAppEnvironmentDto struct {
@@ -32,6 +47,48 @@ type powerAppCreatedByDto struct {
 	UserPrincipalName string `json:"userPrincipalName"`
 }
 
-type powerAppArrayDto struct {
-	Value []powerAppBapiDto `json:"value"`
+type powerAppPermissionDto struct {
+	Name       string                          `json:"name"`
+	Id         string                          `json:"id"`
+	Properties powerAppPermissionPropertiesDto `json:"properties"`
+}
+
+type powerAppPermissionPropertiesDto struct {
+	RoleName  string                         `json:"roleName"`
+	Principal powerAppPermissionPrincipalDto `json:"principal"`
+}
+
+type powerAppPermissionPrincipalDto struct {
+	Id          string `json:"id"`
+	DisplayName string `json:"displayName,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Type        string `json:"type"`
+}
+
+type modifyPowerAppPermissionsDto struct {
+	Put    []modifyPowerAppPermissionsPutDto    `json:"put"`
+	Delete []modifyPowerAppPermissionsDeleteDto `json:"delete"`
+}
+
+type modifyPowerAppPermissionsPutDto struct {
+	Properties modifyPowerAppPermissionsPutPropertiesDto `json:"properties"`
+}
+
+type modifyPowerAppPermissionsPutPropertiesDto struct {
+	RoleName                string                         `json:"roleName"`
+	Principal               powerAppPermissionPrincipalDto `json:"principal"`
+	NotifyShareTargetOption string                         `json:"NotifyShareTargetOption"`
+}
+
+type modifyPowerAppPermissionsDeleteDto struct {
+	Id string `json:"id"`
+}
+
+type modifyPowerAppOwnerDto struct {
+	RoleForOldAppOwner string `json:"roleForOldAppOwner"`
+	NewAppOwner        string `json:"newAppOwner"`
+}
+
+type setPowerAppBypassConsentDto struct {
+	BypassConsent bool `json:"bypassconsent"`
 }
@@ -4,6 +4,10 @@
 package powerapps
 
 import (
+	"context"
+	"sort"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
@@ -11,26 +15,199 @@ import (
 
 type EnvironmentPowerAppsDataSource struct {
 	helpers.TypeInfo
-	PowerAppssClient client
+	PowerAppssClient Client
 }
 
 type EnvironmentPowerAppsListDataSourceModel struct {
-	Timeouts  timeouts.Value                        `tfsdk:"timeouts"`
-	PowerApps []EnvironmentPowerAppsDataSourceModel `tfsdk:"powerapps"`
+	Timeouts      timeouts.Value                        `tfsdk:"timeouts"`
+	OwnerId       types.String                          `tfsdk:"owner_id"`
+	ConnectorName types.String                          `tfsdk:"connector_name"`
+	PowerApps     []EnvironmentPowerAppsDataSourceModel `tfsdk:"powerapps"`
 }
 
 type EnvironmentPowerAppsDataSourceModel struct {
-	EnvironmentId types.String `tfsdk:"id"`
-	DisplayName   types.String `tfsdk:"display_name"`
+	EnvironmentId     types.String                            `tfsdk:"id"`
+	DisplayName       types.String                            `tfsdk:"display_name"`
+	Name              types.String                            `tfsdk:"name"`
+	CreatedTime       types.String                            `tfsdk:"created_time"`
+	LastModifiedTime  types.String                            `tfsdk:"last_modified_time"`
+	AppType           types.String                            `tfsdk:"app_type"`
+	Owner             EnvironmentPowerAppOwnerDataSourceModel `tfsdk:"owner"`
+	Connections       []EnvironmentPowerAppConnectionModel    `tfsdk:"connections"`
+	SharedUsersCount  types.Int64                             `tfsdk:"shared_users_count"`
+	SharedGroupsCount types.Int64                             `tfsdk:"shared_groups_count"`
+	IsQuarantined     types.Bool                              `tfsdk:"is_quarantined"`
+}
+
+type EnvironmentPowerAppOwnerDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	DisplayName       types.String `tfsdk:"display_name"`
+	UserPrincipalName types.String `tfsdk:"user_principal_name"`
+}
+
+type EnvironmentPowerAppConnectionModel struct {
 	Name          types.String `tfsdk:"name"`
-	CreatedTime   types.String `tfsdk:"created_time"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	ConnectorId   types.String `tfsdk:"connector_id"`
+	ConnectorName types.String `tfsdk:"connector_name"`
 }
 
-func ConvertFromPowerAppDto(powerAppDto powerAppBapiDto) EnvironmentPowerAppsDataSourceModel {
+func ConvertFromPowerAppDto(powerAppDto PowerAppBapiDto) EnvironmentPowerAppsDataSourceModel {
+	connections := make([]EnvironmentPowerAppConnectionModel, 0, len(powerAppDto.Properties.ConnectionReferences))
+	for name, connection := range powerAppDto.Properties.ConnectionReferences {
+		connections = append(connections, EnvironmentPowerAppConnectionModel{
+			Name:          types.StringValue(name),
+			DisplayName:   types.StringValue(connection.DisplayName),
+			ConnectorId:   types.StringValue(connection.Id),
+			ConnectorName: types.StringValue(connectorName(connection.Id)),
+		})
+	}
+	sort.Slice(connections, func(i, j int) bool {
+		return connections[i].Name.ValueString() < connections[j].Name.ValueString()
+	})
+
 	return EnvironmentPowerAppsDataSourceModel{
-		EnvironmentId: types.StringValue(powerAppDto.Properties.Environment.Name),
-		DisplayName:   types.StringValue(powerAppDto.Properties.DisplayName),
-		Name:          types.StringValue(powerAppDto.Name),
-		CreatedTime:   types.StringValue(powerAppDto.Properties.CreatedTime),
+		EnvironmentId:    types.StringValue(powerAppDto.Properties.Environment.Name),
+		DisplayName:      types.StringValue(powerAppDto.Properties.DisplayName),
+		Name:             types.StringValue(powerAppDto.Name),
+		CreatedTime:      types.StringValue(powerAppDto.Properties.CreatedTime),
+		LastModifiedTime: types.StringValue(powerAppDto.Properties.LastModifiedTime),
+		AppType:          types.StringValue(powerAppType(powerAppDto.AppType)),
+		Owner: EnvironmentPowerAppOwnerDataSourceModel{
+			Id:                types.StringValue(powerAppDto.Properties.Owner.Id),
+			DisplayName:       types.StringValue(powerAppDto.Properties.Owner.DisplayName),
+			UserPrincipalName: types.StringValue(powerAppDto.Properties.Owner.UserPrincipalName),
+		},
+		Connections:       connections,
+		SharedUsersCount:  types.Int64Value(powerAppDto.Properties.SharedUsersCount),
+		SharedGroupsCount: types.Int64Value(powerAppDto.Properties.SharedGroupsCount),
+		IsQuarantined:     types.BoolValue(powerAppDto.Properties.ExecutionRestrictions.IsTemporarilyDisabled),
+	}
+}
+
+type ShareResource struct {
+	helpers.TypeInfo
+	PowerAppsClient Client
+}
+
+type ShareResourceModel struct {
+	Timeouts      timeouts.Value              `tfsdk:"timeouts"`
+	Id            types.String                `tfsdk:"id"`
+	EnvironmentId types.String                `tfsdk:"environment_id"`
+	AppName       types.String                `tfsdk:"app_name"`
+	RoleName      types.String                `tfsdk:"role_name"`
+	Principal     SharePrincipalResourceModel `tfsdk:"principal"`
+}
+
+type SharePrincipalResourceModel struct {
+	EntraObjectId types.String `tfsdk:"entra_object_id"`
+	Type          types.String `tfsdk:"type"`
+	DisplayName   types.String `tfsdk:"display_name"`
+}
+
+func convertFromPowerAppShareDto(oldPlan *ShareResourceModel, share *powerAppPermissionDto) *ShareResourceModel {
+	principalType := types.StringValue(share.Properties.Principal.Type)
+	if share.Properties.Principal.Type == "" {
+		principalType = oldPlan.Principal.Type
+	}
+	// Keep the id as configured, the API may return it in another casing.
+	entraObjectId := types.StringValue(share.Properties.Principal.Id)
+	if strings.EqualFold(oldPlan.Principal.EntraObjectId.ValueString(), share.Properties.Principal.Id) {
+		entraObjectId = oldPlan.Principal.EntraObjectId
+	}
+	return &ShareResourceModel{
+		Timeouts:      oldPlan.Timeouts,
+		Id:            types.StringValue(share.Name),
+		EnvironmentId: oldPlan.EnvironmentId,
+		AppName:       oldPlan.AppName,
+		RoleName:      types.StringValue(share.Properties.RoleName),
+		Principal: SharePrincipalResourceModel{
+			EntraObjectId: entraObjectId,
+			Type:          principalType,
+			DisplayName:   types.StringValue(share.Properties.Principal.DisplayName),
+		},
+	}
+}
+
+type OwnerResource struct {
+	helpers.TypeInfo
+	PowerAppsClient Client
+}
+
+type OwnerResourceModel struct {
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
+	Id                   types.String   `tfsdk:"id"`
+	EnvironmentId        types.String   `tfsdk:"environment_id"`
+	AppName              types.String   `tfsdk:"app_name"`
+	OwnerId              types.String   `tfsdk:"owner_id"`
+	OwnerDisplayName     types.String   `tfsdk:"owner_display_name"`
+	RoleForPreviousOwner types.String   `tfsdk:"role_for_previous_owner"`
+}
+
+func convertFromPowerAppOwnerDto(oldPlan *OwnerResourceModel, app *PowerAppBapiDto) *OwnerResourceModel {
+	ownerId := types.StringValue(app.Properties.Owner.Id)
+	if strings.EqualFold(oldPlan.OwnerId.ValueString(), app.Properties.Owner.Id) {
+		ownerId = oldPlan.OwnerId
 	}
+	return &OwnerResourceModel{
+		Timeouts:             oldPlan.Timeouts,
+		Id:                   types.StringValue(powerAppResourceId(oldPlan.EnvironmentId.ValueString(), app.Name)),
+		EnvironmentId:        oldPlan.EnvironmentId,
+		AppName:              types.StringValue(app.Name),
+		OwnerId:              ownerId,
+		OwnerDisplayName:     types.StringValue(app.Properties.Owner.DisplayName),
+		RoleForPreviousOwner: oldPlan.RoleForPreviousOwner,
+	}
+}
+
+type AppFlagResource struct {
+	helpers.TypeInfo
+	PowerAppsClient Client
+	flag            appFlag
+}
+
+// appFlag describes a boolean flag of an app that is managed by its own resource.
+type appFlag interface {
+	resourceDescription() string
+	attributeName() string
+	attributeDescription() string
+	newModel() appFlagResourceModel
+	value(app *PowerAppBapiDto) bool
+	setValue(ctx context.Context, client *Client, environmentId, appName string, value bool) error
+}
+
+// appFlagResourceModel is implemented by the models of the resources that manage a boolean flag of an app.
+type appFlagResourceModel interface {
+	app() *AppFlagResourceModel
+	flag() *types.Bool
+}
+
+type AppFlagResourceModel struct {
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+	Id            types.String   `tfsdk:"id"`
+	EnvironmentId types.String   `tfsdk:"environment_id"`
+	AppName       types.String   `tfsdk:"app_name"`
+}
+
+type QuarantineResourceModel struct {
+	AppFlagResourceModel
+	Quarantined types.Bool `tfsdk:"quarantined"`
+}
+
+func (m *QuarantineResourceModel) app() *AppFlagResourceModel { return &m.AppFlagResourceModel }
+func (m *QuarantineResourceModel) flag() *types.Bool          { return &m.Quarantined }
+
+type BypassConsentResourceModel struct {
+	AppFlagResourceModel
+	BypassConsent types.Bool `tfsdk:"bypass_consent"`
+}
+
+func (m *BypassConsentResourceModel) app() *AppFlagResourceModel { return &m.AppFlagResourceModel }
+func (m *BypassConsentResourceModel) flag() *types.Bool          { return &m.BypassConsent }
+
+func convertFromPowerAppFlagDto(model appFlagResourceModel, app *PowerAppBapiDto, value bool) appFlagResourceModel {
+	model.app().Id = types.StringValue(powerAppResourceId(model.app().EnvironmentId.ValueString(), app.Name))
+	model.app().AppName = types.StringValue(app.Name)
+	*model.flag() = types.BoolValue(value)
+	return model
 }
@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+func NewPowerAppBypassConsentResource() resource.Resource {
+	return &AppFlagResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "powerapp_bypass_consent",
+		},
+		flag: bypassConsentFlag{},
+	}
+}
+
+type bypassConsentFlag struct{}
+
+func (bypassConsentFlag) resourceDescription() string {
+	return "Sets whether users launch a canvas app without being prompted to consent to the connections it uses. Bypassing consent is only supported for apps that use Microsoft first party connectors or custom connectors with Entra ID authentication. Changing the consent bypass outside of Terraform is detected as drift. Deleting this resource prompts users for consent again. See [Bypass API consent](https://learn.microsoft.com/power-apps/maker/canvas-apps/share-app#bypass-api-consent) for more details."
+}
+
+func (bypassConsentFlag) attributeName() string {
+	return "bypass_consent"
+}
+
+func (bypassConsentFlag) attributeDescription() string {
+	return "Whether users launch the app without being prompted to consent to its connections"
+}
+
+func (bypassConsentFlag) newModel() appFlagResourceModel {
+	return &BypassConsentResourceModel{}
+}
+
+func (bypassConsentFlag) value(app *PowerAppBapiDto) bool {
+	return app.Properties.BypassConsent
+}
+
+func (bypassConsentFlag) setValue(ctx context.Context, client *Client, environmentId, appName string, bypassConsent bool) error {
+	return client.SetPowerAppBypassConsent(ctx, environmentId, appName, bypassConsent)
+}
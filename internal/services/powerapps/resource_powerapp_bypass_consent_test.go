@@ -0,0 +1,122 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitPowerAppBypassConsentResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The mocked API keeps the consent bypass it was sent, so that the app read back follows the applied changes.
+	app := map[string]any{}
+	if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_Owner/get_app.json").String()), &app); err != nil {
+		t.Fatal(err)
+	}
+	properties := app["properties"].(map[string]any)
+	properties["bypassConsent"] = false
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, app)
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/setPowerAppConnectionDirectConsentBypass?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]bool{}
+			raw, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return nil, err
+			}
+			properties["bypassConsent"] = body["bypassconsent"]
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	config := func(bypassConsent bool) string {
+		return `
+		resource "powerplatform_powerapp_bypass_consent" "expenses" {
+			environment_id = "00000000-0000-0000-0000-000000000001"
+			app_name       = "00000000-0000-0000-0000-000000000002"
+			bypass_consent = ` + strconv.FormatBool(bypassConsent) + `
+		}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if properties["bypassConsent"] == true {
+				return errors.New("app still bypasses consent after the resource was destroyed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_bypass_consent.expenses", "id", "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_bypass_consent.expenses", "bypass_consent", "true"),
+				),
+			},
+			{
+				// Clearing the consent bypass outside of Terraform is detected as drift.
+				PreConfig: func() {
+					properties["bypassConsent"] = false
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_bypass_consent.expenses", "bypass_consent", "false"),
+				),
+			},
+			{
+				Config: config(true),
+			},
+			{
+				ResourceName:      "powerplatform_powerapp_bypass_consent.expenses",
+				ImportState:       true,
+				ImportStateId:     "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitPowerAppBypassConsentResource_Validate_Invalid_Import_Id(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_powerapp_bypass_consent" "expenses" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					app_name       = "00000000-0000-0000-0000-000000000002"
+					bypass_consent = true
+				}`,
+				ResourceName:  "powerplatform_powerapp_bypass_consent.expenses",
+				ImportState:   true,
+				ImportStateId: "00000000-0000-0000-0000-000000000002",
+				ExpectError:   regexp.MustCompile("Invalid import id"),
+			},
+		},
+	})
+}
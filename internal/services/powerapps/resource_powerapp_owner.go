@@ -0,0 +1,233 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &OwnerResource{}
+var _ resource.ResourceWithImportState = &OwnerResource{}
+
+func NewPowerAppOwnerResource() resource.Resource {
+	return &OwnerResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "powerapp_owner",
+		},
+	}
+}
+
+func (r *OwnerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *OwnerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Changes the owner of a canvas app, for example when its maker leaves the organization. The previous owner keeps access to the app with the role set in `role_for_previous_owner`. Changing the owner outside of Terraform is detected as drift. Deleting this resource leaves the app with its current owner. See [Manage Power Apps](https://learn.microsoft.com/power-platform/admin/admin-manage-apps) for more details.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				Read:   true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Composite resource id in the format `{environment_id}/{app_name}`",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the environment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app, e.g. the `name` of an app of the `powerplatform_environment_powerapps` data source",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner_id": schema.StringAttribute{
+				MarkdownDescription: "Entra Object Id of the user that owns the app",
+				Required:            true,
+			},
+			"owner_display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the user that owns the app",
+				Computed:            true,
+			},
+			"role_for_previous_owner": schema.StringAttribute{
+				MarkdownDescription: "Role the previous owner keeps on the app, either `CanView` or `CanEdit`. Defaults to `CanEdit`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("CanEdit"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("CanView", "CanEdit"),
+				},
+			},
+		},
+	}
+}
+
+func (r *OwnerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.PowerAppsClient = NewPowerAppsClient(client.Api)
+}
+
+func (r *OwnerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan *OwnerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.setPowerAppOwner(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppOwnerDto(plan, app))...)
+}
+
+func (r *OwnerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state *OwnerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.PowerAppsClient.GetEnvironmentPowerApp(ctx, state.EnvironmentId.ValueString(), state.AppName.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	// Use the owner the API returned, so that changing the owner outside of Terraform is detected as drift.
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppOwnerDto(state, app))...)
+}
+
+func (r *OwnerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan *OwnerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.setPowerAppOwner(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppOwnerDto(plan, app))...)
+}
+
+func (r *OwnerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state *OwnerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An app always has an owner, so there is nothing to revert.
+	tflog.Debug(ctx, fmt.Sprintf("Leaving Power App '%s' with owner '%s'", state.AppName.ValueString(), state.OwnerId.ValueString()))
+}
+
+func (r *OwnerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, appName, err := parsePowerAppResourceId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), appName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_for_previous_owner"), "CanEdit")...)
+}
+
+// setPowerAppOwner changes the owner of the app when it isn't owned by the planned owner yet, and returns the app as read after the change.
+func (r *OwnerResource) setPowerAppOwner(ctx context.Context, plan *OwnerResourceModel) (*PowerAppBapiDto, error) {
+	environmentId := plan.EnvironmentId.ValueString()
+	appName := plan.AppName.ValueString()
+
+	app, err := r.PowerAppsClient.GetEnvironmentPowerApp(ctx, environmentId, appName)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(app.Properties.Owner.Id, plan.OwnerId.ValueString()) {
+		return app, nil
+	}
+
+	err = r.PowerAppsClient.SetPowerAppOwner(ctx, environmentId, appName, plan.OwnerId.ValueString(), plan.RoleForPreviousOwner.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	return r.PowerAppsClient.GetEnvironmentPowerApp(ctx, environmentId, appName)
+}
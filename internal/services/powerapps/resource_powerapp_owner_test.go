@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitPowerAppOwnerResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The mocked API keeps the owner it was sent, so that the app read back follows the applied changes.
+	app := map[string]any{}
+	if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_Owner/get_app.json").String()), &app); err != nil {
+		t.Fatal(err)
+	}
+	owner := app["properties"].(map[string]any)["owner"].(map[string]any)
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, app)
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/modifyAppOwner?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]string{}
+			raw, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return nil, err
+			}
+			if body["roleForOldAppOwner"] != "CanView" {
+				return httpmock.NewStringResponse(http.StatusBadRequest, "unexpected role for the previous owner"), nil
+			}
+			owner["id"] = body["newAppOwner"]
+			owner["displayName"] = "Jane"
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	config := func(ownerId string) string {
+		return `
+		resource "powerplatform_powerapp_owner" "expenses" {
+			environment_id          = "00000000-0000-0000-0000-000000000001"
+			app_name                = "00000000-0000-0000-0000-000000000002"
+			owner_id                = "` + ownerId + `"
+			role_for_previous_owner = "CanView"
+		}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("00000000-0000-0000-0000-000000000003"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_owner.expenses", "id", "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_owner.expenses", "owner_id", "00000000-0000-0000-0000-000000000003"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_owner.expenses", "owner_display_name", "Jane"),
+				),
+			},
+			{
+				// Changing the owner in Power Apps is detected as drift.
+				PreConfig: func() {
+					owner["id"] = "f99f844b-ce3b-49ae-86f3-e374ecae789c"
+				},
+				Config:             config("00000000-0000-0000-0000-000000000003"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config("00000000-0000-0000-0000-000000000004"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_owner.expenses", "owner_id", "00000000-0000-0000-0000-000000000004"),
+				),
+			},
+			{
+				ResourceName:            "powerplatform_powerapp_owner.expenses",
+				ImportState:             true,
+				ImportStateId:           "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"role_for_previous_owner"},
+			},
+		},
+	})
+}
+
+func TestUnitPowerAppOwnerResource_Validate_App_Not_Found(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"?api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusNotFound, `{"error":{"code":"AppNotFound"}}`))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_powerapp_owner" "expenses" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					app_name       = "00000000-0000-0000-0000-000000000002"
+					owner_id       = "00000000-0000-0000-0000-000000000003"
+				}`,
+				ExpectError: regexp.MustCompile("Power App '00000000-0000-0000-0000-000000000002' not found"),
+			},
+		},
+	})
+}
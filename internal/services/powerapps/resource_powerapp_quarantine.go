@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+func NewPowerAppQuarantineResource() resource.Resource {
+	return &AppFlagResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "powerapp_quarantine",
+		},
+		flag: quarantineFlag{},
+	}
+}
+
+type quarantineFlag struct{}
+
+func (quarantineFlag) resourceDescription() string {
+	return "Quarantines a canvas app, so that users can no longer launch it while a security incident is investigated. Makers and admins can still edit the app. Quarantining or releasing the app outside of Terraform is detected as drift. Deleting this resource releases the app from quarantine. See [Quarantine an app](https://learn.microsoft.com/power-platform/admin/admin-manage-apps#manage-app-quarantine-state) for more details."
+}
+
+func (quarantineFlag) attributeName() string {
+	return "quarantined"
+}
+
+func (quarantineFlag) attributeDescription() string {
+	return "Whether the app is quarantined"
+}
+
+func (quarantineFlag) newModel() appFlagResourceModel {
+	return &QuarantineResourceModel{}
+}
+
+func (quarantineFlag) value(app *PowerAppBapiDto) bool {
+	return app.Properties.ExecutionRestrictions.IsTemporarilyDisabled
+}
+
+func (quarantineFlag) setValue(ctx context.Context, client *Client, environmentId, appName string, quarantined bool) error {
+	return client.SetPowerAppQuarantine(ctx, environmentId, appName, quarantined)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitPowerAppQuarantineResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The mocked API keeps the quarantine state it was sent, so that the app read back follows the applied changes.
+	app := map[string]any{}
+	if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_Owner/get_app.json").String()), &app); err != nil {
+		t.Fatal(err)
+	}
+	executionRestrictions := map[string]any{"isTemporarilyDisabled": false}
+	app["properties"].(map[string]any)["executionRestrictions"] = executionRestrictions
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, app)
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/quarantine?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			executionRestrictions["isTemporarilyDisabled"] = true
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/unquarantine?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			executionRestrictions["isTemporarilyDisabled"] = false
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	config := func(quarantined bool) string {
+		return `
+		resource "powerplatform_powerapp_quarantine" "expenses" {
+			environment_id = "00000000-0000-0000-0000-000000000001"
+			app_name       = "00000000-0000-0000-0000-000000000002"
+			quarantined    = ` + strconv.FormatBool(quarantined) + `
+		}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if executionRestrictions["isTemporarilyDisabled"] == true {
+				return errors.New("app is still quarantined after the resource was destroyed")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_quarantine.expenses", "id", "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_quarantine.expenses", "quarantined", "true"),
+				),
+			},
+			{
+				// Releasing the app from quarantine in the admin center is detected as drift.
+				PreConfig: func() {
+					executionRestrictions["isTemporarilyDisabled"] = false
+				},
+				Config:             config(true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_quarantine.expenses", "quarantined", "true"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_powerapp_quarantine.expenses",
+				ImportState:       true,
+				ImportStateId:     "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002",
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_quarantine.expenses", "quarantined", "false"),
+				),
+			},
+			{
+				Config: config(true),
+			},
+		},
+	})
+}
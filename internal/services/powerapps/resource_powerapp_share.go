@@ -0,0 +1,259 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &ShareResource{}
+var _ resource.ResourceWithImportState = &ShareResource{}
+
+func NewPowerAppShareResource() resource.Resource {
+	return &ShareResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "powerapp_share",
+		},
+	}
+}
+
+func (r *ShareResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *ShareResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shares a canvas app with an Entra user or group. See [Share a canvas app](https://learn.microsoft.com/power-apps/maker/canvas-apps/share-app) for more details.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+				Read:   true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the app share",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Unique identifier of the environment",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app, e.g. the `name` of an app of the `powerplatform_environment_powerapps` data source",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the role to assign to the principal, either `CanView` to use the app or `CanEdit` to also edit and share it",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("CanView", "CanEdit"),
+				},
+			},
+			"principal": schema.SingleNestedAttribute{
+				MarkdownDescription: "Principal to share the app with",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"entra_object_id": schema.StringAttribute{
+						MarkdownDescription: "Entra Object Id of the principal",
+						Required:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"type": schema.StringAttribute{
+						MarkdownDescription: "Type of the principal, either `User` or `Group`. Defaults to `User`",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("User"),
+						Validators: []validator.String{
+							stringvalidator.OneOf("User", "Group"),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
+					},
+					"display_name": schema.StringAttribute{
+						MarkdownDescription: "Display name of the principal",
+						Computed:            true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ShareResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.PowerAppsClient = NewPowerAppsClient(client.Api)
+}
+
+func (r *ShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan *ShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	share, err := r.sharePowerApp(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error sharing Power App", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppShareDto(plan, share))...)
+}
+
+func (r *ShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state *ShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	share, err := r.PowerAppsClient.GetPowerAppShare(ctx, state.EnvironmentId.ValueString(), state.AppName.ValueString(), state.Principal.EntraObjectId.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Error getting Power App share", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppShareDto(state, share))...)
+}
+
+func (r *ShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan *ShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sharing the app again with the same principal replaces its role.
+	share, err := r.sharePowerApp(ctx, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating Power App share", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, convertFromPowerAppShareDto(plan, share))...)
+}
+
+func (r *ShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state *ShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	share, err := r.PowerAppsClient.GetPowerAppShare(ctx, state.EnvironmentId.ValueString(), state.AppName.ValueString(), state.Principal.EntraObjectId.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Error deleting Power App share", err.Error())
+		return
+	}
+
+	err = r.PowerAppsClient.DeletePowerAppShare(ctx, state.EnvironmentId.ValueString(), state.AppName.ValueString(), share.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting Power App share", err.Error())
+		return
+	}
+}
+
+func (r *ShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	environmentId, appName, principalId, err := parsePowerAppShareResourceId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), environmentId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_name"), appName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal").AtName("entra_object_id"), principalId)...)
+}
+
+func (r *ShareResource) sharePowerApp(ctx context.Context, plan *ShareResourceModel) (*powerAppPermissionDto, error) {
+	principal := powerAppPermissionPrincipalDto{
+		Id:   plan.Principal.EntraObjectId.ValueString(),
+		Type: plan.Principal.Type.ValueString(),
+	}
+	err := r.PowerAppsClient.SharePowerApp(ctx, plan.EnvironmentId.ValueString(), plan.AppName.ValueString(), plan.RoleName.ValueString(), principal)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.PowerAppsClient.GetPowerAppShare(ctx, plan.EnvironmentId.ValueString(), plan.AppName.ValueString(), plan.Principal.EntraObjectId.ValueString())
+}
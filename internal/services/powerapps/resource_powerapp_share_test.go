@@ -0,0 +1,234 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package powerapps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+const powerAppUrl = "https://api.powerapps.com/providers/Microsoft.PowerApps/scopes/admin/environments/00000000-0000-0000-0000-000000000001/apps/00000000-0000-0000-0000-000000000002"
+
+func TestUnitPowerAppShareResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The mocked API keeps the permissions it was sent, so that the shares read back follow the applied changes.
+	permissions := map[string]any{}
+	if err := json.Unmarshal([]byte(httpmock.File("tests/resource/Validate_Share/get_permissions.json").String()), &permissions); err != nil {
+		t.Fatal(err)
+	}
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"/permissions?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewJsonResponse(http.StatusOK, permissions)
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/modifyPermissions?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			body := struct {
+				Put []struct {
+					Properties struct {
+						RoleName  string         `json:"roleName"`
+						Principal map[string]any `json:"principal"`
+					} `json:"properties"`
+				} `json:"put"`
+				Delete []struct {
+					Id string `json:"id"`
+				} `json:"delete"`
+			}{}
+			raw, _ := io.ReadAll(req.Body)
+			if err := json.Unmarshal(raw, &body); err != nil {
+				return nil, err
+			}
+
+			value := []any{}
+			for _, permission := range permissions["value"].([]any) {
+				deleted := false
+				for _, d := range body.Delete {
+					deleted = deleted || permission.(map[string]any)["id"] == d.Id
+				}
+				principalId := permission.(map[string]any)["properties"].(map[string]any)["principal"].(map[string]any)["id"]
+				for _, put := range body.Put {
+					deleted = deleted || principalId == put.Properties.Principal["id"]
+				}
+				if !deleted {
+					value = append(value, permission)
+				}
+			}
+			for _, put := range body.Put {
+				principal := put.Properties.Principal
+				principal["displayName"] = "Contoso Sales"
+				value = append(value, map[string]any{
+					"name": principal["id"],
+					"id":   "/providers/Microsoft.PowerApps/scopes/admin/apps/00000000-0000-0000-0000-000000000002/permissions/" + principal["id"].(string),
+					"properties": map[string]any{
+						"roleName":  put.Properties.RoleName,
+						"principal": principal,
+					},
+				})
+			}
+			permissions["value"] = value
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	config := func(roleName string) string {
+		return `
+		resource "powerplatform_powerapp_share" "sales" {
+			environment_id = "00000000-0000-0000-0000-000000000001"
+			app_name       = "00000000-0000-0000-0000-000000000002"
+			role_name      = "` + roleName + `"
+			principal = {
+				entra_object_id = "00000000-0000-0000-0000-000000000003"
+				type            = "Group"
+			}
+		}`
+	}
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if len(permissions["value"].([]any)) != 1 {
+				return errors.New("app share was not deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("CanView"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.sales", "id", "00000000-0000-0000-0000-000000000003"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.sales", "role_name", "CanView"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.sales", "principal.type", "Group"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.sales", "principal.display_name", "Contoso Sales"),
+				),
+			},
+			{
+				Config: config("CanEdit"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.sales", "role_name", "CanEdit"),
+				),
+			},
+			{
+				ResourceName:      "powerplatform_powerapp_share.sales",
+				ImportState:       true,
+				ImportStateId:     "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002/00000000-0000-0000-0000-000000000003",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitPowerAppShareResource_Validate_Read_ShareNotFound(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	shared := false
+	httpmock.RegisterResponder("GET", powerAppUrl+"/permissions?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			if !shared {
+				return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Share/get_permissions.json").String()), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, strings.Replace(httpmock.File("tests/resource/Validate_Share/get_permissions.json").String(), `"value": [`, `"value": [
+				{
+					"name": "00000000-0000-0000-0000-000000000003",
+					"id": "/providers/Microsoft.PowerApps/scopes/admin/apps/00000000-0000-0000-0000-000000000002/permissions/00000000-0000-0000-0000-000000000003",
+					"properties": {
+						"roleName": "CanView",
+						"principal": { "id": "00000000-0000-0000-0000-000000000003", "displayName": "Jane", "type": "User" }
+					}
+				},`, 1)), nil
+		})
+
+	httpmock.RegisterResponder("POST", powerAppUrl+"/modifyPermissions?api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			shared = true
+			return httpmock.NewStringResponse(http.StatusOK, ""), nil
+		})
+
+	config := `
+	resource "powerplatform_powerapp_share" "jane" {
+		environment_id = "00000000-0000-0000-0000-000000000001"
+		app_name       = "00000000-0000-0000-0000-000000000002"
+		role_name      = "CanView"
+		principal = {
+			entra_object_id = "00000000-0000-0000-0000-000000000003"
+		}
+	}`
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.jane", "principal.type", "User"),
+					resource.TestCheckResourceAttr("powerplatform_powerapp_share.jane", "principal.display_name", "Jane"),
+				),
+			},
+			{
+				// Unsharing the app in Power Apps is detected, so the share is created again.
+				PreConfig: func() {
+					shared = false
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitPowerAppShareResource_Validate_Read_Paged(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// The share is only returned on the second page of the permissions of the app.
+	httpmock.RegisterResponder("GET", powerAppUrl+"/permissions?api-version=2023-06-01",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Validate_Share_Paged/get_permissions_page_1.json").String()))
+
+	httpmock.RegisterResponder("GET", powerAppUrl+"/permissions?api-version=2023-06-01&%24skiptoken=page2",
+		httpmock.NewStringResponder(http.StatusOK, httpmock.File("tests/resource/Validate_Share_Paged/get_permissions_page_2.json").String()))
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				ResourceName: "powerplatform_powerapp_share.jane",
+				Config: `
+				resource "powerplatform_powerapp_share" "jane" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					app_name       = "00000000-0000-0000-0000-000000000002"
+					role_name      = "CanView"
+					principal = {
+						entra_object_id = "00000000-0000-0000-0000-000000000003"
+					}
+				}`,
+				ImportState:   true,
+				ImportStateId: "00000000-0000-0000-0000-000000000001/00000000-0000-0000-0000-000000000002/00000000-0000-0000-0000-000000000003",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return errors.New("expected one imported share")
+					}
+					if states[0].Attributes["principal.display_name"] != "Jane" {
+						return errors.New("expected the share of Jane to be read from the second page")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -85,8 +85,7 @@ func (client *client) GetPublishers(ctx context.Context, environmentId string) (
 
 	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/publishers", nil)
 
-	publishers := publishersDto{}
-	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden}, &publishers)
+	publishers, resp, err := api.ExecuteForAllPages[publisherDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +93,7 @@ func (client *client) GetPublishers(ctx context.Context, environmentId string) (
 		return nil, err
 	}
 
-	return publishers.Value, nil
+	return publishers, nil
 }
 
 func (client *client) GetPublisherByUniqueName(ctx context.Context, environmentId, uniqueName string) (*publisherDto, error) {
@@ -107,8 +106,7 @@ func (client *client) GetPublisherByUniqueName(ctx context.Context, environmentI
 	values.Add("$filter", fmt.Sprintf("uniquename eq '%s'", escapeODataString(uniqueName)))
 	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/publishers", values)
 
-	publishers := publishersDto{}
-	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &publishers)
+	publishers, resp, err := api.ExecuteForAllPages[publisherDto](ctx, client.Api, nil, apiUrl, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -119,11 +117,11 @@ func (client *client) GetPublisherByUniqueName(ctx context.Context, environmentI
 		return nil, customerrors.WrapIntoProviderError(err, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("publisher '%s' not found", uniqueName))
 	}
 
-	if len(publishers.Value) == 0 {
+	if len(publishers) == 0 {
 		return nil, customerrors.WrapIntoProviderError(nil, customerrors.ErrorCode(constants.ERROR_OBJECT_NOT_FOUND), fmt.Sprintf("publisher '%s' not found", uniqueName))
 	}
 
-	return &publishers.Value[0], nil
+	return &publishers[0], nil
 }
 
 func (client *client) UpdatePublisher(ctx context.Context, environmentId, publisherId string, model *ResourceModel) (*publisherDto, error) {
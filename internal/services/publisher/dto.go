@@ -58,7 +58,3 @@ type publisherDto struct {
 	Address2UpsZone            string   `json:"address2_upszone"`
 	Address2UtcOffset          *int64   `json:"address2_utcoffset"`
 }
-
-type publishersDto struct {
-	Value []publisherDto `json:"value"`
-}
@@ -11,8 +11,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/microsoft/terraform-provider-power-platform/internal/api"
 	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
 	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
@@ -132,8 +135,7 @@ func (client *Client) listSolutions(ctx context.Context, environmentId, filter s
 	values.Add("$orderby", "createdon desc")
 	apiUrl.RawQuery = values.Encode()
 
-	solutionArray := solutionArrayDto{}
-	resp, err := client.Api.Execute(ctx, nil, "GET", apiUrl.String(), nil, nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, &solutionArray)
+	solutions, resp, err := api.ExecuteForAllPages[SolutionDto](ctx, client.Api, nil, apiUrl.String(), nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -144,16 +146,119 @@ func (client *Client) listSolutions(ctx context.Context, environmentId, filter s
 		return nil, err
 	}
 
-	for inx := range solutionArray.Value {
-		solutionArray.Value[inx].EnvironmentId = environmentId
+	for inx := range solutions {
+		solutions[inx].EnvironmentId = environmentId
 	}
 
-	solutions := make([]SolutionDto, 0)
-	solutions = append(solutions, solutionArray.Value...)
-
 	return solutions, nil
 }
 
+func (client *Client) GetSolutionComponents(ctx context.Context, environmentId, solutionId string) ([]solutionComponentDto, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	components, err := client.listSolutionComponents(ctx, environmentHost, fmt.Sprintf("_solutionid_value eq %s", solutionId))
+	if err != nil {
+		return nil, err
+	}
+
+	customComponentTypes := make([]int64, 0)
+	for _, component := range components {
+		if component.ComponentType >= CUSTOM_COMPONENT_TYPE_THRESHOLD && !slices.Contains(customComponentTypes, component.ComponentType) {
+			customComponentTypes = append(customComponentTypes, component.ComponentType)
+		}
+	}
+
+	tableLogicalNames, err := client.getTableLogicalNamesByObjectTypeCode(ctx, environmentHost, customComponentTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	for inx := range components {
+		components[inx].ComponentTypeName = resolveComponentTypeName(components[inx].ComponentType, tableLogicalNames)
+	}
+
+	return components, nil
+}
+
+// GetSolutionRootComponents returns the components that were added to the solution directly, skipping subcomponents
+// that are only part of the solution because their root component is.
+func (client *Client) GetSolutionRootComponents(ctx context.Context, environmentId, solutionId string) ([]solutionComponentDto, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.listSolutionComponents(ctx, environmentHost, fmt.Sprintf("_solutionid_value eq %s and rootsolutioncomponentid eq null", solutionId))
+}
+
+func (client *Client) listSolutionComponents(ctx context.Context, environmentHost, filter string) ([]solutionComponentDto, error) {
+	values := url.Values{}
+	values.Add("$select", "solutioncomponentid,_solutionid_value,objectid,componenttype,rootcomponentbehavior,rootsolutioncomponentid,ismetadata,createdon,modifiedon")
+	values.Add("$filter", filter)
+	values.Add("$orderby", "componenttype asc")
+
+	components, resp, err := api.ExecuteForAllPages[solutionComponentDto](ctx, client.Api, nil, helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/solutioncomponents", values), nil, []int{http.StatusOK, http.StatusForbidden, http.StatusNotFound}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleNotFoundResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+// getTableLogicalNamesByObjectTypeCode resolves component types of solution aware tables, which are the object type codes
+// of those tables, to the logical names of the tables.
+func (client *Client) getTableLogicalNamesByObjectTypeCode(ctx context.Context, environmentHost string, componentTypes []int64) (map[int64]string, error) {
+	names := make(map[int64]string)
+	if len(componentTypes) == 0 {
+		return names, nil
+	}
+
+	filters := make([]string, 0, len(componentTypes))
+	for _, componentType := range componentTypes {
+		filters = append(filters, fmt.Sprintf("ObjectTypeCode eq %d", componentType))
+	}
+
+	values := url.Values{}
+	values.Add("$select", "LogicalName,ObjectTypeCode")
+	values.Add("$filter", strings.Join(filters, " or "))
+
+	entityDefinitions := entityDefinitionObjectTypeCodeArrayDto{}
+	resp, err := client.Api.Execute(ctx, nil, "GET", helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/EntityDefinitions", values), nil, nil, []int{http.StatusOK, http.StatusForbidden}, &entityDefinitions)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	for _, entityDefinition := range entityDefinitions.Value {
+		names[entityDefinition.ObjectTypeCode] = entityDefinition.LogicalName
+	}
+	return names, nil
+}
+
+func resolveComponentTypeName(componentType int64, tableLogicalNames map[int64]string) string {
+	if name, ok := componentTypeNames[componentType]; ok {
+		return name
+	}
+	if logicalName, ok := tableLogicalNames[componentType]; ok {
+		if name, ok := customComponentTypeNames[logicalName]; ok {
+			return name
+		}
+		return logicalName
+	}
+	return fmt.Sprintf("Unknown (%d)", componentType)
+}
+
 func (client *Client) CreateSolution(ctx context.Context, environmentId string, content []byte, settings []byte) (*SolutionDto, error) {
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
 	if err != nil {
@@ -333,10 +438,24 @@ func (client *Client) validateSolutionImportResult(ctx context.Context, environm
 }
 
 func (client *Client) DeleteSolution(ctx context.Context, environmentId, solutionId string) error {
+	solution, err := client.GetSolutionById(ctx, environmentId, solutionId)
+	if err != nil {
+		return err
+	}
+
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
 	if err != nil {
 		return err
 	}
+
+	// Uninstalling an unmanaged solution only deletes the solution record and leaves its components in place,
+	// so other solutions depending on those components don't block it.
+	if solution.IsManaged {
+		if err := client.waitForDependentSolutions(ctx, environmentId, environmentHost, solution); err != nil {
+			return err
+		}
+	}
+
 	apiUrl := &url.URL{
 		Scheme: constants.HTTPS,
 		Host:   environmentHost,
@@ -353,6 +472,241 @@ func (client *Client) DeleteSolution(ctx context.Context, environmentId, solutio
 	return nil
 }
 
+// waitForDependentSolutions returns once no component of another solution depends on the managed solution anymore.
+// While a dependent solution is being uninstalled, for example because it is destroyed in the same apply, the check
+// is repeated up to a maximum number of attempts. Otherwise the blocking solutions and components are returned as an
+// error after a short grace period.
+func (client *Client) waitForDependentSolutions(ctx context.Context, environmentId, environmentHost string, solution *SolutionDto) error {
+	for attempt := 0; ; attempt++ {
+		dependencies, err := client.RetrieveDependenciesForUninstall(ctx, environmentHost, solution.Name)
+		if err != nil {
+			return err
+		}
+
+		blockingDependencies := make([]dependencyDto, 0, len(dependencies))
+		blockingSolutionIds := make([]string, 0)
+		for _, dependency := range dependencies {
+			solutionId := dependency.DependentComponentBaseSolutionId
+			if solutionId == "" || strings.EqualFold(solutionId, solution.Id) {
+				continue
+			}
+			blockingDependencies = append(blockingDependencies, dependency)
+			if !slices.Contains(blockingSolutionIds, solutionId) {
+				blockingSolutionIds = append(blockingSolutionIds, solutionId)
+			}
+		}
+		if len(blockingDependencies) == 0 {
+			return nil
+		}
+
+		blockingSolutions, err := client.getSolutionsById(ctx, environmentId, blockingSolutionIds)
+		if err != nil {
+			return err
+		}
+
+		uninstallsInProgress, err := client.getSolutionUninstallsInProgress(ctx, environmentHost)
+		if err != nil {
+			return err
+		}
+
+		beingUninstalled := false
+		for _, blockingSolution := range blockingSolutions {
+			if slices.Contains(uninstallsInProgress, blockingSolution.Name) {
+				beingUninstalled = true
+				break
+			}
+		}
+
+		if (!beingUninstalled && attempt >= constants.SOLUTION_UNINSTALL_DEPENDENCY_GRACE_RETRY_COUNT) || attempt >= constants.SOLUTION_UNINSTALL_DEPENDENCY_MAX_RETRY_COUNT {
+			return client.newSolutionDependencyError(ctx, environmentHost, solution, blockingDependencies, blockingSolutions)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Solution '%s' is required by %d other solution(s), waiting before uninstalling it", solution.Name, len(blockingSolutionIds)))
+		if err := client.Api.SleepWithContext(ctx, constants.SOLUTION_UNINSTALL_DEPENDENCY_POLL_INTERVAL); err != nil {
+			return err
+		}
+	}
+}
+
+func (client *Client) RetrieveDependenciesForUninstall(ctx context.Context, environmentHost, solutionUniqueName string) ([]dependencyDto, error) {
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/v9.2/RetrieveDependenciesForUninstall(SolutionUniqueName='%s')", strings.ReplaceAll(solutionUniqueName, "'", "''")), nil)
+
+	dependencies := dependencyArrayDto{}
+	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, apiUrl, nil, nil, []int{http.StatusOK, http.StatusForbidden}, &dependencies)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+	return dependencies.Value, nil
+}
+
+func (client *Client) getSolutionsById(ctx context.Context, environmentId string, solutionIds []string) ([]SolutionDto, error) {
+	filters := make([]string, 0, len(solutionIds))
+	for _, solutionId := range solutionIds {
+		filters = append(filters, fmt.Sprintf("solutionid eq %s", solutionId))
+	}
+	return client.listSolutions(ctx, environmentId, fmt.Sprintf("(%s)", strings.Join(filters, " or ")))
+}
+
+// getSolutionUninstallsInProgress returns the unique names of the solutions that are being uninstalled, as recorded in the solution history.
+func (client *Client) getSolutionUninstallsInProgress(ctx context.Context, environmentHost string) ([]string, error) {
+	values := url.Values{}
+	values.Add("$select", "msdyn_name")
+	values.Add("$filter", fmt.Sprintf("msdyn_operation eq %d and msdyn_status eq %d", SOLUTION_HISTORY_OPERATION_UNINSTALL, SOLUTION_HISTORY_STATUS_STARTED))
+
+	history := solutionHistoryArrayDto{}
+	resp, err := client.Api.Execute(ctx, nil, http.MethodGet, helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/msdyn_solutionhistories", values), nil, nil, []int{http.StatusOK, http.StatusForbidden}, &history)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(history.Value))
+	for _, entry := range history.Value {
+		names = append(names, entry.Name)
+	}
+	return names, nil
+}
+
+func (client *Client) newSolutionDependencyError(ctx context.Context, environmentHost string, solution *SolutionDto, dependencies []dependencyDto, blockingSolutions []SolutionDto) error {
+	customComponentTypes := make([]int64, 0)
+	for _, dependency := range dependencies {
+		for _, componentType := range []int64{dependency.DependentComponentType, dependency.RequiredComponentType} {
+			if componentType >= CUSTOM_COMPONENT_TYPE_THRESHOLD && !slices.Contains(customComponentTypes, componentType) {
+				customComponentTypes = append(customComponentTypes, componentType)
+			}
+		}
+	}
+	tableLogicalNames, err := client.getTableLogicalNamesByObjectTypeCode(ctx, environmentHost, customComponentTypes)
+	if err != nil {
+		return err
+	}
+
+	solutionNames := make(map[string]string, len(blockingSolutions))
+	for _, blockingSolution := range blockingSolutions {
+		solutionNames[strings.ToLower(blockingSolution.Id)] = blockingSolution.Name
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "solution '%s' can't be uninstalled because components of other solutions depend on it. Uninstall these solutions first or remove the dependencies:", solution.Name)
+	for _, dependency := range dependencies {
+		solutionName, ok := solutionNames[strings.ToLower(dependency.DependentComponentBaseSolutionId)]
+		if !ok {
+			solutionName = dependency.DependentComponentBaseSolutionId
+		}
+		fmt.Fprintf(&message, "\n  - solution '%s': %s '%s' requires %s '%s'",
+			solutionName,
+			resolveComponentTypeName(dependency.DependentComponentType, tableLogicalNames), dependency.DependentComponentObjectId,
+			resolveComponentTypeName(dependency.RequiredComponentType, tableLogicalNames), dependency.RequiredComponentObjectId)
+	}
+	return errors.New(message.String())
+}
+
+func (client *Client) CreateUnmanagedSolution(ctx context.Context, environmentId string, solution unmanagedSolutionDto) (*SolutionDto, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/solutions", nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, nil, solution, []int{http.StatusCreated, http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	solutionId, err := getSolutionIdFromResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetSolutionById(ctx, environmentId, solutionId)
+}
+
+func (client *Client) UpdateUnmanagedSolution(ctx context.Context, environmentId, solutionId string, solution unmanagedSolutionDto) (*SolutionDto, error) {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, fmt.Sprintf("/api/data/v9.2/solutions(%s)", solutionId), nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPatch, apiUrl, nil, solution, []int{http.StatusNoContent, http.StatusForbidden}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Api.HandleForbiddenResponse(resp); err != nil {
+		return nil, err
+	}
+
+	return client.GetSolutionById(ctx, environmentId, solutionId)
+}
+
+func (client *Client) AddSolutionComponent(ctx context.Context, environmentId, solutionUniqueName string, component solutionComponentMembershipDto) error {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	request := addSolutionComponentDto{
+		ComponentId:               component.ObjectId,
+		ComponentType:             component.ComponentType,
+		SolutionUniqueName:        solutionUniqueName,
+		AddRequiredComponents:     false,
+		DoNotIncludeSubcomponents: !component.IncludeSubcomponents,
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/AddSolutionComponent", nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, nil, request, []int{http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+func (client *Client) RemoveSolutionComponent(ctx context.Context, environmentId, solutionUniqueName string, component solutionComponentMembershipDto) error {
+	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
+	if err != nil {
+		return err
+	}
+
+	request := removeSolutionComponentDto{
+		ComponentId:        component.ObjectId,
+		ComponentType:      component.ComponentType,
+		SolutionUniqueName: solutionUniqueName,
+	}
+
+	apiUrl := helpers.BuildDataverseApiUrl(environmentHost, "/api/data/v9.2/RemoveSolutionComponent", nil)
+	resp, err := client.Api.Execute(ctx, nil, http.MethodPost, apiUrl, nil, request, []int{http.StatusOK, http.StatusForbidden}, nil)
+	if err != nil {
+		return err
+	}
+	return client.Api.HandleForbiddenResponse(resp)
+}
+
+func getSolutionIdFromResponse(resp *api.Response) (string, error) {
+	entityId := resp.GetHeader(constants.HEADER_ODATA_ENTITY_ID)
+	if entityId == "" {
+		return "", errors.New("no solution id returned from the API")
+	}
+
+	parsed, err := url.Parse(entityId)
+	if err != nil {
+		return "", err
+	}
+
+	solutionId, found := strings.CutPrefix(path.Base(parsed.Path), "solutions(")
+	if !found {
+		return "", errors.New("no solution id returned from the API")
+	}
+	return strings.TrimSuffix(solutionId, ")"), nil
+}
+
 func (client *Client) GetTableData(ctx context.Context, environmentId, tableName, odataQuery string, responseObj any) error {
 	environmentHost, err := client.GetEnvironmentHostById(ctx, environmentId)
 	if err != nil {
@@ -0,0 +1,174 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package solution
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/config"
+	"github.com/microsoft/terraform-provider-power-platform/internal/constants"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseSolutionId      = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	testDependentSolutionId = "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+)
+
+func registerSolutionDependencyMocks() {
+	httpmock.RegisterNoResponder(func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("no responder found for %s %s", req.Method, req.URL)
+	})
+	httpmock.RegisterResponder("GET", "https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{
+  "id":"00000000-0000-0000-0000-000000000001",
+  "name":"env",
+  "properties":{"linkedEnvironmentMetadata":{"instanceURL":"https://example.crm.dynamics.com/"}}
+}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=solutionid+eq+"+testBaseSolutionId,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"solutionid":"`+testBaseSolutionId+`","uniquename":"ContosoBase","friendlyname":"Contoso Base","ismanaged":true,"version":"1.0.0.0"}]}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=%28solutionid+eq+"+testDependentSolutionId+"%29&%24orderby=createdon+desc",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"solutionid":"`+testDependentSolutionId+`","uniquename":"ContosoSales","friendlyname":"Contoso Sales","ismanaged":true,"version":"1.0.0.0"}]}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/EntityDefinitions?%24filter=ObjectTypeCode+eq+10132&%24select=LogicalName%2CObjectTypeCode",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"LogicalName":"connectionreference","ObjectTypeCode":10132}]}`), nil
+		})
+}
+
+func newTestSolutionClient() Client {
+	cfg := &config.ProviderConfig{
+		TestMode: true,
+		Urls:     config.ProviderConfigUrls{BapiUrl: "api.bap.microsoft.com"},
+	}
+	return NewSolutionClient(api.NewApiClientBase(cfg, api.NewAuthBase(cfg)))
+}
+
+const testBlockingDependencies = `{"value":[
+  {"dependencyid":"11111111-1111-1111-1111-111111111111","dependentcomponentobjectid":"cccccccc-cccc-cccc-cccc-cccccccccccc","dependentcomponenttype":29,"_dependentcomponentbasesolutionid_value":"` + testDependentSolutionId + `","requiredcomponentobjectid":"dddddddd-dddd-dddd-dddd-dddddddddddd","requiredcomponenttype":10132,"_requiredcomponentbasesolutionid_value":"` + testBaseSolutionId + `"},
+  {"dependencyid":"22222222-2222-2222-2222-222222222222","dependentcomponentobjectid":"eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee","dependentcomponenttype":2,"_dependentcomponentbasesolutionid_value":"` + testBaseSolutionId + `","requiredcomponentobjectid":"ffffffff-ffff-ffff-ffff-ffffffffffff","requiredcomponenttype":1,"_requiredcomponentbasesolutionid_value":"` + testBaseSolutionId + `"}
+]}`
+
+func TestUnitDeleteSolution_WaitsForDependentSolutionBeingUninstalled(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerSolutionDependencyMocks()
+
+	dependencyChecks := 0
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=%27ContosoBase%27%29",
+		func(req *http.Request) (*http.Response, error) {
+			dependencyChecks++
+			if dependencyChecks <= 10 {
+				return httpmock.NewStringResponse(http.StatusOK, testBlockingDependencies), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[]}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/msdyn_solutionhistories?%24filter=msdyn_operation+eq+1+and+msdyn_status+eq+0&%24select=msdyn_name",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"msdyn_name":"ContosoSales"}]}`), nil
+		})
+	deleted := false
+	httpmock.RegisterResponder("DELETE", "https://example.crm.dynamics.com/api/data/v9.2/solutions%28"+testBaseSolutionId+"%29",
+		func(req *http.Request) (*http.Response, error) {
+			deleted = true
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	client := newTestSolutionClient()
+	err := client.DeleteSolution(context.Background(), "00000000-0000-0000-0000-000000000001", testBaseSolutionId)
+
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.Equal(t, 11, dependencyChecks)
+}
+
+func TestUnitDeleteSolution_ReportsBlockingSolutionsAndComponents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerSolutionDependencyMocks()
+
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=%27ContosoBase%27%29",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, testBlockingDependencies), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/msdyn_solutionhistories?%24filter=msdyn_operation+eq+1+and+msdyn_status+eq+0&%24select=msdyn_name",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[]}`), nil
+		})
+
+	client := newTestSolutionClient()
+	err := client.DeleteSolution(context.Background(), "00000000-0000-0000-0000-000000000001", testBaseSolutionId)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "solution 'ContosoBase' can't be uninstalled")
+	require.Contains(t, err.Error(), "solution 'ContosoSales': Workflow 'cccccccc-cccc-cccc-cccc-cccccccccccc' requires Connection Reference 'dddddddd-dddd-dddd-dddd-dddddddddddd'")
+	require.NotContains(t, err.Error(), "eeeeeeee-eeee-eeee-eeee-eeeeeeeeeeee")
+	require.Equal(t, 0, httpmock.GetCallCountInfo()["DELETE https://example.crm.dynamics.com/api/data/v9.2/solutions%28"+testBaseSolutionId+"%29"])
+}
+
+func TestUnitDeleteSolution_StopsWaitingForUninstallThatNeverCompletes(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerSolutionDependencyMocks()
+
+	dependencyChecks := 0
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=%27ContosoBase%27%29",
+		func(req *http.Request) (*http.Response, error) {
+			dependencyChecks++
+			return httpmock.NewStringResponse(http.StatusOK, testBlockingDependencies), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/msdyn_solutionhistories?%24filter=msdyn_operation+eq+1+and+msdyn_status+eq+0&%24select=msdyn_name",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"msdyn_name":"ContosoSales"}]}`), nil
+		})
+
+	client := newTestSolutionClient()
+	err := client.DeleteSolution(context.Background(), "00000000-0000-0000-0000-000000000001", testBaseSolutionId)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "solution 'ContosoBase' can't be uninstalled")
+	require.Equal(t, constants.SOLUTION_UNINSTALL_DEPENDENCY_MAX_RETRY_COUNT+1, dependencyChecks)
+}
+
+func TestUnitDeleteSolution_UnmanagedSolutionSkipsDependencyCheck(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerSolutionDependencyMocks()
+
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=solutionid+eq+"+testBaseSolutionId,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[{"solutionid":"`+testBaseSolutionId+`","uniquename":"ContosoBase","friendlyname":"Contoso Base","ismanaged":false,"version":"1.0.0.0"}]}`), nil
+		})
+	httpmock.RegisterResponder("GET", "https://example.crm.dynamics.com/api/data/v9.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=%27ContosoBase%27%29",
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, testBlockingDependencies), nil
+		})
+	deleted := false
+	httpmock.RegisterResponder("DELETE", "https://example.crm.dynamics.com/api/data/v9.2/solutions%28"+testBaseSolutionId+"%29",
+		func(req *http.Request) (*http.Response, error) {
+			deleted = true
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	client := newTestSolutionClient()
+	err := client.DeleteSolution(context.Background(), "00000000-0000-0000-0000-000000000001", testBaseSolutionId)
+
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.Equal(t, 0, httpmock.GetCallCountInfo()["GET https://example.crm.dynamics.com/api/data/v9.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=%27ContosoBase%27%29"])
+}
@@ -0,0 +1,182 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package solution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var (
+	_ datasource.DataSource              = &ComponentsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ComponentsDataSource{}
+)
+
+func NewSolutionComponentsDataSource() datasource.DataSource {
+	return &ComponentsDataSource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "solution_components",
+		},
+	}
+}
+
+func (d *ComponentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	// update our own internal storage of the provider type name.
+	d.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	// Set the type name for the resource to providername_resourcename.
+	resp.TypeName = d.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (d *ComponentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the list of components in a Solution, such as tables, cloud flows, apps, plug-in assemblies, web resources, connection references and environment variables. Components are read from the [`solutioncomponent`](https://learn.microsoft.com/power-apps/developer/data-platform/reference/entities/solutioncomponent) table.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Read: true,
+			}),
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Unique environment id (guid)",
+				Required:            true,
+			},
+			"solution_name": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the solution",
+				Required:            true,
+			},
+			"solution_id": schema.StringAttribute{
+				MarkdownDescription: "Solution id",
+				Computed:            true,
+			},
+			"is_managed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the solution is managed",
+				Computed:            true,
+			},
+			"components": schema.ListNestedAttribute{
+				MarkdownDescription: "List of Solution components",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Solution component id",
+							Computed:            true,
+						},
+						"object_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the object that the component represents, for example the id of the table, workflow or web resource",
+							Computed:            true,
+						},
+						"component_type": schema.Int64Attribute{
+							MarkdownDescription: "Component type value. Values of `10000` and above are the object type codes of solution aware tables",
+							Computed:            true,
+						},
+						"component_type_name": schema.StringAttribute{
+							MarkdownDescription: "Component type name, for example `Entity`, `Workflow`, `Canvas App`, `Plugin Assembly`, `Web Resource`, `Connection Reference` or `Environment Variable Definition`",
+							Computed:            true,
+						},
+						"root_component_behavior": schema.StringAttribute{
+							MarkdownDescription: "Behavior of a root component. One of `IncludeSubcomponents`, `DoNotIncludeSubcomponents` or `IncludeAsShellOnly`",
+							Computed:            true,
+						},
+						"root_solution_component_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the root solution component when the component is a subcomponent",
+							Computed:            true,
+						},
+						"is_metadata": schema.BoolAttribute{
+							MarkdownDescription: "Whether the component is a metadata component",
+							Computed:            true,
+						},
+						"created_time": schema.StringAttribute{
+							MarkdownDescription: "Created time",
+							Computed:            true,
+						},
+						"modified_time": schema.StringAttribute{
+							MarkdownDescription: "Modified time",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ComponentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.SolutionClient = NewSolutionClient(client.Api)
+}
+
+func (d *ComponentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, d.TypeInfo, req)
+	defer exitContext()
+	var state ComponentsListDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dvExits, err := d.SolutionClient.DataverseExists(ctx, state.EnvironmentId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when checking if Dataverse exists in environment '%s'", state.EnvironmentId.ValueString()), err.Error())
+		return
+	}
+
+	if !dvExits {
+		resp.Diagnostics.AddError(fmt.Sprintf("No Dataverse exists in environment '%s'", state.EnvironmentId.ValueString()), "")
+		return
+	}
+
+	solution, err := d.SolutionClient.GetSolutionUniqueName(ctx, state.EnvironmentId.ValueString(), state.SolutionName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", d.FullTypeName()), err.Error())
+		return
+	}
+
+	components, err := d.SolutionClient.GetSolutionComponents(ctx, state.EnvironmentId.ValueString(), solution.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", d.FullTypeName()), err.Error())
+		return
+	}
+
+	state.SolutionId = types.StringValue(solution.Id)
+	state.IsManaged = types.BoolValue(solution.IsManaged)
+	state.Components = make([]ComponentsDataSourceModel, 0, len(components))
+	for _, component := range components {
+		state.Components = append(state.Components, convertFromSolutionComponentDto(component))
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
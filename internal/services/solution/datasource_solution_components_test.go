@@ -0,0 +1,151 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package solution_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitSolutionComponentsDataSource_Validate_Read(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Components_Read/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=uniquename+eq+%27ContosoCore%27`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Components_Read/get_solution.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutioncomponents?%24filter=_solutionid_value+eq+86928ed8-df37-4ce2-add5-47030a833bff&%24orderby=componenttype+asc&%24select=solutioncomponentid%2C_solutionid_value%2Cobjectid%2Ccomponenttype%2Crootcomponentbehavior%2Crootsolutioncomponentid%2Cismetadata%2Ccreatedon%2Cmodifiedon`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Components_Read/get_solution_components.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/EntityDefinitions?%24filter=ObjectTypeCode+eq+10132&%24select=LogicalName%2CObjectTypeCode`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Components_Read/get_entity_definitions.json").String()), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_solution_components" "core" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					solution_name  = "ContosoCore"
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "solution_id", "86928ed8-df37-4ce2-add5-47030a833bff"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "is_managed", "false"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.#", "6"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.id", "1a6e4a5e-5b3e-ef11-8409-000d3a4a5a01"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.object_id", "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.component_type", "1"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.component_type_name", "Entity"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.root_component_behavior", "IncludeSubcomponents"),
+					resource.TestCheckNoResourceAttr("data.powerplatform_solution_components.core", "components.0.root_solution_component_id"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.is_metadata", "true"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.created_time", "2024-07-10T09:12:31Z"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.0.modified_time", "2024-07-10T09:12:31Z"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.1.component_type_name", "Attribute"),
+					resource.TestCheckNoResourceAttr("data.powerplatform_solution_components.core", "components.1.root_component_behavior"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.1.root_solution_component_id", "1a6e4a5e-5b3e-ef11-8409-000d3a4a5a01"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.2.component_type_name", "Workflow"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.3.component_type_name", "Web Resource"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.3.root_component_behavior", "DoNotIncludeSubcomponents"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.4.component_type_name", "Environment Variable Definition"),
+
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.5.component_type", "10132"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.5.component_type_name", "Connection Reference"),
+					resource.TestCheckResourceAttr("data.powerplatform_solution_components.core", "components.5.root_component_behavior", "IncludeAsShellOnly"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitSolutionComponentsDataSource_Validate_Solution_Not_Found(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/datasource/Validate_Components_Read/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=uniquename+eq+%27ContosoCore%27`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[]}`), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				data "powerplatform_solution_components" "core" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					solution_name  = "ContosoCore"
+				}`,
+				ExpectError: regexp.MustCompile(`solution with unique name 'ContosoCore' not found`),
+			},
+		},
+	})
+}
+
+func TestAccSolutionComponentsDataSource_Validate_Read(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "development" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+						language_code    = "1033"
+						currency_code    = "USD"
+						security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				data "powerplatform_solution_components" "default" {
+					environment_id = powerplatform_environment.development.id
+					solution_name  = "Default"
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.powerplatform_solution_components.default", "solution_id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestMatchResourceAttr("data.powerplatform_solution_components.default", "components.0.id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestMatchResourceAttr("data.powerplatform_solution_components.default", "components.0.object_id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestMatchResourceAttr("data.powerplatform_solution_components.default", "components.0.component_type_name", regexp.MustCompile(helpers.StringRegex)),
+					resource.TestMatchResourceAttr("data.powerplatform_solution_components.default", "components.0.created_time", regexp.MustCompile(helpers.TimeRegex)),
+				),
+			},
+		},
+	})
+}
@@ -29,6 +29,8 @@ type SolutionDto struct {
 	Version       string `json:"version"`
 	ModifiedTime  string `json:"modifiedon"`
 	InstallTime   string `json:"installedon"`
+	Description   string `json:"description"`
+	PublisherId   string `json:"_publisherid_value"`
 }
 
 type solutionArrayDto struct {
@@ -141,3 +143,75 @@ type environmentIdPropertiesDto struct {
 type linkedEnvironmentIdMetadataDto struct {
 	InstanceURL string
 }
+
+type solutionComponentDto struct {
+	Id                      string `json:"solutioncomponentid"`
+	SolutionId              string `json:"_solutionid_value"`
+	ObjectId                string `json:"objectid"`
+	ComponentType           int64  `json:"componenttype"`
+	RootComponentBehavior   *int64 `json:"rootcomponentbehavior"`
+	RootSolutionComponentId string `json:"rootsolutioncomponentid"`
+	IsMetadata              bool   `json:"ismetadata"`
+	CreatedTime             string `json:"createdon"`
+	ModifiedTime            string `json:"modifiedon"`
+	ComponentTypeName       string `json:"-"`
+}
+
+type entityDefinitionObjectTypeCodeDto struct {
+	LogicalName    string `json:"LogicalName"`
+	ObjectTypeCode int64  `json:"ObjectTypeCode"`
+}
+
+type entityDefinitionObjectTypeCodeArrayDto struct {
+	Value []entityDefinitionObjectTypeCodeDto `json:"value"`
+}
+
+type unmanagedSolutionDto struct {
+	UniqueName      string  `json:"uniquename,omitempty"`
+	FriendlyName    string  `json:"friendlyname"`
+	Version         string  `json:"version"`
+	Description     *string `json:"description"`
+	PublisherIdBind string  `json:"publisherid@odata.bind"`
+}
+
+type solutionComponentMembershipDto struct {
+	ObjectId             string
+	ComponentType        int64
+	IncludeSubcomponents bool
+}
+
+type addSolutionComponentDto struct {
+	ComponentId               string `json:"ComponentId"`
+	ComponentType             int64  `json:"ComponentType"`
+	SolutionUniqueName        string `json:"SolutionUniqueName"`
+	AddRequiredComponents     bool   `json:"AddRequiredComponents"`
+	DoNotIncludeSubcomponents bool   `json:"DoNotIncludeSubcomponents"`
+}
+
+type removeSolutionComponentDto struct {
+	ComponentId        string `json:"ComponentId"`
+	ComponentType      int64  `json:"ComponentType"`
+	SolutionUniqueName string `json:"SolutionUniqueName"`
+}
+
+type dependencyDto struct {
+	DependencyId                     string `json:"dependencyid"`
+	DependentComponentObjectId       string `json:"dependentcomponentobjectid"`
+	DependentComponentType           int64  `json:"dependentcomponenttype"`
+	DependentComponentBaseSolutionId string `json:"_dependentcomponentbasesolutionid_value"`
+	RequiredComponentObjectId        string `json:"requiredcomponentobjectid"`
+	RequiredComponentType            int64  `json:"requiredcomponenttype"`
+	RequiredComponentBaseSolutionId  string `json:"_requiredcomponentbasesolutionid_value"`
+}
+
+type dependencyArrayDto struct {
+	Value []dependencyDto `json:"value"`
+}
+
+type solutionHistoryDto struct {
+	Name string `json:"msdyn_name"`
+}
+
+type solutionHistoryArrayDto struct {
+	Value []solutionHistoryDto `json:"value"`
+}
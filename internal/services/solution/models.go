@@ -63,3 +63,76 @@ type ResourceModel struct {
 	IsManaged            types.Bool     `tfsdk:"is_managed"`
 	DisplayName          types.String   `tfsdk:"display_name"`
 }
+
+type ComponentsDataSource struct {
+	helpers.TypeInfo
+	SolutionClient Client
+}
+
+type ComponentsListDataSourceModel struct {
+	Timeouts      timeouts.Value              `tfsdk:"timeouts"`
+	EnvironmentId types.String                `tfsdk:"environment_id"`
+	SolutionName  types.String                `tfsdk:"solution_name"`
+	SolutionId    types.String                `tfsdk:"solution_id"`
+	IsManaged     types.Bool                  `tfsdk:"is_managed"`
+	Components    []ComponentsDataSourceModel `tfsdk:"components"`
+}
+
+type ComponentsDataSourceModel struct {
+	Id                      types.String `tfsdk:"id"`
+	ObjectId                types.String `tfsdk:"object_id"`
+	ComponentType           types.Int64  `tfsdk:"component_type"`
+	ComponentTypeName       types.String `tfsdk:"component_type_name"`
+	RootComponentBehavior   types.String `tfsdk:"root_component_behavior"`
+	RootSolutionComponentId types.String `tfsdk:"root_solution_component_id"`
+	IsMetadata              types.Bool   `tfsdk:"is_metadata"`
+	CreatedTime             types.String `tfsdk:"created_time"`
+	ModifiedTime            types.String `tfsdk:"modified_time"`
+}
+
+func convertFromSolutionComponentDto(componentDto solutionComponentDto) ComponentsDataSourceModel {
+	rootComponentBehavior := types.StringNull()
+	if componentDto.RootComponentBehavior != nil {
+		rootComponentBehavior = types.StringValue(rootComponentBehaviorNames[*componentDto.RootComponentBehavior])
+	}
+
+	rootSolutionComponentId := types.StringNull()
+	if componentDto.RootSolutionComponentId != "" {
+		rootSolutionComponentId = types.StringValue(componentDto.RootSolutionComponentId)
+	}
+
+	return ComponentsDataSourceModel{
+		Id:                      types.StringValue(componentDto.Id),
+		ObjectId:                types.StringValue(componentDto.ObjectId),
+		ComponentType:           types.Int64Value(componentDto.ComponentType),
+		ComponentTypeName:       types.StringValue(componentDto.ComponentTypeName),
+		RootComponentBehavior:   rootComponentBehavior,
+		RootSolutionComponentId: rootSolutionComponentId,
+		IsMetadata:              types.BoolValue(componentDto.IsMetadata),
+		CreatedTime:             types.StringValue(componentDto.CreatedTime),
+		ModifiedTime:            types.StringValue(componentDto.ModifiedTime),
+	}
+}
+
+type UnmanagedSolutionResource struct {
+	helpers.TypeInfo
+	SolutionClient Client
+}
+
+type UnmanagedSolutionResourceModel struct {
+	Timeouts      timeouts.Value                    `tfsdk:"timeouts"`
+	Id            types.String                      `tfsdk:"id"`
+	EnvironmentId types.String                      `tfsdk:"environment_id"`
+	UniqueName    types.String                      `tfsdk:"uniquename"`
+	DisplayName   types.String                      `tfsdk:"display_name"`
+	Version       types.String                      `tfsdk:"version"`
+	PublisherId   types.String                      `tfsdk:"publisher_id"`
+	Description   types.String                      `tfsdk:"description"`
+	Components    []UnmanagedSolutionComponentModel `tfsdk:"components"`
+}
+
+type UnmanagedSolutionComponentModel struct {
+	ComponentType        types.Int64  `tfsdk:"component_type"`
+	ObjectId             types.String `tfsdk:"object_id"`
+	IncludeSubcomponents types.Bool   `tfsdk:"include_subcomponents"`
+}
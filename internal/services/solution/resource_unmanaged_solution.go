@@ -0,0 +1,436 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package solution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/microsoft/terraform-provider-power-platform/internal/api"
+	"github.com/microsoft/terraform-provider-power-platform/internal/customerrors"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+)
+
+var _ resource.Resource = &UnmanagedSolutionResource{}
+var _ resource.ResourceWithConfigure = &UnmanagedSolutionResource{}
+var _ resource.ResourceWithImportState = &UnmanagedSolutionResource{}
+
+func NewUnmanagedSolutionResource() resource.Resource {
+	return &UnmanagedSolutionResource{
+		TypeInfo: helpers.TypeInfo{
+			TypeName: "unmanaged_solution",
+		},
+	}
+}
+
+func (r *UnmanagedSolutionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.ProviderTypeName = req.ProviderTypeName
+
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.TypeName = r.FullTypeName()
+	tflog.Debug(ctx, fmt.Sprintf("METADATA: %s", resp.TypeName))
+}
+
+func (r *UnmanagedSolutionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an unmanaged Dataverse solution and the components that belong to it. Components are added with the [`AddSolutionComponent`](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/reference/addsolutioncomponent) action and removed with the [`RemoveSolutionComponent`](https://learn.microsoft.com/power-apps/developer/data-platform/webapi/reference/removesolutioncomponent) action. Deleting the resource deletes the solution, but the components themselves stay in the environment.",
+		Attributes: map[string]schema.Attribute{
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Dataverse solution id.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"environment_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the Dataverse-enabled environment containing the solution.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"uniquename": schema.StringAttribute{
+				MarkdownDescription: "Unique name of the solution.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the solution.",
+				Required:            true,
+			},
+			"version": schema.StringAttribute{
+				MarkdownDescription: "Version of the solution in the `major.minor.build.revision` format.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(helpers.VersionRegex), "version must be in the `major.minor.build.revision` format"),
+				},
+			},
+			"publisher_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the publisher that owns the solution, for example `powerplatform_publisher.example.id`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(helpers.GuidRegex), "publisher_id must be a valid GUID"),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the solution.",
+				Optional:            true,
+			},
+			"components": schema.SetNestedAttribute{
+				MarkdownDescription: "Root components of the solution. Components that are added to the solution outside of Terraform are removed on the next apply.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"component_type": schema.Int64Attribute{
+							MarkdownDescription: "Component type value, for example `1` for a table, `29` for a workflow or cloud flow, `61` for a web resource or `300` for a canvas app. See the [`componenttype` choices](https://learn.microsoft.com/power-apps/developer/data-platform/reference/entities/solutioncomponent#componenttype-choicesoptions).",
+							Required:            true,
+						},
+						"object_id": schema.StringAttribute{
+							MarkdownDescription: "Id of the object to add to the solution, for example the `MetadataId` of a table or the id of a workflow.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(regexp.MustCompile(helpers.GuidRegex), "object_id must be a valid GUID"),
+							},
+						},
+						"include_subcomponents": schema.BoolAttribute{
+							MarkdownDescription: "Whether all subcomponents, such as the columns, forms and views of a table, are included with the component. Defaults to `true`.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *UnmanagedSolutionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	if req.ProviderData == nil {
+		// ProviderData will be null when Configure is called from ValidateConfig.  It's ok.
+		return
+	}
+
+	client, ok := req.ProviderData.(*api.ProviderClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected ProviderData Type",
+			fmt.Sprintf("Expected *api.ProviderClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.SolutionClient = NewSolutionClient(client.Api)
+}
+
+func (r *UnmanagedSolutionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan UnmanagedSolutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateUnmanagedSolutionComponents(plan.Components)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	solution, err := r.SolutionClient.CreateUnmanagedSolution(ctx, plan.EnvironmentId.ValueString(), unmanagedSolutionBodyFromModel(&plan, true))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when creating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	components := plan.Components
+	state := plan
+	state.Components = nil
+	if components != nil {
+		state.Components = []UnmanagedSolutionComponentModel{}
+	}
+	setUnmanagedSolutionModelFromDto(&state, solution)
+
+	// Save the solution before adding components so that a failed component does not orphan the solution.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, component := range components {
+		if err := r.SolutionClient.AddSolutionComponent(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString(), componentMembershipFromModel(component)); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when adding component '%s' to %s", component.ObjectId.ValueString(), r.FullTypeName()), err.Error())
+			return
+		}
+		state.Components = append(state.Components, component)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	}
+}
+
+func (r *UnmanagedSolutionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state UnmanagedSolutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	solution, err := r.SolutionClient.GetSolutionById(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, customerrors.ErrObjectNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	if solution.IsManaged {
+		resp.Diagnostics.AddError(fmt.Sprintf("Solution '%s' is managed", solution.Name), fmt.Sprintf("%s can only manage unmanaged solutions.", r.FullTypeName()))
+		return
+	}
+
+	components, err := r.SolutionClient.GetSolutionRootComponents(ctx, state.EnvironmentId.ValueString(), solution.Id)
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when reading components of %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	setUnmanagedSolutionModelFromDto(&state, solution)
+	state.Components = componentModelsFromDto(components, state.Components)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *UnmanagedSolutionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var plan UnmanagedSolutionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	var state UnmanagedSolutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateUnmanagedSolutionComponents(plan.Components)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	solution, err := r.SolutionClient.UpdateUnmanagedSolution(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString(), unmanagedSolutionBodyFromModel(&plan, false))
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when updating %s", r.FullTypeName()), err.Error())
+		return
+	}
+
+	toRemove, toAdd := diffComponents(state.Components, plan.Components)
+	for _, component := range toRemove {
+		if err := r.SolutionClient.RemoveSolutionComponent(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString(), componentMembershipFromModel(component)); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when removing component '%s' from %s", component.ObjectId.ValueString(), r.FullTypeName()), err.Error())
+			return
+		}
+	}
+	for _, component := range toAdd {
+		if err := r.SolutionClient.AddSolutionComponent(ctx, state.EnvironmentId.ValueString(), state.UniqueName.ValueString(), componentMembershipFromModel(component)); err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("Client error when adding component '%s' to %s", component.ObjectId.ValueString(), r.FullTypeName()), err.Error())
+			return
+		}
+	}
+
+	plan.Id = state.Id
+	setUnmanagedSolutionModelFromDto(&plan, solution)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *UnmanagedSolutionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	var state UnmanagedSolutionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Id.IsNull() || state.EnvironmentId.IsNull() {
+		return
+	}
+
+	err := r.SolutionClient.DeleteSolution(ctx, state.EnvironmentId.ValueString(), state.Id.ValueString())
+	if err != nil && !errors.Is(err, customerrors.ErrObjectNotFound) {
+		resp.Diagnostics.AddError(fmt.Sprintf("Client error when deleting %s", r.FullTypeName()), err.Error())
+	}
+}
+
+func (r *UnmanagedSolutionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx, exitContext := helpers.EnterRequestContext(ctx, r.TypeInfo, req)
+	defer exitContext()
+
+	guidRegex := regexp.MustCompile(helpers.GuidRegex)
+	parts := strings.SplitN(req.ID, "_", 2)
+	if len(parts) != 2 || !guidRegex.MatchString(parts[0]) || !guidRegex.MatchString(parts[1]) {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected import ID in format 'environment_id_solution_id', got '%s'", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("environment_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+func unmanagedSolutionBodyFromModel(model *UnmanagedSolutionResourceModel, includeUniqueName bool) unmanagedSolutionDto {
+	body := unmanagedSolutionDto{
+		FriendlyName:    model.DisplayName.ValueString(),
+		Version:         model.Version.ValueString(),
+		PublisherIdBind: fmt.Sprintf("/publishers(%s)", model.PublisherId.ValueString()),
+	}
+	if includeUniqueName {
+		body.UniqueName = model.UniqueName.ValueString()
+	}
+	if !model.Description.IsNull() && !model.Description.IsUnknown() {
+		body.Description = model.Description.ValueStringPointer()
+	}
+	return body
+}
+
+func setUnmanagedSolutionModelFromDto(model *UnmanagedSolutionResourceModel, solution *SolutionDto) {
+	model.Id = types.StringValue(solution.Id)
+	model.UniqueName = types.StringValue(solution.Name)
+	model.DisplayName = types.StringValue(solution.DisplayName)
+	model.Version = types.StringValue(solution.Version)
+	model.PublisherId = types.StringValue(solution.PublisherId)
+	if solution.Description != "" {
+		model.Description = types.StringValue(solution.Description)
+	} else if model.Description.IsUnknown() || model.Description.ValueString() != "" {
+		// Keep an explicitly empty description, Dataverse does not distinguish it from no description.
+		model.Description = types.StringNull()
+	}
+}
+
+func componentMembershipFromModel(component UnmanagedSolutionComponentModel) solutionComponentMembershipDto {
+	return solutionComponentMembershipDto{
+		ObjectId:             component.ObjectId.ValueString(),
+		ComponentType:        component.ComponentType.ValueInt64(),
+		IncludeSubcomponents: component.IncludeSubcomponents.IsNull() || component.IncludeSubcomponents.ValueBool(),
+	}
+}
+
+func componentKey(componentType int64, objectId string) string {
+	return fmt.Sprintf("%d_%s", componentType, strings.ToLower(objectId))
+}
+
+// diffComponents returns the components that have to be removed from and added to the solution to move from the
+// current set of components to the planned one. Components whose subcomponent behavior changes are removed and added again.
+func diffComponents(current, planned []UnmanagedSolutionComponentModel) (toRemove, toAdd []UnmanagedSolutionComponentModel) {
+	currentByKey := make(map[string]UnmanagedSolutionComponentModel, len(current))
+	for _, component := range current {
+		currentByKey[componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())] = component
+	}
+	plannedByKey := make(map[string]UnmanagedSolutionComponentModel, len(planned))
+	for _, component := range planned {
+		plannedByKey[componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())] = component
+	}
+
+	for _, component := range current {
+		key := componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())
+		plannedComponent, ok := plannedByKey[key]
+		if !ok || componentMembershipFromModel(plannedComponent).IncludeSubcomponents != componentMembershipFromModel(component).IncludeSubcomponents {
+			toRemove = append(toRemove, component)
+		}
+	}
+	for _, component := range planned {
+		key := componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())
+		currentComponent, ok := currentByKey[key]
+		if !ok || componentMembershipFromModel(currentComponent).IncludeSubcomponents != componentMembershipFromModel(component).IncludeSubcomponents {
+			toAdd = append(toAdd, component)
+		}
+	}
+	return toRemove, toAdd
+}
+
+// componentModelsFromDto maps the root components of a solution to the resource model, keeping the object id casing and
+// the unset subcomponent behavior of components that are already known in state.
+func componentModelsFromDto(components []solutionComponentDto, existing []UnmanagedSolutionComponentModel) []UnmanagedSolutionComponentModel {
+	existingByKey := make(map[string]UnmanagedSolutionComponentModel, len(existing))
+	for _, component := range existing {
+		existingByKey[componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())] = component
+	}
+
+	if len(components) == 0 && existing == nil {
+		return nil
+	}
+
+	models := make([]UnmanagedSolutionComponentModel, 0, len(components))
+	for _, component := range components {
+		includeSubcomponents := component.RootComponentBehavior == nil || *component.RootComponentBehavior == 0
+		model := UnmanagedSolutionComponentModel{
+			ComponentType:        types.Int64Value(component.ComponentType),
+			ObjectId:             types.StringValue(component.ObjectId),
+			IncludeSubcomponents: types.BoolValue(includeSubcomponents),
+		}
+		if existingComponent, ok := existingByKey[componentKey(component.ComponentType, component.ObjectId)]; ok {
+			model.ObjectId = existingComponent.ObjectId
+			if existingComponent.IncludeSubcomponents.IsNull() && includeSubcomponents {
+				model.IncludeSubcomponents = types.BoolNull()
+			}
+		}
+		models = append(models, model)
+	}
+	return models
+}
+
+func validateUnmanagedSolutionComponents(components []UnmanagedSolutionComponentModel) (diags diag.Diagnostics) {
+	seen := map[string]struct{}{}
+	for _, component := range components {
+		if component.ComponentType.IsUnknown() || component.ObjectId.IsUnknown() {
+			continue
+		}
+		key := componentKey(component.ComponentType.ValueInt64(), component.ObjectId.ValueString())
+		if _, exists := seen[key]; exists {
+			diags.AddAttributeError(
+				path.Root("components"),
+				"Duplicate solution component",
+				fmt.Sprintf("Component '%s' of type %d is declared more than once.", component.ObjectId.ValueString(), component.ComponentType.ValueInt64()),
+			)
+			continue
+		}
+		seen[key] = struct{}{}
+	}
+	return diags
+}
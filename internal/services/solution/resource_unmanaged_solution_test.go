@@ -0,0 +1,285 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package solution_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jarcoal/httpmock"
+	"github.com/microsoft/terraform-provider-power-platform/internal/helpers"
+	"github.com/microsoft/terraform-provider-power-platform/internal/mocks"
+)
+
+func TestUnitUnmanagedSolutionResource_Validate_CRUD(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+
+	updated := false
+	addedComponents := map[string]bool{}
+	removedComponents := map[string]bool{}
+
+	httpmock.RegisterResponder("GET", `https://api.bap.microsoft.com/providers/Microsoft.BusinessAppPlatform/scopes/admin/environments/00000000-0000-0000-0000-000000000001?%24expand=permissions%2Cproperties.capacity%2Cproperties%2FbillingPolicy&api-version=2023-06-01`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Unmanaged_Solution_CRUD/get_environment_00000000-0000-0000-0000-000000000001.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions`,
+		func(req *http.Request) (*http.Response, error) {
+			resp := httpmock.NewStringResponse(http.StatusNoContent, "")
+			resp.Header.Add("OData-EntityId", "https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions(5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01)")
+			return resp, nil
+		})
+
+	httpmock.RegisterResponder("PATCH", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions%285b0c7c2d-2f4e-ef11-a317-000d3a4a5a01%29`,
+		func(req *http.Request) (*http.Response, error) {
+			updated = true
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions?%24expand=publisherid&%24filter=solutionid+eq+5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01`,
+		func(req *http.Request) (*http.Response, error) {
+			if updated {
+				return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Unmanaged_Solution_CRUD/get_solution_2.json").String()), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Unmanaged_Solution_CRUD/get_solution_1.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("GET", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutioncomponents?%24filter=_solutionid_value+eq+5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01+and+rootsolutioncomponentid+eq+null&%24orderby=componenttype+asc&%24select=solutioncomponentid%2C_solutionid_value%2Cobjectid%2Ccomponenttype%2Crootcomponentbehavior%2Crootsolutioncomponentid%2Cismetadata%2Ccreatedon%2Cmodifiedon`,
+		func(req *http.Request) (*http.Response, error) {
+			if updated {
+				return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Unmanaged_Solution_CRUD/get_root_components_2.json").String()), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, httpmock.File("tests/resource/Validate_Unmanaged_Solution_CRUD/get_root_components_1.json").String()), nil
+		})
+
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/AddSolutionComponent`,
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			if body["SolutionUniqueName"] != "ContosoCore" || body["AddRequiredComponents"] != false {
+				return httpmock.NewStringResponse(http.StatusBadRequest, ""), nil
+			}
+			addedComponents[fmt.Sprintf("%v_%v_%v", body["ComponentType"], body["ComponentId"], body["DoNotIncludeSubcomponents"])] = true
+			return httpmock.NewStringResponse(http.StatusOK, `{"id":"00000000-0000-0000-0000-000000000000"}`), nil
+		})
+
+	httpmock.RegisterResponder("POST", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/RemoveSolutionComponent`,
+		func(req *http.Request) (*http.Response, error) {
+			body := map[string]any{}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				return nil, err
+			}
+			removedComponents[fmt.Sprintf("%v_%v", body["ComponentType"], body["ComponentId"])] = true
+			return httpmock.NewStringResponse(http.StatusOK, `{"id":"00000000-0000-0000-0000-000000000000"}`), nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://00000000-0000-0000-0000-000000000001\.crm4\.dynamics\.com/api/data/v9\.2/RetrieveDependenciesForUninstall%28SolutionUniqueName=`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusOK, `{"value":[]}`), nil
+		})
+
+	httpmock.RegisterResponder("DELETE", `https://00000000-0000-0000-0000-000000000001.crm4.dynamics.com/api/data/v9.2/solutions%285b0c7c2d-2f4e-ef11-a317-000d3a4a5a01%29`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(http.StatusNoContent, ""), nil
+		})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_unmanaged_solution" "core" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					uniquename     = "ContosoCore"
+					display_name   = "Contoso Core"
+					version        = "1.0.0.0"
+					publisher_id   = "aa47dc6c-bf13-490b-a007-1da95a0d1e3f"
+
+					components = [
+						{
+							component_type = 1
+							object_id      = "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"
+						},
+						{
+							component_type        = 61
+							object_id             = "c81f6d5e-5b3e-ef11-8409-000d3a4a5a01"
+							include_subcomponents = false
+						}
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "id", "5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "uniquename", "ContosoCore"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "display_name", "Contoso Core"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "version", "1.0.0.0"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "publisher_id", "aa47dc6c-bf13-490b-a007-1da95a0d1e3f"),
+					resource.TestCheckNoResourceAttr("powerplatform_unmanaged_solution.core", "description"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "components.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerplatform_unmanaged_solution.core", "components.*", map[string]string{
+						"component_type": "1",
+						"object_id":      "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("powerplatform_unmanaged_solution.core", "components.*", map[string]string{
+						"component_type":        "61",
+						"object_id":             "c81f6d5e-5b3e-ef11-8409-000d3a4a5a01",
+						"include_subcomponents": "false",
+					}),
+					func(_ *terraform.State) error {
+						for _, key := range []string{"1_6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01_false", "61_c81f6d5e-5b3e-ef11-8409-000d3a4a5a01_true"} {
+							if !addedComponents[key] {
+								return fmt.Errorf("expected component '%s' to be added to the solution", key)
+							}
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+				resource "powerplatform_unmanaged_solution" "core" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					uniquename     = "ContosoCore"
+					display_name   = "Contoso Core Platform"
+					version        = "1.1.0.0"
+					publisher_id   = "aa47dc6c-bf13-490b-a007-1da95a0d1e3f"
+					description    = "Core tables and flows of Contoso"
+
+					components = [
+						{
+							component_type = 1
+							object_id      = "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"
+						},
+						{
+							component_type = 29
+							object_id      = "2b7a0e3f-5b3e-ef11-8409-000d3a4a5a01"
+						}
+					]
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "id", "5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "display_name", "Contoso Core Platform"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "version", "1.1.0.0"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "description", "Core tables and flows of Contoso"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.core", "components.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("powerplatform_unmanaged_solution.core", "components.*", map[string]string{
+						"component_type": "29",
+						"object_id":      "2b7a0e3f-5b3e-ef11-8409-000d3a4a5a01",
+					}),
+					func(_ *terraform.State) error {
+						if !removedComponents["61_c81f6d5e-5b3e-ef11-8409-000d3a4a5a01"] {
+							return fmt.Errorf("expected the web resource component to be removed from the solution")
+						}
+						if removedComponents["1_6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"] {
+							return fmt.Errorf("expected the table component to stay in the solution")
+						}
+						if !addedComponents["29_2b7a0e3f-5b3e-ef11-8409-000d3a4a5a01_false"] {
+							return fmt.Errorf("expected the workflow component to be added to the solution")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "powerplatform_unmanaged_solution.core",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"components"},
+				ImportStateId:           "00000000-0000-0000-0000-000000000001_5b0c7c2d-2f4e-ef11-a317-000d3a4a5a01",
+			},
+		},
+	})
+}
+
+func TestUnitUnmanagedSolutionResource_Validate_Duplicate_Components(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	mocks.ActivateEnvironmentHttpMocks()
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest:               true,
+		ProtoV6ProviderFactories: mocks.TestUnitTestProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_unmanaged_solution" "core" {
+					environment_id = "00000000-0000-0000-0000-000000000001"
+					uniquename     = "ContosoCore"
+					display_name   = "Contoso Core"
+					version        = "1.0.0.0"
+					publisher_id   = "aa47dc6c-bf13-490b-a007-1da95a0d1e3f"
+
+					components = [
+						{
+							component_type = 1
+							object_id      = "6f2e9c1a-5b3e-ef11-8409-000d3a4a5a01"
+						},
+						{
+							component_type        = 1
+							object_id             = "6F2E9C1A-5B3E-EF11-8409-000D3A4A5A01"
+							include_subcomponents = false
+						}
+					]
+				}`,
+				ExpectError: regexp.MustCompile(`Duplicate solution component`),
+			},
+		},
+	})
+}
+
+func TestAccUnmanagedSolutionResource_Validate_CRUD(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: mocks.TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "powerplatform_environment" "environment" {
+					display_name     = "` + mocks.TestName() + `"
+					location         = "unitedstates"
+					environment_type = "Sandbox"
+					dataverse = {
+						language_code     = "1033"
+						currency_code     = "USD"
+						security_group_id = "00000000-0000-0000-0000-000000000000"
+					}
+				}
+
+				resource "powerplatform_publisher" "publisher" {
+					environment_id       = powerplatform_environment.environment.id
+					uniquename           = "terraformpublisher"
+					friendly_name        = "Terraform Publisher"
+					customization_prefix = "tfp"
+				}
+
+				resource "powerplatform_unmanaged_solution" "solution" {
+					environment_id = powerplatform_environment.environment.id
+					uniquename     = "TerraformUnmanagedSolution"
+					display_name   = "Terraform Unmanaged Solution"
+					version        = "1.0.0.0"
+					publisher_id   = powerplatform_publisher.publisher.id
+				}`,
+
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("powerplatform_unmanaged_solution.solution", "id", regexp.MustCompile(helpers.GuidRegex)),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.solution", "uniquename", "TerraformUnmanagedSolution"),
+					resource.TestCheckResourceAttr("powerplatform_unmanaged_solution.solution", "version", "1.0.0.0"),
+					resource.TestCheckNoResourceAttr("powerplatform_unmanaged_solution.solution", "components"),
+				),
+			},
+		},
+	})
+}